@@ -0,0 +1,179 @@
+package main
+
+import (
+	"catalog/internal"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPort              = 50051
+	defaultHTTPPort          = 8080
+	defaultQueryTimeout      = 5 * time.Second
+	defaultDrainPeriod       = 5 * time.Second
+	defaultShutdownTimeout   = 30 * time.Second
+	defaultHealthInterval    = 10 * time.Second
+	defaultDictionaryRefresh = 30 * time.Second
+	defaultAvailabilityCheck = 30 * time.Second
+)
+
+// config holds the settings read from the environment (or the .env file).
+type config struct {
+	Port int
+	// HTTPPort serves the REST/JSON gateway, 0 disables it.
+	HTTPPort        int
+	CORS            internal.CORSConfig
+	QueryTimeout    time.Duration
+	ValidationRules internal.ValidationRules
+	// Currency is the ISO 4217 code of base prices and of float prices in the API.
+	Currency string
+	// AdminToken is the bearer token of callers that see unpublished products.
+	AdminToken string
+	// DrainPeriod is how long the server reports NOT_SERVING before it stops
+	// accepting calls, ShutdownTimeout bounds the wait for in-flight calls.
+	DrainPeriod     time.Duration
+	ShutdownTimeout time.Duration
+	// HealthCheckInterval is how often the database is pinged for grpc.health.v1.
+	HealthCheckInterval time.Duration
+	// SearchDictionaryRefresh is how often synonyms and stop words are
+	// reloaded to pick up changes made through other instances.
+	SearchDictionaryRefresh time.Duration
+	// AvailabilityCheck is how often products crossing their availability
+	// window are looked for to publish their changes.
+	AvailabilityCheck time.Duration
+	// IdempotencyTTL is how long AddProduct remembers an idempotency key,
+	// expired keys are purged every IdempotencyPurge.
+	IdempotencyTTL   time.Duration
+	IdempotencyPurge time.Duration
+	// Reflection enables gRPC server reflection for tools like grpcurl.
+	Reflection bool
+}
+
+func loadConfig() (*config, error) {
+	cfg := &config{}
+	var err error
+	if cfg.Port, err = envInt("PORT", defaultPort); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPPort, err = envInt("HTTP_PORT", defaultHTTPPort); err != nil {
+		return nil, err
+	}
+	cfg.CORS.AllowedOrigins = envList("CORS_ALLOWED_ORIGINS")
+	cfg.CORS.AllowedHeaders = envList("CORS_ALLOWED_HEADERS")
+	if cfg.CORS.MaxAge, err = envDuration("CORS_MAX_AGE", 0); err != nil {
+		return nil, err
+	}
+	if cfg.QueryTimeout, err = envDuration("DB_QUERY_TIMEOUT", defaultQueryTimeout); err != nil {
+		return nil, err
+	}
+	if cfg.DrainPeriod, err = envDuration("SHUTDOWN_DRAIN_PERIOD", defaultDrainPeriod); err != nil {
+		return nil, err
+	}
+	if cfg.ShutdownTimeout, err = envDuration("SHUTDOWN_TIMEOUT", defaultShutdownTimeout); err != nil {
+		return nil, err
+	}
+	if cfg.HealthCheckInterval, err = envDuration("HEALTH_CHECK_INTERVAL", defaultHealthInterval); err != nil {
+		return nil, err
+	}
+	if cfg.SearchDictionaryRefresh, err = envDuration("SEARCH_DICTIONARY_REFRESH_INTERVAL", defaultDictionaryRefresh); err != nil {
+		return nil, err
+	}
+	if cfg.AvailabilityCheck, err = envDuration("AVAILABILITY_CHECK_INTERVAL", defaultAvailabilityCheck); err != nil {
+		return nil, err
+	}
+	if cfg.IdempotencyTTL, err = envDuration("IDEMPOTENCY_KEY_TTL", internal.DefaultIdempotencyTTL); err != nil {
+		return nil, err
+	}
+	if cfg.IdempotencyPurge, err = envDuration("IDEMPOTENCY_PURGE_INTERVAL", internal.DefaultIdempotencyPurgeInterval); err != nil {
+		return nil, err
+	}
+	if cfg.Reflection, err = envBool("GRPC_REFLECTION", false); err != nil {
+		return nil, err
+	}
+	if cfg.ValidationRules, err = validationRulesFromEnv(); err != nil {
+		return nil, err
+	}
+	cfg.Currency = internal.DefaultCurrency
+	if v, ok := os.LookupEnv("CATALOG_CURRENCY"); ok {
+		cfg.Currency = strings.ToUpper(strings.TrimSpace(v))
+	}
+	if !internal.ValidCurrency(cfg.Currency) {
+		return nil, fmt.Errorf("invalid CATALOG_CURRENCY: %q", cfg.Currency)
+	}
+	cfg.AdminToken = os.Getenv("CATALOG_ADMIN_TOKEN")
+	return cfg, nil
+}
+
+func envInt(name string, fallback int) (int, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return n, nil
+}
+
+// envList reads a comma separated list, skipping empty items.
+func envList(name string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(name), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func envBool(name string, fallback bool) (bool, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return fallback, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return b, nil
+}
+
+// envDuration reads a time.ParseDuration value such as "5s" or "250ms".
+func envDuration(name string, fallback time.Duration) (time.Duration, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, v)
+	}
+	return d, nil
+}
+
+// validationRulesFromEnv overrides the default product validation limits with
+// PRODUCT_MAX_* and PRODUCT_IMAGE_SCHEMES environment variables.
+func validationRulesFromEnv() (internal.ValidationRules, error) {
+	rules := internal.DefaultValidationRules()
+	limits := map[string]*int{
+		"PRODUCT_MAX_NAME_LENGTH":        &rules.MaxNameLength,
+		"PRODUCT_MAX_SKU_LENGTH":         &rules.MaxSkuLength,
+		"PRODUCT_MAX_DESCRIPTION_LENGTH": &rules.MaxDescriptionLength,
+		"PRODUCT_MAX_IMAGE_URL_LENGTH":   &rules.MaxImageURLLength,
+	}
+	for name, limit := range limits {
+		n, err := envInt(name, *limit)
+		if err != nil || n <= 0 {
+			return rules, fmt.Errorf("invalid %s: %q", name, os.Getenv(name))
+		}
+		*limit = n
+	}
+	if _, ok := os.LookupEnv("PRODUCT_IMAGE_SCHEMES"); ok {
+		rules.ImageSchemes = envList("PRODUCT_IMAGE_SCHEMES")
+	}
+	return rules, nil
+}
@@ -0,0 +1,2015 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: catalog/catalog_service.proto
+
+package catalogv1
+
+import (
+	context "context"
+	catalog "github.com/akolpakov-somehash/headless-ecom-protos/gen/go/catalog"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Catalog_GetProductBySku_FullMethodName        = "/catalog.Catalog/GetProductBySku"
+	Catalog_PatchProduct_FullMethodName           = "/catalog.Catalog/PatchProduct"
+	Catalog_BatchGetProducts_FullMethodName       = "/catalog.Catalog/BatchGetProducts"
+	Catalog_BatchCreateProducts_FullMethodName    = "/catalog.Catalog/BatchCreateProducts"
+	Catalog_BatchUpdateProducts_FullMethodName    = "/catalog.Catalog/BatchUpdateProducts"
+	Catalog_BatchDeleteProducts_FullMethodName    = "/catalog.Catalog/BatchDeleteProducts"
+	Catalog_ListProducts_FullMethodName           = "/catalog.Catalog/ListProducts"
+	Catalog_FacetProducts_FullMethodName          = "/catalog.Catalog/FacetProducts"
+	Catalog_SearchProducts_FullMethodName         = "/catalog.Catalog/SearchProducts"
+	Catalog_SuggestProducts_FullMethodName        = "/catalog.Catalog/SuggestProducts"
+	Catalog_RebuildSearchIndex_FullMethodName     = "/catalog.Catalog/RebuildSearchIndex"
+	Catalog_ExportProducts_FullMethodName         = "/catalog.Catalog/ExportProducts"
+	Catalog_GetProductPrices_FullMethodName       = "/catalog.Catalog/GetProductPrices"
+	Catalog_SetProductPrices_FullMethodName       = "/catalog.Catalog/SetProductPrices"
+	Catalog_GetProductLifecycle_FullMethodName    = "/catalog.Catalog/GetProductLifecycle"
+	Catalog_SetProductLifecycle_FullMethodName    = "/catalog.Catalog/SetProductLifecycle"
+	Catalog_GetProductAvailability_FullMethodName = "/catalog.Catalog/GetProductAvailability"
+	Catalog_SetProductAvailability_FullMethodName = "/catalog.Catalog/SetProductAvailability"
+	Catalog_WatchProductChanges_FullMethodName    = "/catalog.Catalog/WatchProductChanges"
+	Catalog_SetProductOptions_FullMethodName      = "/catalog.Catalog/SetProductOptions"
+	Catalog_CreateVariant_FullMethodName          = "/catalog.Catalog/CreateVariant"
+	Catalog_GetVariant_FullMethodName             = "/catalog.Catalog/GetVariant"
+	Catalog_UpdateVariant_FullMethodName          = "/catalog.Catalog/UpdateVariant"
+	Catalog_DeleteVariant_FullMethodName          = "/catalog.Catalog/DeleteVariant"
+	Catalog_GetProductVariants_FullMethodName     = "/catalog.Catalog/GetProductVariants"
+	Catalog_CreateCategory_FullMethodName         = "/catalog.Catalog/CreateCategory"
+	Catalog_GetCategory_FullMethodName            = "/catalog.Catalog/GetCategory"
+	Catalog_UpdateCategory_FullMethodName         = "/catalog.Catalog/UpdateCategory"
+	Catalog_MoveCategory_FullMethodName           = "/catalog.Catalog/MoveCategory"
+	Catalog_DeleteCategory_FullMethodName         = "/catalog.Catalog/DeleteCategory"
+	Catalog_GetCategoryTree_FullMethodName        = "/catalog.Catalog/GetCategoryTree"
+	Catalog_AssignProducts_FullMethodName         = "/catalog.Catalog/AssignProducts"
+	Catalog_UnassignProducts_FullMethodName       = "/catalog.Catalog/UnassignProducts"
+	Catalog_CreateAttribute_FullMethodName        = "/catalog.Catalog/CreateAttribute"
+	Catalog_GetAttribute_FullMethodName           = "/catalog.Catalog/GetAttribute"
+	Catalog_UpdateAttribute_FullMethodName        = "/catalog.Catalog/UpdateAttribute"
+	Catalog_ListAttributes_FullMethodName         = "/catalog.Catalog/ListAttributes"
+	Catalog_CreateAttributeSet_FullMethodName     = "/catalog.Catalog/CreateAttributeSet"
+	Catalog_GetAttributeSet_FullMethodName        = "/catalog.Catalog/GetAttributeSet"
+	Catalog_UpdateAttributeSet_FullMethodName     = "/catalog.Catalog/UpdateAttributeSet"
+	Catalog_SetProductAttributes_FullMethodName   = "/catalog.Catalog/SetProductAttributes"
+	Catalog_GetProductAttributes_FullMethodName   = "/catalog.Catalog/GetProductAttributes"
+	Catalog_CreateSynonymSet_FullMethodName       = "/catalog.Catalog/CreateSynonymSet"
+	Catalog_GetSynonymSet_FullMethodName          = "/catalog.Catalog/GetSynonymSet"
+	Catalog_UpdateSynonymSet_FullMethodName       = "/catalog.Catalog/UpdateSynonymSet"
+	Catalog_DeleteSynonymSet_FullMethodName       = "/catalog.Catalog/DeleteSynonymSet"
+	Catalog_ListSynonymSets_FullMethodName        = "/catalog.Catalog/ListSynonymSets"
+	Catalog_GetStopWords_FullMethodName           = "/catalog.Catalog/GetStopWords"
+	Catalog_SetStopWords_FullMethodName           = "/catalog.Catalog/SetStopWords"
+)
+
+// CatalogClient is the client API for Catalog service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CatalogClient interface {
+	GetProductBySku(ctx context.Context, in *ProductSku, opts ...grpc.CallOption) (*catalog.Product, error)
+	// Changes the fields of a product listed in update_mask and returns the
+	// product as stored.
+	PatchProduct(ctx context.Context, in *PatchProductRequest, opts ...grpc.CallOption) (*catalog.Product, error)
+	// Looks up to 500 products with one query.
+	BatchGetProducts(ctx context.Context, in *BatchGetProductsRequest, opts ...grpc.CallOption) (*BatchGetProductsResponse, error)
+	// Create, update or delete up to 500 products in one transaction, either
+	// all or nothing or as many as possible.
+	BatchCreateProducts(ctx context.Context, in *BatchCreateProductsRequest, opts ...grpc.CallOption) (*BatchWriteResponse, error)
+	BatchUpdateProducts(ctx context.Context, in *BatchUpdateProductsRequest, opts ...grpc.CallOption) (*BatchWriteResponse, error)
+	BatchDeleteProducts(ctx context.Context, in *BatchDeleteProductsRequest, opts ...grpc.CallOption) (*BatchWriteResponse, error)
+	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	// Lists products like ListProducts together with the number of matching
+	// products per attribute value and price range.
+	FacetProducts(ctx context.Context, in *FacetProductsRequest, opts ...grpc.CallOption) (*FacetProductsResponse, error)
+	// Full-text search with stemming, prefix matching of the last term and
+	// typo tolerance, ranked by field weighted relevance.
+	SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error)
+	// Completes a prefix to product names and SKUs from memory.
+	SuggestProducts(ctx context.Context, in *SuggestProductsRequest, opts ...grpc.CallOption) (*SuggestProductsResponse, error)
+	// Rebuilds the search and suggestion indexes from the database, they keep
+	// being served from the previous indexes meanwhile.
+	RebuildSearchIndex(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*RebuildSearchIndexResponse, error)
+	// Streams the whole catalog ordered by id.
+	ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (Catalog_ExportProductsClient, error)
+	GetProductPrices(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductPrices, error)
+	// Replaces the prices of a product, one per currency. The price in the base
+	// currency updates the base price, which is kept when omitted.
+	SetProductPrices(ctx context.Context, in *ProductPrices, opts ...grpc.CallOption) (*catalog.Empty, error)
+	GetProductLifecycle(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductLifecycle, error)
+	// Changes status and visibility of a product. Drafts can be activated or
+	// archived, active products archived and archived products reactivated.
+	SetProductLifecycle(ctx context.Context, in *ProductLifecycle, opts ...grpc.CallOption) (*catalog.Empty, error)
+	GetProductAvailability(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductAvailability, error)
+	// Replaces the availability window of a product.
+	SetProductAvailability(ctx context.Context, in *ProductAvailability, opts ...grpc.CallOption) (*catalog.Empty, error)
+	// Streams products becoming available or unavailable to the public, when
+	// their availability window opens or closes or their lifecycle or window is
+	// changed. A subscriber falling behind is ended with UNAVAILABLE and should
+	// resubscribe and reload what it caches.
+	WatchProductChanges(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (Catalog_WatchProductChangesClient, error)
+	// Replaces the option axes of a product. Existing variants must still have
+	// exactly one allowed value for every option.
+	SetProductOptions(ctx context.Context, in *ProductOptions, opts ...grpc.CallOption) (*catalog.Empty, error)
+	CreateVariant(ctx context.Context, in *Variant, opts ...grpc.CallOption) (*VariantId, error)
+	GetVariant(ctx context.Context, in *VariantId, opts ...grpc.CallOption) (*Variant, error)
+	// Updates a variant, product_id is ignored.
+	UpdateVariant(ctx context.Context, in *Variant, opts ...grpc.CallOption) (*catalog.Empty, error)
+	DeleteVariant(ctx context.Context, in *VariantId, opts ...grpc.CallOption) (*catalog.Empty, error)
+	// Returns a product together with its options and variants.
+	GetProductVariants(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductVariants, error)
+	CreateCategory(ctx context.Context, in *Category, opts ...grpc.CallOption) (*CategoryId, error)
+	GetCategory(ctx context.Context, in *CategoryId, opts ...grpc.CallOption) (*Category, error)
+	// Updates name, slug and position of a category.
+	UpdateCategory(ctx context.Context, in *Category, opts ...grpc.CallOption) (*catalog.Empty, error)
+	// Moves a category together with its subtree.
+	MoveCategory(ctx context.Context, in *MoveCategoryRequest, opts ...grpc.CallOption) (*catalog.Empty, error)
+	// Deletes a category with its subtree. Products are only unassigned.
+	DeleteCategory(ctx context.Context, in *CategoryId, opts ...grpc.CallOption) (*catalog.Empty, error)
+	// Returns all root categories with their descendants nested.
+	GetCategoryTree(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*CategoryTree, error)
+	AssignProducts(ctx context.Context, in *CategoryProducts, opts ...grpc.CallOption) (*catalog.Empty, error)
+	UnassignProducts(ctx context.Context, in *CategoryProducts, opts ...grpc.CallOption) (*catalog.Empty, error)
+	CreateAttribute(ctx context.Context, in *Attribute, opts ...grpc.CallOption) (*AttributeId, error)
+	GetAttribute(ctx context.Context, in *AttributeId, opts ...grpc.CallOption) (*Attribute, error)
+	// Enum options still used by products cannot be removed.
+	UpdateAttribute(ctx context.Context, in *Attribute, opts ...grpc.CallOption) (*catalog.Empty, error)
+	ListAttributes(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*AttributeList, error)
+	CreateAttributeSet(ctx context.Context, in *AttributeSet, opts ...grpc.CallOption) (*AttributeSetId, error)
+	GetAttributeSet(ctx context.Context, in *AttributeSetId, opts ...grpc.CallOption) (*AttributeSet, error)
+	UpdateAttributeSet(ctx context.Context, in *AttributeSet, opts ...grpc.CallOption) (*catalog.Empty, error)
+	// Replaces the attribute set and all attribute values of a product. Values
+	// are validated against the attributes of the set.
+	SetProductAttributes(ctx context.Context, in *ProductAttributes, opts ...grpc.CallOption) (*catalog.Empty, error)
+	GetProductAttributes(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductAttributes, error)
+	// Synonyms and stop words are applied to SearchProducts queries. Changes
+	// take effect right away on this instance and within the refresh interval
+	// on the others.
+	CreateSynonymSet(ctx context.Context, in *SynonymSet, opts ...grpc.CallOption) (*SynonymSetId, error)
+	GetSynonymSet(ctx context.Context, in *SynonymSetId, opts ...grpc.CallOption) (*SynonymSet, error)
+	UpdateSynonymSet(ctx context.Context, in *SynonymSet, opts ...grpc.CallOption) (*catalog.Empty, error)
+	DeleteSynonymSet(ctx context.Context, in *SynonymSetId, opts ...grpc.CallOption) (*catalog.Empty, error)
+	ListSynonymSets(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*SynonymSetList, error)
+	GetStopWords(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*StopWords, error)
+	// Replaces all stop words.
+	SetStopWords(ctx context.Context, in *StopWords, opts ...grpc.CallOption) (*catalog.Empty, error)
+}
+
+type catalogClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogClient(cc grpc.ClientConnInterface) CatalogClient {
+	return &catalogClient{cc}
+}
+
+func (c *catalogClient) GetProductBySku(ctx context.Context, in *ProductSku, opts ...grpc.CallOption) (*catalog.Product, error) {
+	out := new(catalog.Product)
+	err := c.cc.Invoke(ctx, Catalog_GetProductBySku_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) PatchProduct(ctx context.Context, in *PatchProductRequest, opts ...grpc.CallOption) (*catalog.Product, error) {
+	out := new(catalog.Product)
+	err := c.cc.Invoke(ctx, Catalog_PatchProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) BatchGetProducts(ctx context.Context, in *BatchGetProductsRequest, opts ...grpc.CallOption) (*BatchGetProductsResponse, error) {
+	out := new(BatchGetProductsResponse)
+	err := c.cc.Invoke(ctx, Catalog_BatchGetProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) BatchCreateProducts(ctx context.Context, in *BatchCreateProductsRequest, opts ...grpc.CallOption) (*BatchWriteResponse, error) {
+	out := new(BatchWriteResponse)
+	err := c.cc.Invoke(ctx, Catalog_BatchCreateProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) BatchUpdateProducts(ctx context.Context, in *BatchUpdateProductsRequest, opts ...grpc.CallOption) (*BatchWriteResponse, error) {
+	out := new(BatchWriteResponse)
+	err := c.cc.Invoke(ctx, Catalog_BatchUpdateProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) BatchDeleteProducts(ctx context.Context, in *BatchDeleteProductsRequest, opts ...grpc.CallOption) (*BatchWriteResponse, error) {
+	out := new(BatchWriteResponse)
+	err := c.cc.Invoke(ctx, Catalog_BatchDeleteProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, Catalog_ListProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) FacetProducts(ctx context.Context, in *FacetProductsRequest, opts ...grpc.CallOption) (*FacetProductsResponse, error) {
+	out := new(FacetProductsResponse)
+	err := c.cc.Invoke(ctx, Catalog_FacetProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error) {
+	out := new(SearchProductsResponse)
+	err := c.cc.Invoke(ctx, Catalog_SearchProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SuggestProducts(ctx context.Context, in *SuggestProductsRequest, opts ...grpc.CallOption) (*SuggestProductsResponse, error) {
+	out := new(SuggestProductsResponse)
+	err := c.cc.Invoke(ctx, Catalog_SuggestProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) RebuildSearchIndex(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*RebuildSearchIndexResponse, error) {
+	out := new(RebuildSearchIndexResponse)
+	err := c.cc.Invoke(ctx, Catalog_RebuildSearchIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (Catalog_ExportProductsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Catalog_ServiceDesc.Streams[0], Catalog_ExportProducts_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogExportProductsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Catalog_ExportProductsClient interface {
+	Recv() (*catalog.Product, error)
+	grpc.ClientStream
+}
+
+type catalogExportProductsClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogExportProductsClient) Recv() (*catalog.Product, error) {
+	m := new(catalog.Product)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *catalogClient) GetProductPrices(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductPrices, error) {
+	out := new(ProductPrices)
+	err := c.cc.Invoke(ctx, Catalog_GetProductPrices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SetProductPrices(ctx context.Context, in *ProductPrices, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_SetProductPrices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetProductLifecycle(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductLifecycle, error) {
+	out := new(ProductLifecycle)
+	err := c.cc.Invoke(ctx, Catalog_GetProductLifecycle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SetProductLifecycle(ctx context.Context, in *ProductLifecycle, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_SetProductLifecycle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetProductAvailability(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductAvailability, error) {
+	out := new(ProductAvailability)
+	err := c.cc.Invoke(ctx, Catalog_GetProductAvailability_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SetProductAvailability(ctx context.Context, in *ProductAvailability, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_SetProductAvailability_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) WatchProductChanges(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (Catalog_WatchProductChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Catalog_ServiceDesc.Streams[1], Catalog_WatchProductChanges_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogWatchProductChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Catalog_WatchProductChangesClient interface {
+	Recv() (*ProductChange, error)
+	grpc.ClientStream
+}
+
+type catalogWatchProductChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogWatchProductChangesClient) Recv() (*ProductChange, error) {
+	m := new(ProductChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *catalogClient) SetProductOptions(ctx context.Context, in *ProductOptions, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_SetProductOptions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) CreateVariant(ctx context.Context, in *Variant, opts ...grpc.CallOption) (*VariantId, error) {
+	out := new(VariantId)
+	err := c.cc.Invoke(ctx, Catalog_CreateVariant_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetVariant(ctx context.Context, in *VariantId, opts ...grpc.CallOption) (*Variant, error) {
+	out := new(Variant)
+	err := c.cc.Invoke(ctx, Catalog_GetVariant_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) UpdateVariant(ctx context.Context, in *Variant, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_UpdateVariant_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) DeleteVariant(ctx context.Context, in *VariantId, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_DeleteVariant_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetProductVariants(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductVariants, error) {
+	out := new(ProductVariants)
+	err := c.cc.Invoke(ctx, Catalog_GetProductVariants_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) CreateCategory(ctx context.Context, in *Category, opts ...grpc.CallOption) (*CategoryId, error) {
+	out := new(CategoryId)
+	err := c.cc.Invoke(ctx, Catalog_CreateCategory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetCategory(ctx context.Context, in *CategoryId, opts ...grpc.CallOption) (*Category, error) {
+	out := new(Category)
+	err := c.cc.Invoke(ctx, Catalog_GetCategory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) UpdateCategory(ctx context.Context, in *Category, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_UpdateCategory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) MoveCategory(ctx context.Context, in *MoveCategoryRequest, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_MoveCategory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) DeleteCategory(ctx context.Context, in *CategoryId, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_DeleteCategory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetCategoryTree(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*CategoryTree, error) {
+	out := new(CategoryTree)
+	err := c.cc.Invoke(ctx, Catalog_GetCategoryTree_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) AssignProducts(ctx context.Context, in *CategoryProducts, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_AssignProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) UnassignProducts(ctx context.Context, in *CategoryProducts, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_UnassignProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) CreateAttribute(ctx context.Context, in *Attribute, opts ...grpc.CallOption) (*AttributeId, error) {
+	out := new(AttributeId)
+	err := c.cc.Invoke(ctx, Catalog_CreateAttribute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetAttribute(ctx context.Context, in *AttributeId, opts ...grpc.CallOption) (*Attribute, error) {
+	out := new(Attribute)
+	err := c.cc.Invoke(ctx, Catalog_GetAttribute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) UpdateAttribute(ctx context.Context, in *Attribute, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_UpdateAttribute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ListAttributes(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*AttributeList, error) {
+	out := new(AttributeList)
+	err := c.cc.Invoke(ctx, Catalog_ListAttributes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) CreateAttributeSet(ctx context.Context, in *AttributeSet, opts ...grpc.CallOption) (*AttributeSetId, error) {
+	out := new(AttributeSetId)
+	err := c.cc.Invoke(ctx, Catalog_CreateAttributeSet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetAttributeSet(ctx context.Context, in *AttributeSetId, opts ...grpc.CallOption) (*AttributeSet, error) {
+	out := new(AttributeSet)
+	err := c.cc.Invoke(ctx, Catalog_GetAttributeSet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) UpdateAttributeSet(ctx context.Context, in *AttributeSet, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_UpdateAttributeSet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SetProductAttributes(ctx context.Context, in *ProductAttributes, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_SetProductAttributes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetProductAttributes(ctx context.Context, in *catalog.ProductId, opts ...grpc.CallOption) (*ProductAttributes, error) {
+	out := new(ProductAttributes)
+	err := c.cc.Invoke(ctx, Catalog_GetProductAttributes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) CreateSynonymSet(ctx context.Context, in *SynonymSet, opts ...grpc.CallOption) (*SynonymSetId, error) {
+	out := new(SynonymSetId)
+	err := c.cc.Invoke(ctx, Catalog_CreateSynonymSet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetSynonymSet(ctx context.Context, in *SynonymSetId, opts ...grpc.CallOption) (*SynonymSet, error) {
+	out := new(SynonymSet)
+	err := c.cc.Invoke(ctx, Catalog_GetSynonymSet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) UpdateSynonymSet(ctx context.Context, in *SynonymSet, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_UpdateSynonymSet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) DeleteSynonymSet(ctx context.Context, in *SynonymSetId, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_DeleteSynonymSet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ListSynonymSets(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*SynonymSetList, error) {
+	out := new(SynonymSetList)
+	err := c.cc.Invoke(ctx, Catalog_ListSynonymSets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetStopWords(ctx context.Context, in *catalog.Empty, opts ...grpc.CallOption) (*StopWords, error) {
+	out := new(StopWords)
+	err := c.cc.Invoke(ctx, Catalog_GetStopWords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SetStopWords(ctx context.Context, in *StopWords, opts ...grpc.CallOption) (*catalog.Empty, error) {
+	out := new(catalog.Empty)
+	err := c.cc.Invoke(ctx, Catalog_SetStopWords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatalogServer is the server API for Catalog service.
+// All implementations must embed UnimplementedCatalogServer
+// for forward compatibility
+type CatalogServer interface {
+	GetProductBySku(context.Context, *ProductSku) (*catalog.Product, error)
+	// Changes the fields of a product listed in update_mask and returns the
+	// product as stored.
+	PatchProduct(context.Context, *PatchProductRequest) (*catalog.Product, error)
+	// Looks up to 500 products with one query.
+	BatchGetProducts(context.Context, *BatchGetProductsRequest) (*BatchGetProductsResponse, error)
+	// Create, update or delete up to 500 products in one transaction, either
+	// all or nothing or as many as possible.
+	BatchCreateProducts(context.Context, *BatchCreateProductsRequest) (*BatchWriteResponse, error)
+	BatchUpdateProducts(context.Context, *BatchUpdateProductsRequest) (*BatchWriteResponse, error)
+	BatchDeleteProducts(context.Context, *BatchDeleteProductsRequest) (*BatchWriteResponse, error)
+	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	// Lists products like ListProducts together with the number of matching
+	// products per attribute value and price range.
+	FacetProducts(context.Context, *FacetProductsRequest) (*FacetProductsResponse, error)
+	// Full-text search with stemming, prefix matching of the last term and
+	// typo tolerance, ranked by field weighted relevance.
+	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
+	// Completes a prefix to product names and SKUs from memory.
+	SuggestProducts(context.Context, *SuggestProductsRequest) (*SuggestProductsResponse, error)
+	// Rebuilds the search and suggestion indexes from the database, they keep
+	// being served from the previous indexes meanwhile.
+	RebuildSearchIndex(context.Context, *catalog.Empty) (*RebuildSearchIndexResponse, error)
+	// Streams the whole catalog ordered by id.
+	ExportProducts(*ExportProductsRequest, Catalog_ExportProductsServer) error
+	GetProductPrices(context.Context, *catalog.ProductId) (*ProductPrices, error)
+	// Replaces the prices of a product, one per currency. The price in the base
+	// currency updates the base price, which is kept when omitted.
+	SetProductPrices(context.Context, *ProductPrices) (*catalog.Empty, error)
+	GetProductLifecycle(context.Context, *catalog.ProductId) (*ProductLifecycle, error)
+	// Changes status and visibility of a product. Drafts can be activated or
+	// archived, active products archived and archived products reactivated.
+	SetProductLifecycle(context.Context, *ProductLifecycle) (*catalog.Empty, error)
+	GetProductAvailability(context.Context, *catalog.ProductId) (*ProductAvailability, error)
+	// Replaces the availability window of a product.
+	SetProductAvailability(context.Context, *ProductAvailability) (*catalog.Empty, error)
+	// Streams products becoming available or unavailable to the public, when
+	// their availability window opens or closes or their lifecycle or window is
+	// changed. A subscriber falling behind is ended with UNAVAILABLE and should
+	// resubscribe and reload what it caches.
+	WatchProductChanges(*catalog.Empty, Catalog_WatchProductChangesServer) error
+	// Replaces the option axes of a product. Existing variants must still have
+	// exactly one allowed value for every option.
+	SetProductOptions(context.Context, *ProductOptions) (*catalog.Empty, error)
+	CreateVariant(context.Context, *Variant) (*VariantId, error)
+	GetVariant(context.Context, *VariantId) (*Variant, error)
+	// Updates a variant, product_id is ignored.
+	UpdateVariant(context.Context, *Variant) (*catalog.Empty, error)
+	DeleteVariant(context.Context, *VariantId) (*catalog.Empty, error)
+	// Returns a product together with its options and variants.
+	GetProductVariants(context.Context, *catalog.ProductId) (*ProductVariants, error)
+	CreateCategory(context.Context, *Category) (*CategoryId, error)
+	GetCategory(context.Context, *CategoryId) (*Category, error)
+	// Updates name, slug and position of a category.
+	UpdateCategory(context.Context, *Category) (*catalog.Empty, error)
+	// Moves a category together with its subtree.
+	MoveCategory(context.Context, *MoveCategoryRequest) (*catalog.Empty, error)
+	// Deletes a category with its subtree. Products are only unassigned.
+	DeleteCategory(context.Context, *CategoryId) (*catalog.Empty, error)
+	// Returns all root categories with their descendants nested.
+	GetCategoryTree(context.Context, *catalog.Empty) (*CategoryTree, error)
+	AssignProducts(context.Context, *CategoryProducts) (*catalog.Empty, error)
+	UnassignProducts(context.Context, *CategoryProducts) (*catalog.Empty, error)
+	CreateAttribute(context.Context, *Attribute) (*AttributeId, error)
+	GetAttribute(context.Context, *AttributeId) (*Attribute, error)
+	// Enum options still used by products cannot be removed.
+	UpdateAttribute(context.Context, *Attribute) (*catalog.Empty, error)
+	ListAttributes(context.Context, *catalog.Empty) (*AttributeList, error)
+	CreateAttributeSet(context.Context, *AttributeSet) (*AttributeSetId, error)
+	GetAttributeSet(context.Context, *AttributeSetId) (*AttributeSet, error)
+	UpdateAttributeSet(context.Context, *AttributeSet) (*catalog.Empty, error)
+	// Replaces the attribute set and all attribute values of a product. Values
+	// are validated against the attributes of the set.
+	SetProductAttributes(context.Context, *ProductAttributes) (*catalog.Empty, error)
+	GetProductAttributes(context.Context, *catalog.ProductId) (*ProductAttributes, error)
+	// Synonyms and stop words are applied to SearchProducts queries. Changes
+	// take effect right away on this instance and within the refresh interval
+	// on the others.
+	CreateSynonymSet(context.Context, *SynonymSet) (*SynonymSetId, error)
+	GetSynonymSet(context.Context, *SynonymSetId) (*SynonymSet, error)
+	UpdateSynonymSet(context.Context, *SynonymSet) (*catalog.Empty, error)
+	DeleteSynonymSet(context.Context, *SynonymSetId) (*catalog.Empty, error)
+	ListSynonymSets(context.Context, *catalog.Empty) (*SynonymSetList, error)
+	GetStopWords(context.Context, *catalog.Empty) (*StopWords, error)
+	// Replaces all stop words.
+	SetStopWords(context.Context, *StopWords) (*catalog.Empty, error)
+	mustEmbedUnimplementedCatalogServer()
+}
+
+// UnimplementedCatalogServer must be embedded to have forward compatible implementations.
+type UnimplementedCatalogServer struct {
+}
+
+func (UnimplementedCatalogServer) GetProductBySku(context.Context, *ProductSku) (*catalog.Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductBySku not implemented")
+}
+func (UnimplementedCatalogServer) PatchProduct(context.Context, *PatchProductRequest) (*catalog.Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchProduct not implemented")
+}
+func (UnimplementedCatalogServer) BatchGetProducts(context.Context, *BatchGetProductsRequest) (*BatchGetProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetProducts not implemented")
+}
+func (UnimplementedCatalogServer) BatchCreateProducts(context.Context, *BatchCreateProductsRequest) (*BatchWriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateProducts not implemented")
+}
+func (UnimplementedCatalogServer) BatchUpdateProducts(context.Context, *BatchUpdateProductsRequest) (*BatchWriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateProducts not implemented")
+}
+func (UnimplementedCatalogServer) BatchDeleteProducts(context.Context, *BatchDeleteProductsRequest) (*BatchWriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteProducts not implemented")
+}
+func (UnimplementedCatalogServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedCatalogServer) FacetProducts(context.Context, *FacetProductsRequest) (*FacetProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FacetProducts not implemented")
+}
+func (UnimplementedCatalogServer) SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchProducts not implemented")
+}
+func (UnimplementedCatalogServer) SuggestProducts(context.Context, *SuggestProductsRequest) (*SuggestProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestProducts not implemented")
+}
+func (UnimplementedCatalogServer) RebuildSearchIndex(context.Context, *catalog.Empty) (*RebuildSearchIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildSearchIndex not implemented")
+}
+func (UnimplementedCatalogServer) ExportProducts(*ExportProductsRequest, Catalog_ExportProductsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportProducts not implemented")
+}
+func (UnimplementedCatalogServer) GetProductPrices(context.Context, *catalog.ProductId) (*ProductPrices, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductPrices not implemented")
+}
+func (UnimplementedCatalogServer) SetProductPrices(context.Context, *ProductPrices) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductPrices not implemented")
+}
+func (UnimplementedCatalogServer) GetProductLifecycle(context.Context, *catalog.ProductId) (*ProductLifecycle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductLifecycle not implemented")
+}
+func (UnimplementedCatalogServer) SetProductLifecycle(context.Context, *ProductLifecycle) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductLifecycle not implemented")
+}
+func (UnimplementedCatalogServer) GetProductAvailability(context.Context, *catalog.ProductId) (*ProductAvailability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductAvailability not implemented")
+}
+func (UnimplementedCatalogServer) SetProductAvailability(context.Context, *ProductAvailability) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductAvailability not implemented")
+}
+func (UnimplementedCatalogServer) WatchProductChanges(*catalog.Empty, Catalog_WatchProductChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchProductChanges not implemented")
+}
+func (UnimplementedCatalogServer) SetProductOptions(context.Context, *ProductOptions) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductOptions not implemented")
+}
+func (UnimplementedCatalogServer) CreateVariant(context.Context, *Variant) (*VariantId, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVariant not implemented")
+}
+func (UnimplementedCatalogServer) GetVariant(context.Context, *VariantId) (*Variant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVariant not implemented")
+}
+func (UnimplementedCatalogServer) UpdateVariant(context.Context, *Variant) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVariant not implemented")
+}
+func (UnimplementedCatalogServer) DeleteVariant(context.Context, *VariantId) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVariant not implemented")
+}
+func (UnimplementedCatalogServer) GetProductVariants(context.Context, *catalog.ProductId) (*ProductVariants, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductVariants not implemented")
+}
+func (UnimplementedCatalogServer) CreateCategory(context.Context, *Category) (*CategoryId, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedCatalogServer) GetCategory(context.Context, *CategoryId) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategory not implemented")
+}
+func (UnimplementedCatalogServer) UpdateCategory(context.Context, *Category) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCategory not implemented")
+}
+func (UnimplementedCatalogServer) MoveCategory(context.Context, *MoveCategoryRequest) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCategory not implemented")
+}
+func (UnimplementedCatalogServer) DeleteCategory(context.Context, *CategoryId) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+}
+func (UnimplementedCatalogServer) GetCategoryTree(context.Context, *catalog.Empty) (*CategoryTree, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategoryTree not implemented")
+}
+func (UnimplementedCatalogServer) AssignProducts(context.Context, *CategoryProducts) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignProducts not implemented")
+}
+func (UnimplementedCatalogServer) UnassignProducts(context.Context, *CategoryProducts) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnassignProducts not implemented")
+}
+func (UnimplementedCatalogServer) CreateAttribute(context.Context, *Attribute) (*AttributeId, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttribute not implemented")
+}
+func (UnimplementedCatalogServer) GetAttribute(context.Context, *AttributeId) (*Attribute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttribute not implemented")
+}
+func (UnimplementedCatalogServer) UpdateAttribute(context.Context, *Attribute) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAttribute not implemented")
+}
+func (UnimplementedCatalogServer) ListAttributes(context.Context, *catalog.Empty) (*AttributeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttributes not implemented")
+}
+func (UnimplementedCatalogServer) CreateAttributeSet(context.Context, *AttributeSet) (*AttributeSetId, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttributeSet not implemented")
+}
+func (UnimplementedCatalogServer) GetAttributeSet(context.Context, *AttributeSetId) (*AttributeSet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttributeSet not implemented")
+}
+func (UnimplementedCatalogServer) UpdateAttributeSet(context.Context, *AttributeSet) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAttributeSet not implemented")
+}
+func (UnimplementedCatalogServer) SetProductAttributes(context.Context, *ProductAttributes) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductAttributes not implemented")
+}
+func (UnimplementedCatalogServer) GetProductAttributes(context.Context, *catalog.ProductId) (*ProductAttributes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductAttributes not implemented")
+}
+func (UnimplementedCatalogServer) CreateSynonymSet(context.Context, *SynonymSet) (*SynonymSetId, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSynonymSet not implemented")
+}
+func (UnimplementedCatalogServer) GetSynonymSet(context.Context, *SynonymSetId) (*SynonymSet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSynonymSet not implemented")
+}
+func (UnimplementedCatalogServer) UpdateSynonymSet(context.Context, *SynonymSet) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSynonymSet not implemented")
+}
+func (UnimplementedCatalogServer) DeleteSynonymSet(context.Context, *SynonymSetId) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSynonymSet not implemented")
+}
+func (UnimplementedCatalogServer) ListSynonymSets(context.Context, *catalog.Empty) (*SynonymSetList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSynonymSets not implemented")
+}
+func (UnimplementedCatalogServer) GetStopWords(context.Context, *catalog.Empty) (*StopWords, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStopWords not implemented")
+}
+func (UnimplementedCatalogServer) SetStopWords(context.Context, *StopWords) (*catalog.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStopWords not implemented")
+}
+func (UnimplementedCatalogServer) mustEmbedUnimplementedCatalogServer() {}
+
+// UnsafeCatalogServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogServer will
+// result in compilation errors.
+type UnsafeCatalogServer interface {
+	mustEmbedUnimplementedCatalogServer()
+}
+
+func RegisterCatalogServer(s grpc.ServiceRegistrar, srv CatalogServer) {
+	s.RegisterService(&Catalog_ServiceDesc, srv)
+}
+
+func _Catalog_GetProductBySku_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductSku)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetProductBySku(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetProductBySku_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetProductBySku(ctx, req.(*ProductSku))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_PatchProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).PatchProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_PatchProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).PatchProduct(ctx, req.(*PatchProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_BatchGetProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).BatchGetProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_BatchGetProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).BatchGetProducts(ctx, req.(*BatchGetProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_BatchCreateProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).BatchCreateProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_BatchCreateProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).BatchCreateProducts(ctx, req.(*BatchCreateProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_BatchUpdateProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).BatchUpdateProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_BatchUpdateProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).BatchUpdateProducts(ctx, req.(*BatchUpdateProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_BatchDeleteProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).BatchDeleteProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_BatchDeleteProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).BatchDeleteProducts(ctx, req.(*BatchDeleteProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ListProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ListProducts(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_FacetProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FacetProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).FacetProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_FacetProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).FacetProducts(ctx, req.(*FacetProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).SearchProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_SearchProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).SearchProducts(ctx, req.(*SearchProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_SuggestProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).SuggestProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_SuggestProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).SuggestProducts(ctx, req.(*SuggestProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_RebuildSearchIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).RebuildSearchIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_RebuildSearchIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).RebuildSearchIndex(ctx, req.(*catalog.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ExportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServer).ExportProducts(m, &catalogExportProductsServer{stream})
+}
+
+type Catalog_ExportProductsServer interface {
+	Send(*catalog.Product) error
+	grpc.ServerStream
+}
+
+type catalogExportProductsServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogExportProductsServer) Send(m *catalog.Product) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Catalog_GetProductPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.ProductId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetProductPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetProductPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetProductPrices(ctx, req.(*catalog.ProductId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_SetProductPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductPrices)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).SetProductPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_SetProductPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).SetProductPrices(ctx, req.(*ProductPrices))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetProductLifecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.ProductId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetProductLifecycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetProductLifecycle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetProductLifecycle(ctx, req.(*catalog.ProductId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_SetProductLifecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductLifecycle)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).SetProductLifecycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_SetProductLifecycle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).SetProductLifecycle(ctx, req.(*ProductLifecycle))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetProductAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.ProductId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetProductAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetProductAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetProductAvailability(ctx, req.(*catalog.ProductId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_SetProductAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductAvailability)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).SetProductAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_SetProductAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).SetProductAvailability(ctx, req.(*ProductAvailability))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_WatchProductChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(catalog.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServer).WatchProductChanges(m, &catalogWatchProductChangesServer{stream})
+}
+
+type Catalog_WatchProductChangesServer interface {
+	Send(*ProductChange) error
+	grpc.ServerStream
+}
+
+type catalogWatchProductChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogWatchProductChangesServer) Send(m *ProductChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Catalog_SetProductOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).SetProductOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_SetProductOptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).SetProductOptions(ctx, req.(*ProductOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_CreateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Variant)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).CreateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_CreateVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).CreateVariant(ctx, req.(*Variant))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariantId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetVariant(ctx, req.(*VariantId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_UpdateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Variant)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).UpdateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_UpdateVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).UpdateVariant(ctx, req.(*Variant))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_DeleteVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariantId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).DeleteVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_DeleteVariant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).DeleteVariant(ctx, req.(*VariantId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetProductVariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.ProductId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetProductVariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetProductVariants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetProductVariants(ctx, req.(*catalog.ProductId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Category)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_CreateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).CreateCategory(ctx, req.(*Category))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetCategory(ctx, req.(*CategoryId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_UpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Category)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).UpdateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_UpdateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).UpdateCategory(ctx, req.(*Category))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_MoveCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).MoveCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_MoveCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).MoveCategory(ctx, req.(*MoveCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).DeleteCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_DeleteCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).DeleteCategory(ctx, req.(*CategoryId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetCategoryTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetCategoryTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetCategoryTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetCategoryTree(ctx, req.(*catalog.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_AssignProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryProducts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).AssignProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_AssignProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).AssignProducts(ctx, req.(*CategoryProducts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_UnassignProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryProducts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).UnassignProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_UnassignProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).UnassignProducts(ctx, req.(*CategoryProducts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_CreateAttribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Attribute)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).CreateAttribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_CreateAttribute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).CreateAttribute(ctx, req.(*Attribute))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetAttribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttributeId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetAttribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetAttribute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetAttribute(ctx, req.(*AttributeId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_UpdateAttribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Attribute)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).UpdateAttribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_UpdateAttribute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).UpdateAttribute(ctx, req.(*Attribute))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ListAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ListAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ListAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ListAttributes(ctx, req.(*catalog.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_CreateAttributeSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttributeSet)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).CreateAttributeSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_CreateAttributeSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).CreateAttributeSet(ctx, req.(*AttributeSet))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetAttributeSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttributeSetId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetAttributeSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetAttributeSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetAttributeSet(ctx, req.(*AttributeSetId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_UpdateAttributeSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttributeSet)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).UpdateAttributeSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_UpdateAttributeSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).UpdateAttributeSet(ctx, req.(*AttributeSet))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_SetProductAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductAttributes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).SetProductAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_SetProductAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).SetProductAttributes(ctx, req.(*ProductAttributes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetProductAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.ProductId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetProductAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetProductAttributes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetProductAttributes(ctx, req.(*catalog.ProductId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_CreateSynonymSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynonymSet)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).CreateSynonymSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_CreateSynonymSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).CreateSynonymSet(ctx, req.(*SynonymSet))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetSynonymSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynonymSetId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetSynonymSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetSynonymSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetSynonymSet(ctx, req.(*SynonymSetId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_UpdateSynonymSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynonymSet)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).UpdateSynonymSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_UpdateSynonymSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).UpdateSynonymSet(ctx, req.(*SynonymSet))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_DeleteSynonymSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynonymSetId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).DeleteSynonymSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_DeleteSynonymSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).DeleteSynonymSet(ctx, req.(*SynonymSetId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ListSynonymSets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ListSynonymSets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ListSynonymSets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ListSynonymSets(ctx, req.(*catalog.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetStopWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(catalog.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetStopWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetStopWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetStopWords(ctx, req.(*catalog.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_SetStopWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopWords)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).SetStopWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_SetStopWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).SetStopWords(ctx, req.(*StopWords))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Catalog_ServiceDesc is the grpc.ServiceDesc for Catalog service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Catalog_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "catalog.Catalog",
+	HandlerType: (*CatalogServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProductBySku",
+			Handler:    _Catalog_GetProductBySku_Handler,
+		},
+		{
+			MethodName: "PatchProduct",
+			Handler:    _Catalog_PatchProduct_Handler,
+		},
+		{
+			MethodName: "BatchGetProducts",
+			Handler:    _Catalog_BatchGetProducts_Handler,
+		},
+		{
+			MethodName: "BatchCreateProducts",
+			Handler:    _Catalog_BatchCreateProducts_Handler,
+		},
+		{
+			MethodName: "BatchUpdateProducts",
+			Handler:    _Catalog_BatchUpdateProducts_Handler,
+		},
+		{
+			MethodName: "BatchDeleteProducts",
+			Handler:    _Catalog_BatchDeleteProducts_Handler,
+		},
+		{
+			MethodName: "ListProducts",
+			Handler:    _Catalog_ListProducts_Handler,
+		},
+		{
+			MethodName: "FacetProducts",
+			Handler:    _Catalog_FacetProducts_Handler,
+		},
+		{
+			MethodName: "SearchProducts",
+			Handler:    _Catalog_SearchProducts_Handler,
+		},
+		{
+			MethodName: "SuggestProducts",
+			Handler:    _Catalog_SuggestProducts_Handler,
+		},
+		{
+			MethodName: "RebuildSearchIndex",
+			Handler:    _Catalog_RebuildSearchIndex_Handler,
+		},
+		{
+			MethodName: "GetProductPrices",
+			Handler:    _Catalog_GetProductPrices_Handler,
+		},
+		{
+			MethodName: "SetProductPrices",
+			Handler:    _Catalog_SetProductPrices_Handler,
+		},
+		{
+			MethodName: "GetProductLifecycle",
+			Handler:    _Catalog_GetProductLifecycle_Handler,
+		},
+		{
+			MethodName: "SetProductLifecycle",
+			Handler:    _Catalog_SetProductLifecycle_Handler,
+		},
+		{
+			MethodName: "GetProductAvailability",
+			Handler:    _Catalog_GetProductAvailability_Handler,
+		},
+		{
+			MethodName: "SetProductAvailability",
+			Handler:    _Catalog_SetProductAvailability_Handler,
+		},
+		{
+			MethodName: "SetProductOptions",
+			Handler:    _Catalog_SetProductOptions_Handler,
+		},
+		{
+			MethodName: "CreateVariant",
+			Handler:    _Catalog_CreateVariant_Handler,
+		},
+		{
+			MethodName: "GetVariant",
+			Handler:    _Catalog_GetVariant_Handler,
+		},
+		{
+			MethodName: "UpdateVariant",
+			Handler:    _Catalog_UpdateVariant_Handler,
+		},
+		{
+			MethodName: "DeleteVariant",
+			Handler:    _Catalog_DeleteVariant_Handler,
+		},
+		{
+			MethodName: "GetProductVariants",
+			Handler:    _Catalog_GetProductVariants_Handler,
+		},
+		{
+			MethodName: "CreateCategory",
+			Handler:    _Catalog_CreateCategory_Handler,
+		},
+		{
+			MethodName: "GetCategory",
+			Handler:    _Catalog_GetCategory_Handler,
+		},
+		{
+			MethodName: "UpdateCategory",
+			Handler:    _Catalog_UpdateCategory_Handler,
+		},
+		{
+			MethodName: "MoveCategory",
+			Handler:    _Catalog_MoveCategory_Handler,
+		},
+		{
+			MethodName: "DeleteCategory",
+			Handler:    _Catalog_DeleteCategory_Handler,
+		},
+		{
+			MethodName: "GetCategoryTree",
+			Handler:    _Catalog_GetCategoryTree_Handler,
+		},
+		{
+			MethodName: "AssignProducts",
+			Handler:    _Catalog_AssignProducts_Handler,
+		},
+		{
+			MethodName: "UnassignProducts",
+			Handler:    _Catalog_UnassignProducts_Handler,
+		},
+		{
+			MethodName: "CreateAttribute",
+			Handler:    _Catalog_CreateAttribute_Handler,
+		},
+		{
+			MethodName: "GetAttribute",
+			Handler:    _Catalog_GetAttribute_Handler,
+		},
+		{
+			MethodName: "UpdateAttribute",
+			Handler:    _Catalog_UpdateAttribute_Handler,
+		},
+		{
+			MethodName: "ListAttributes",
+			Handler:    _Catalog_ListAttributes_Handler,
+		},
+		{
+			MethodName: "CreateAttributeSet",
+			Handler:    _Catalog_CreateAttributeSet_Handler,
+		},
+		{
+			MethodName: "GetAttributeSet",
+			Handler:    _Catalog_GetAttributeSet_Handler,
+		},
+		{
+			MethodName: "UpdateAttributeSet",
+			Handler:    _Catalog_UpdateAttributeSet_Handler,
+		},
+		{
+			MethodName: "SetProductAttributes",
+			Handler:    _Catalog_SetProductAttributes_Handler,
+		},
+		{
+			MethodName: "GetProductAttributes",
+			Handler:    _Catalog_GetProductAttributes_Handler,
+		},
+		{
+			MethodName: "CreateSynonymSet",
+			Handler:    _Catalog_CreateSynonymSet_Handler,
+		},
+		{
+			MethodName: "GetSynonymSet",
+			Handler:    _Catalog_GetSynonymSet_Handler,
+		},
+		{
+			MethodName: "UpdateSynonymSet",
+			Handler:    _Catalog_UpdateSynonymSet_Handler,
+		},
+		{
+			MethodName: "DeleteSynonymSet",
+			Handler:    _Catalog_DeleteSynonymSet_Handler,
+		},
+		{
+			MethodName: "ListSynonymSets",
+			Handler:    _Catalog_ListSynonymSets_Handler,
+		},
+		{
+			MethodName: "GetStopWords",
+			Handler:    _Catalog_GetStopWords_Handler,
+		},
+		{
+			MethodName: "SetStopWords",
+			Handler:    _Catalog_SetStopWords_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportProducts",
+			Handler:       _Catalog_ExportProducts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchProductChanges",
+			Handler:       _Catalog_WatchProductChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "catalog/catalog_service.proto",
+}
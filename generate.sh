@@ -0,0 +1,10 @@
+export PATH="$PATH:$(go env GOPATH)/bin"
+
+# Shared messages (product.Product etc.) come from the headless-ecom-protos module
+PROTOS_DIR=$(go list -m -f '{{.Dir}}' github.com/akolpakov-somehash/headless-ecom-protos)
+PROTOS_GO_PACKAGE=github.com/akolpakov-somehash/headless-ecom-protos/gen/go/catalog
+
+# Generate proto files for Go
+protoc -I proto -I "$PROTOS_DIR/proto" proto/catalog/*.proto \
+  --go_out=./gen/go/ --go_opt=paths=source_relative,Mcatalog/product.proto=$PROTOS_GO_PACKAGE \
+  --go-grpc_out=./gen/go/ --go-grpc_opt=paths=source_relative,Mcatalog/product.proto=$PROTOS_GO_PACKAGE
@@ -1,92 +1,1297 @@
 package internal
 
 import (
+	cpb "catalog/gen/go/catalog"
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"log"
-	"sync"
+	"strconv"
+	"strings"
+	"time"
 
 	pb "github.com/akolpakov-somehash/headless-ecom-protos/gen/go/catalog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// revisionHeader is the response metadata with the revision of the
+	// product that was read or written.
+	revisionHeader = "revision"
+	// ifMatchHeader is the request metadata with the revision an update or
+	// delete is based on.
+	ifMatchHeader = "if-match"
+	// idempotencyKeyHeader is the request metadata making retries of
+	// AddProduct safe, replayedHeader marks responses of such retries.
+	idempotencyKeyHeader = "idempotency-key"
+	replayedHeader       = "idempotent-replayed"
 )
 
 type Server struct {
-	ProductService ProductServiceInterface
+	ProductService    ProductServiceInterface
+	VariantService    VariantServiceInterface
+	CategoryService   CategoryServiceInterface
+	AttributeService  AttributeServiceInterface
+	DictionaryService DictionaryServiceInterface
+	// Changes serves WatchProductChanges, which is unavailable when nil.
+	Changes *ChangeFeed
+	// Validator checks products before they are written. DefaultValidationRules are used when nil.
+	Validator *ProductValidator
+	// Converter builds list responses. A nil converter does plain conversion.
+	Converter *ProductConverter
+	// Currency is the catalog currency: float prices of the product API are
+	// read and written in it. DefaultCurrency is used when empty.
+	Currency string
+	// AdminToken lets callers sending "authorization: Bearer <token>" see
+	// products hidden from the public. Every caller is public when empty.
+	AdminToken string
 	pb.UnimplementedProductInfoServer
+	cpb.UnimplementedCatalogServer
+}
+
+func (s *Server) validator() *ProductValidator {
+	if s.Validator == nil {
+		return NewProductValidator(DefaultValidationRules())
+	}
+	return s.Validator
+}
+
+// admin reports whether the caller presented the admin token.
+func (s *Server) admin(ctx context.Context) bool {
+	if s.AdminToken == "" {
+		return false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	expected := []byte("Bearer " + s.AdminToken)
+	for _, value := range md.Get("authorization") {
+		if subtle.ConstantTimeCompare([]byte(value), expected) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// requireAdmin rejects callers without the admin token.
+func (s *Server) requireAdmin(ctx context.Context) error {
+	if s.admin(ctx) {
+		return nil
+	}
+	return &Error{Kind: KindUnauthenticated, Message: "the admin token is required"}
+}
+
+// visible reports whether the caller may see the product. The public sees
+// products available to it at the time of the call only.
+func (s *Server) visible(ctx context.Context, product *DbProduct) bool {
+	return product.Public(time.Now()) || s.admin(ctx)
+}
+
+// requireVisible looks the product up for public callers and reports it as
+// not found when they may not see it, so its existence is not revealed.
+func (s *Server) requireVisible(ctx context.Context, id uint64) error {
+	if s.admin(ctx) {
+		return nil
+	}
+	product, err := s.ProductService.GetProductByID(ctx, id)
+	if err == nil && !product.Public(time.Now()) {
+		err = NotFoundError(ResourceProduct, id)
+	}
+	return err
+}
+
+// setRevision sends the revision of a product as response header metadata.
+func setRevision(ctx context.Context, revision uint64) {
+	// Fails outside of a call, the response itself is not affected.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(revisionHeader, strconv.FormatUint(revision, 10)))
+}
+
+// ifMatch returns the revision the write of the caller is based on. Quoted
+// HTTP entity tags are accepted.
+func ifMatch(ctx context.Context) (uint64, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(ifMatchHeader)
+	if len(values) == 0 {
+		return 0, &Error{Kind: KindFailedPrecondition, Message: "the revision of the product is required in if-match"}
+	}
+	return parseIfMatch(values[0])
+}
+
+// parseIfMatch reads the revision in an if-match value.
+func parseIfMatch(value string) (uint64, error) {
+	revision, err := strconv.ParseUint(strings.Trim(value, `"`), 10, 64)
+	if err != nil {
+		return 0, InvalidArgumentError("invalid if-match", FieldViolation{Field: ifMatchHeader, Description: "must be a product revision"})
+	}
+	return revision, nil
+}
+
+// writeRevision returns the revision a write is based on, the one named in
+// the request or else the one in if-match.
+func writeRevision(ctx context.Context, revision uint64) (uint64, error) {
+	if revision != 0 {
+		return revision, nil
+	}
+	return ifMatch(ctx)
+}
+
+// idempotencyKey returns the idempotency key sent with in, nil when there is
+// none. Requests are told apart by a hash of their deterministic encoding.
+func idempotencyKey(ctx context.Context, in proto.Message) (*IdempotencyKey, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(idempotencyKeyHeader)
+	if len(values) == 0 || values[0] == "" {
+		return nil, nil
+	}
+	if len(values[0]) > MaxIdempotencyKeyLength {
+		return nil, InvalidArgumentError("invalid idempotency-key", FieldViolation{Field: idempotencyKeyHeader, Description: fmt.Sprintf("must be at most %d characters", MaxIdempotencyKeyLength)})
+	}
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the request: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	return &IdempotencyKey{Key: values[0], RequestHash: hex.EncodeToString(hash[:])}, nil
+}
+
+func (s *Server) currency() string {
+	if s.Currency == "" {
+		return DefaultCurrency
+	}
+	return s.Currency
+}
+
+// productFromProto converts and validates a product of the product API.
+func (s *Server) productFromProto(in *pb.Product) (*DbProduct, error) {
+	if _, err := MoneyFromFloat32(in.Price, s.currency()); err != nil {
+		return nil, InvalidArgumentError("invalid product", FieldViolation{Field: "price", Description: "must be a finite number"})
+	}
+	dbProduct := protoToProduct(in, s.currency())
+	return dbProduct, s.validator().Validate(dbProduct)
 }
 
 func (s *Server) AddProduct(ctx context.Context, in *pb.Product) (*pb.ProductId, error) {
-	dbProduct := protoToProduct(in)
-	id, err := s.ProductService.CreateProduct(dbProduct)
+	dbProduct, err := s.productFromProto(in)
+	var key *IdempotencyKey
+	if err == nil {
+		key, err = idempotencyKey(ctx, in)
+	}
+	if err != nil {
+		log.Printf("Rejected product %v. Error: %v", in.Name, err)
+		return nil, toStatus(err)
+	}
+	var id uint64
+	replayed := false
+	if key == nil {
+		id, err = s.ProductService.CreateProduct(ctx, dbProduct)
+	} else {
+		id, replayed, err = s.ProductService.CreateProductOnce(ctx, *key, dbProduct)
+	}
 	if err != nil {
 		log.Printf("Failed to add product %v : %v. Error: %v", id, in.Name, err)
-		return nil, fmt.Errorf("failed to add product: %w", err)
+		return nil, toStatus(err)
+	}
+	if replayed {
+		// The product may have changed since, its revision is not known.
+		log.Printf("Product %v : %v - Replayed.", id, in.Name)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(replayedHeader, "true"))
+		return &pb.ProductId{Id: id}, nil
 	}
 	log.Printf("Product %v : %v - Added.", id, in.Name)
+	setRevision(ctx, dbProduct.Revision)
 	return &pb.ProductId{Id: id}, nil
 }
 
 func (s *Server) UpdateProduct(ctx context.Context, in *pb.Product) (*pb.Empty, error) {
-	if _, exists := s.ProductService.GetProductByID(in.Id); exists != nil {
-		log.Printf("Failed to find product %v : %v. Error: %v", in.Id, in.Name, exists)
-		return nil, fmt.Errorf("product not found: %w", exists)
+	updatedProduct, err := s.productFromProto(in)
+	if err == nil {
+		updatedProduct.Revision, err = ifMatch(ctx)
 	}
-	updatedProduct := protoToProduct(in)
-	if err := s.ProductService.UpdateProduct(updatedProduct); err != nil {
+	if err != nil {
+		log.Printf("Rejected product %v : %v. Error: %v", in.Id, in.Name, err)
+		return nil, toStatus(err)
+	}
+	err = s.ProductService.InTransaction(ctx, func(tx ProductServiceInterface) error {
+		existing, err := tx.GetProductByID(ctx, in.Id)
+		if err != nil {
+			return err
+		}
+		// The product API has no lifecycle fields, keep the stored ones.
+		updatedProduct.Status, updatedProduct.Visibility = existing.Status, existing.Visibility
+		updatedProduct.AvailableFrom, updatedProduct.AvailableUntil = existing.AvailableFrom, existing.AvailableUntil
+		return tx.UpdateProduct(ctx, updatedProduct)
+	})
+	if err != nil {
 		log.Printf("Failed to update product %v : %v. Error: %v", in.Id, in.Name, err)
-		return nil, fmt.Errorf("failed to update product: %w", err)
+		return nil, toStatus(err)
 	}
 	log.Printf("Product %v : %v - Updated.", in.Id, in.Name)
+	setRevision(ctx, updatedProduct.Revision)
 	return new(pb.Empty), nil
 }
 
+func (s *Server) PatchProduct(ctx context.Context, in *cpb.PatchProductRequest) (*pb.Product, error) {
+	fields, violations := productFieldsFromMask(in.UpdateMask)
+	if in.Product == nil {
+		violations = append(violations, FieldViolation{Field: "product", Description: "must be set"})
+	} else if _, err := MoneyFromFloat32(in.Product.Price, s.currency()); err != nil {
+		violations = append(violations, FieldViolation{Field: "product.price", Description: "must be a finite number"})
+	}
+	if len(violations) > 0 {
+		log.Printf("Rejected patch of product %v. Error: %v", in.GetProduct().GetId(), violations)
+		return nil, toStatus(InvalidArgumentError("invalid product patch", violations...))
+	}
+	patch := protoToProduct(in.Product, s.currency())
+	var err error
+	if patch.Revision, err = ifMatch(ctx); err != nil {
+		log.Printf("Rejected patch of product %v. Error: %v", patch.ID, err)
+		return nil, toStatus(err)
+	}
+	existing, err := s.ProductService.GetProductByID(ctx, patch.ID)
+	if err != nil {
+		log.Printf("Failed to find product %v. Error: %v", patch.ID, err)
+		return nil, toStatus(err)
+	}
+	// The patched product as a whole must stay valid.
+	patched := *existing
+	patched.applyPatch(patch, fields)
+	if err := s.validator().Validate(&patched); err != nil {
+		log.Printf("Rejected patch of product %v. Error: %v", patch.ID, err)
+		return nil, toStatus(err)
+	}
+	product, err := s.ProductService.PatchProduct(ctx, patch.ID, patch, fields)
+	if err != nil {
+		log.Printf("Failed to patch product %v. Error: %v", patch.ID, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Product %v : %v - Patched %v.", product.ID, product.Name, fields)
+	setRevision(ctx, product.Revision)
+	return productToProto(product), nil
+}
+
+// productFieldsFromMask converts the paths of a patch, each field is kept once.
+func productFieldsFromMask(mask *fieldmaskpb.FieldMask) ([]ProductField, []FieldViolation) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, []FieldViolation{{Field: "update_mask", Description: "must list at least one field"}}
+	}
+	var fields []ProductField
+	var violations []FieldViolation
+	seen := map[ProductField]bool{}
+	for i, path := range mask.Paths {
+		field := ProductField(path)
+		if !field.valid() {
+			violations = append(violations, FieldViolation{Field: fmt.Sprintf("update_mask.paths[%d]", i), Description: "must be one of name, sku, description, price, image"})
+			continue
+		}
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields, violations
+}
+
 func (s *Server) DeleteProduct(ctx context.Context, in *pb.ProductId) (*pb.Empty, error) {
-	if err := s.ProductService.DeleteProductByID(in.Id); err != nil {
-		return nil, err
+	revision, err := ifMatch(ctx)
+	if err == nil {
+		err = s.ProductService.DeleteProductByID(ctx, in.Id, revision)
+	}
+	if err != nil {
+		log.Printf("Failed to delete product %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
 	}
 	return new(pb.Empty), nil
 }
 
+func (s *Server) BatchGetProducts(ctx context.Context, in *cpb.BatchGetProductsRequest) (*cpb.BatchGetProductsResponse, error) {
+	if err := batchSizeError("ids", len(in.Ids)); err != nil {
+		return nil, toStatus(err)
+	}
+	products, missing, err := s.ProductService.GetProductsByIDs(ctx, in.Ids)
+	if err != nil {
+		log.Printf("Failed to find products %v. Error: %v", in.Ids, err)
+		return nil, toStatus(err)
+	}
+	admin, now := s.admin(ctx), time.Now()
+	res := &cpb.BatchGetProductsResponse{Products: make([]*pb.Product, 0, len(products)), MissingIds: missing}
+	for _, product := range products {
+		if admin || product.Public(now) {
+			res.Products = append(res.Products, productToProto(product))
+		} else {
+			res.MissingIds = append(res.MissingIds, product.ID)
+		}
+	}
+	return res, nil
+}
+
+func (s *Server) BatchCreateProducts(ctx context.Context, in *cpb.BatchCreateProductsRequest) (*cpb.BatchWriteResponse, error) {
+	if err := batchSizeError("products", len(in.Products)); err != nil {
+		return nil, toStatus(err)
+	}
+	batch := newProtoBatch(len(in.Products), batchMode(in.Mode))
+	var products []*DbProduct
+	for i, item := range in.Products {
+		product, err := s.productFromProto(item)
+		if batch.accept(i, 0, err) {
+			products = append(products, product)
+		}
+	}
+	if err := batch.rejected(); err != nil {
+		log.Printf("Rejected batch of %v products. Error: %v", len(in.Products), err)
+		return nil, toStatus(err)
+	}
+	written, err := s.ProductService.BatchCreateProducts(ctx, products, batch.mode)
+	if err != nil {
+		log.Printf("Failed to add batch of %v products. Error: %v", len(products), err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Batch of %v products - Added.", len(products))
+	return batch.response(written), nil
+}
+
+func (s *Server) BatchUpdateProducts(ctx context.Context, in *cpb.BatchUpdateProductsRequest) (*cpb.BatchWriteResponse, error) {
+	if err := batchSizeError("updates", len(in.Updates)); err != nil {
+		return nil, toStatus(err)
+	}
+	batch := newProtoBatch(len(in.Updates), batchMode(in.Mode))
+	var products []*DbProduct
+	for i, item := range in.Updates {
+		var product *DbProduct
+		var err error
+		switch {
+		case item.Product == nil:
+			err = InvalidArgumentError("invalid product update", FieldViolation{Field: "product", Description: "is required"})
+		case item.Revision == 0:
+			err = revisionRequiredError()
+		default:
+			product, err = s.productFromProto(item.Product)
+		}
+		if batch.accept(i, item.GetProduct().GetId(), err) {
+			product.Revision = item.Revision
+			products = append(products, product)
+		}
+	}
+	if err := batch.rejected(); err != nil {
+		log.Printf("Rejected batch of %v product updates. Error: %v", len(in.Updates), err)
+		return nil, toStatus(err)
+	}
+	written, err := s.ProductService.BatchUpdateProducts(ctx, products, batch.mode)
+	if err != nil {
+		log.Printf("Failed to update batch of %v products. Error: %v", len(products), err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Batch of %v products - Updated.", len(products))
+	return batch.response(written), nil
+}
+
+func (s *Server) BatchDeleteProducts(ctx context.Context, in *cpb.BatchDeleteProductsRequest) (*cpb.BatchWriteResponse, error) {
+	if err := batchSizeError("deletes", len(in.Deletes)); err != nil {
+		return nil, toStatus(err)
+	}
+	batch := newProtoBatch(len(in.Deletes), batchMode(in.Mode))
+	var products []ProductRevision
+	for i, item := range in.Deletes {
+		var err error
+		if item.Revision == 0 {
+			err = revisionRequiredError()
+		}
+		if batch.accept(i, item.Id, err) {
+			products = append(products, ProductRevision{ID: item.Id, Revision: item.Revision})
+		}
+	}
+	if err := batch.rejected(); err != nil {
+		log.Printf("Rejected batch of %v product deletes. Error: %v", len(in.Deletes), err)
+		return nil, toStatus(err)
+	}
+	written, err := s.ProductService.BatchDeleteProducts(ctx, products, batch.mode)
+	if err != nil {
+		log.Printf("Failed to delete batch of %v products. Error: %v", len(products), err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Batch of %v products - Deleted.", len(products))
+	return batch.response(written), nil
+}
+
+// protoBatch collects the results of a batch write whose invalid items are
+// rejected before the valid ones are passed on to ProductService.
+type protoBatch struct {
+	mode    BatchMode
+	results []BatchResult
+	// passed are the indexes of the items passed on, in order.
+	passed  []int
+	invalid int
+}
+
+func newProtoBatch(size int, mode BatchMode) *protoBatch {
+	return &protoBatch{mode: mode, results: make([]BatchResult, size), invalid: -1}
+}
+
+// accept records the validation error of item i and reports whether the
+// item is passed on.
+func (b *protoBatch) accept(i int, id uint64, err error) bool {
+	if err != nil {
+		b.results[i] = BatchResult{ID: id, Err: err}
+		if b.invalid < 0 {
+			b.invalid = i
+		}
+		return false
+	}
+	b.passed = append(b.passed, i)
+	return true
+}
+
+// rejected returns the error failing an atomic batch with invalid items.
+func (b *protoBatch) rejected() error {
+	if b.mode != BatchAtomic || b.invalid < 0 {
+		return nil
+	}
+	return batchItemError(b.invalid, b.results[b.invalid].Err)
+}
+
+func (b *protoBatch) response(written []BatchResult) *cpb.BatchWriteResponse {
+	for j, result := range written {
+		b.results[b.passed[j]] = result
+	}
+	res := &cpb.BatchWriteResponse{Results: make([]*cpb.BatchResult, len(b.results))}
+	for i, result := range b.results {
+		st := status.Convert(toStatus(result.Err))
+		res.Results[i] = &cpb.BatchResult{Id: result.ID, Revision: result.Revision, Code: int32(st.Code()), Message: st.Message()}
+	}
+	return res
+}
+
+func batchMode(mode cpb.BatchMode) BatchMode {
+	if mode == cpb.BatchMode_BATCH_MODE_BEST_EFFORT {
+		return BatchBestEffort
+	}
+	return BatchAtomic
+}
+
+// batchSizeError rejects batches of more than MaxBatchSize items.
+func batchSizeError(field string, size int) error {
+	if size <= MaxBatchSize {
+		return nil
+	}
+	return InvalidArgumentError("too many items", FieldViolation{Field: field, Description: fmt.Sprintf("must have at most %d items", MaxBatchSize)})
+}
+
+func revisionRequiredError() error {
+	return &Error{Kind: KindFailedPrecondition, Message: "the revision of the product is required"}
+}
+
 func (s *Server) GetProductInfo(ctx context.Context, in *pb.ProductId) (*pb.Product, error) {
-	dbProduct, err := s.ProductService.GetProductByID(in.Id)
+	dbProduct, err := s.ProductService.GetProductByID(ctx, in.Id)
+	if err == nil && !s.visible(ctx, dbProduct) {
+		err = NotFoundError(ResourceProduct, in.Id)
+	}
 	if err != nil {
 		log.Printf("Failed to find product %v. Error: %v", in.Id, err)
-		return nil, fmt.Errorf("product not found: %w", err)
+		return nil, toStatus(err)
 	}
+	setRevision(ctx, dbProduct.Revision)
+	return productToProto(dbProduct), nil
+}
+
+func (s *Server) GetProductBySku(ctx context.Context, in *cpb.ProductSku) (*pb.Product, error) {
+	if in.Sku == "" {
+		return nil, toStatus(InvalidArgumentError("sku is required", FieldViolation{Field: "sku", Description: "must not be empty"}))
+	}
+	dbProduct, err := s.ProductService.GetProductBySKU(ctx, in.Sku)
+	if err == nil && !s.visible(ctx, dbProduct) {
+		err = NotFoundError(ResourceProduct, in.Sku)
+	}
+	if err != nil {
+		log.Printf("Failed to find product by sku %v. Error: %v", in.Sku, err)
+		return nil, toStatus(err)
+	}
+	setRevision(ctx, dbProduct.Revision)
 	return productToProto(dbProduct), nil
 }
 
 func (s *Server) GetProductList(ctx context.Context, in *pb.Empty) (*pb.ProductList, error) {
-	dbProducts, err := s.ProductService.GetAllProducts()
+	dbProducts, err := s.ProductService.GetAllProducts(ctx)
 	if err != nil {
 		log.Printf("Failed to obtain product list. Error: %v", err)
-		return nil, fmt.Errorf("failed to obtain product list: %w", err)
+		return nil, toStatus(err)
+	}
+	if !s.admin(ctx) {
+		now := time.Now()
+		listed := make([]*DbProduct, 0, len(dbProducts))
+		for _, product := range dbProducts {
+			if product.InCatalog(now) {
+				listed = append(listed, product)
+			}
+		}
+		dbProducts = listed
 	}
-	protoProducts := make(map[uint64]*pb.Product, len(dbProducts))
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	for _, product := range dbProducts {
-		wg.Add(1)
-		go func(product *DbProduct) {
-			defer wg.Done()
+	protoProducts, err := s.Converter.ToProtoMap(ctx, dbProducts)
+	if err != nil {
+		log.Printf("Failed to convert product list. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	return &pb.ProductList{Products: protoProducts}, nil
+}
 
-			mu.Lock()
-			defer mu.Unlock()
+func (s *Server) ListProducts(ctx context.Context, in *cpb.ListProductsRequest) (*cpb.ListProductsResponse, error) {
+	query, err := s.protoToListQuery(ctx, in)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	page, err := s.ProductService.ListProducts(ctx, query)
+	if err != nil {
+		log.Printf("Failed to list products. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	products, err := s.Converter.ToProtoSlice(ctx, page.Products)
+	if err != nil {
+		log.Printf("Failed to convert products. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	return &cpb.ListProductsResponse{Products: products, NextPageToken: page.NextPageToken}, nil
+}
 
-			protoProducts[product.ID] = productToProto(product)
-		}(product)
+func (s *Server) FacetProducts(ctx context.Context, in *cpb.FacetProductsRequest) (*cpb.FacetProductsResponse, error) {
+	query := FacetQuery{FacetAttributes: in.FacetAttributes}
+	var err error
+	if query.ListQuery, err = s.protoToListQuery(ctx, in.List); err != nil {
+		return nil, toStatus(err)
+	}
+	for i, r := range in.PriceRanges {
+		var priceRange PriceRange
+		for field, bound := range map[string]struct {
+			in  *float32
+			out **Money
+		}{"from": {r.From, &priceRange.From}, "to": {r.To, &priceRange.To}} {
+			if bound.in == nil {
+				continue
+			}
+			price, err := MoneyFromFloat32(*bound.in, s.currency())
+			if err != nil {
+				return nil, toStatus(InvalidArgumentError("invalid facet query", FieldViolation{Field: fmt.Sprintf("price_ranges[%d].%s", i, field), Description: "must be a finite number"}))
+			}
+			*bound.out = &price
+		}
+		query.PriceRanges = append(query.PriceRanges, priceRange)
+	}
+	page, err := s.ProductService.FacetProducts(ctx, query)
+	if err != nil {
+		log.Printf("Failed to facet products. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	products, err := s.Converter.ToProtoSlice(ctx, page.Products)
+	if err != nil {
+		log.Printf("Failed to convert products. Error: %v", err)
+		return nil, toStatus(err)
 	}
-	wg.Wait()
+	out := &cpb.FacetProductsResponse{Products: products, NextPageToken: page.NextPageToken}
+	for _, facet := range page.Attributes {
+		values := make([]*cpb.FacetValue, len(facet.Values))
+		for i, value := range facet.Values {
+			values[i] = &cpb.FacetValue{Value: value.Value, Count: value.Count}
+		}
+		out.Attributes = append(out.Attributes, &cpb.AttributeFacet{Code: facet.Code, Values: values})
+	}
+	for _, count := range page.PriceRanges {
+		priceRange := &cpb.PriceRange{}
+		for _, bound := range []struct {
+			in  *Money
+			out **float32
+		}{{count.From, &priceRange.From}, {count.To, &priceRange.To}} {
+			if bound.in != nil {
+				f := bound.in.Float32()
+				*bound.out = &f
+			}
+		}
+		out.PriceRanges = append(out.PriceRanges, &cpb.PriceRangeCount{Range: priceRange, Count: count.Count})
+	}
+	return out, nil
+}
 
-	return &pb.ProductList{Products: protoProducts}, nil
+func (s *Server) SearchProducts(ctx context.Context, in *cpb.SearchProductsRequest) (*cpb.SearchProductsResponse, error) {
+	page, err := s.ProductService.SearchProducts(ctx, SearchQuery{Text: in.Query, PageSize: int(in.PageSize), PageToken: in.PageToken, Public: !s.admin(ctx)})
+	if err != nil {
+		log.Printf("Failed to search products for %q. Error: %v", in.Query, err)
+		return nil, toStatus(err)
+	}
+	products, err := s.Converter.ToProtoSlice(ctx, page.Products)
+	if err != nil {
+		log.Printf("Failed to convert products. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	out := &cpb.SearchProductsResponse{Hits: make([]*cpb.SearchHit, len(products)), TotalSize: int32(page.TotalHits), NextPageToken: page.NextPageToken}
+	for i, product := range products {
+		out.Hits[i] = &cpb.SearchHit{Product: product, Score: page.Hits[i].Score}
+	}
+	return out, nil
+}
+
+func (s *Server) SuggestProducts(ctx context.Context, in *cpb.SuggestProductsRequest) (*cpb.SuggestProductsResponse, error) {
+	suggestions, err := s.ProductService.SuggestProducts(ctx, in.Prefix, int(in.Limit))
+	if err != nil {
+		log.Printf("Failed to suggest products for %q. Error: %v", in.Prefix, err)
+		return nil, toStatus(err)
+	}
+	out := &cpb.SuggestProductsResponse{Suggestions: make([]*cpb.Suggestion, len(suggestions))}
+	for i, suggestion := range suggestions {
+		out.Suggestions[i] = &cpb.Suggestion{Text: suggestion.Text, Kind: string(suggestion.Kind), ProductId: suggestion.ProductID}
+	}
+	return out, nil
+}
+
+// RebuildSearchIndex reads the whole catalog, so only admins may start it.
+func (s *Server) RebuildSearchIndex(ctx context.Context, in *pb.Empty) (*cpb.RebuildSearchIndexResponse, error) {
+	err := s.requireAdmin(ctx)
+	var indexed int
+	if err == nil {
+		indexed, err = s.ProductService.RebuildSearchIndex(ctx)
+	}
+	if err != nil {
+		log.Printf("Failed to rebuild the search index. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Rebuilt the search index with %d products", indexed)
+	return &cpb.RebuildSearchIndexResponse{Indexed: int64(indexed)}, nil
 }
 
-func protoToProduct(product *pb.Product) *DbProduct {
+// protoToListQuery converts a listing request, prices are in the catalog
+// currency. Only admins see products not listed in the public catalog.
+func (s *Server) protoToListQuery(ctx context.Context, in *cpb.ListProductsRequest) (ListQuery, error) {
+	if in == nil {
+		in = &cpb.ListProductsRequest{}
+	}
+	sortBy, descending, err := ParseOrderBy(in.OrderBy)
+	if err != nil {
+		return ListQuery{}, err
+	}
+	query := ListQuery{
+		PageSize:     int(in.PageSize),
+		PageToken:    in.PageToken,
+		SortBy:       sortBy,
+		Descending:   descending,
+		SkuPrefix:    in.SkuPrefix,
+		NameContains: in.NameContains,
+		CategoryID:   in.CategoryId,
+		Public:       !s.admin(ctx),
+	}
+	for field, bound := range map[string]struct {
+		in  *float32
+		out **Money
+	}{"min_price": {in.MinPrice, &query.MinPrice}, "max_price": {in.MaxPrice, &query.MaxPrice}} {
+		if bound.in == nil {
+			continue
+		}
+		price, err := MoneyFromFloat32(*bound.in, s.currency())
+		if err != nil {
+			return ListQuery{}, InvalidArgumentError("invalid list query", FieldViolation{Field: field, Description: "must be a finite number"})
+		}
+		*bound.out = &price
+	}
+	for _, filter := range in.Attributes {
+		if query.Attributes == nil {
+			query.Attributes = make(map[string][]string, len(in.Attributes))
+		}
+		query.Attributes[filter.Code] = append(query.Attributes[filter.Code], filter.Values...)
+	}
+	return query, nil
+}
+
+func (s *Server) ExportProducts(in *cpb.ExportProductsRequest, stream cpb.Catalog_ExportProductsServer) error {
+	sent := 0
+	admin, now := s.admin(stream.Context()), time.Now()
+	err := s.ProductService.ExportProducts(stream.Context(), in.AfterId, int(in.BatchSize), func(product *DbProduct) error {
+		if !admin && !product.Public(now) {
+			return nil
+		}
+		sent++
+		return stream.Send(productToProto(product))
+	})
+	if err != nil {
+		log.Printf("Product export after %v stopped after %v products. Error: %v", in.AfterId, sent, err)
+		return toStatus(err)
+	}
+	log.Printf("Exported %v products after %v.", sent, in.AfterId)
+	return nil
+}
+
+func (s *Server) GetProductPrices(ctx context.Context, in *pb.ProductId) (*cpb.ProductPrices, error) {
+	err := s.requireVisible(ctx, in.Id)
+	var prices []Money
+	if err == nil {
+		prices, err = s.ProductService.GetProductPrices(ctx, in.Id)
+	}
+	if err != nil {
+		log.Printf("Failed to get prices of product %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	out := &cpb.ProductPrices{ProductId: in.Id, Prices: make([]*cpb.Price, len(prices))}
+	for i, price := range prices {
+		out.Prices[i] = moneyToProto(price)
+	}
+	return out, nil
+}
+
+// SetProductPrices changes what customers pay, so only admins may call it.
+func (s *Server) SetProductPrices(ctx context.Context, in *cpb.ProductPrices) (*pb.Empty, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		log.Printf("Rejected prices of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	prices := make([]Money, len(in.Prices))
+	var violations []FieldViolation
+	for i, price := range in.Prices {
+		money, err := protoToMoney(price)
+		if err != nil {
+			violations = append(violations, FieldViolation{Field: fmt.Sprintf("prices[%d].amount", i), Description: err.Error()})
+		}
+		prices[i] = money
+	}
+	if len(violations) == 0 {
+		violations = s.validator().PriceViolations(prices)
+	}
+	if len(violations) > 0 {
+		log.Printf("Rejected prices of product %v. Error: %v", in.ProductId, violations)
+		return nil, toStatus(InvalidArgumentError("invalid prices", violations...))
+	}
+	revision, err := writeRevision(ctx, in.Revision)
+	if err != nil {
+		log.Printf("Rejected prices of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	if err := s.ProductService.SetProductPrices(ctx, in.ProductId, revision, prices); err != nil {
+		log.Printf("Failed to set prices of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Product %v - %v prices set.", in.ProductId, len(prices))
+	return new(pb.Empty), nil
+}
+
+func (s *Server) GetProductLifecycle(ctx context.Context, in *pb.ProductId) (*cpb.ProductLifecycle, error) {
+	product, err := s.ProductService.GetProductByID(ctx, in.Id)
+	if err == nil && !s.visible(ctx, product) {
+		err = NotFoundError(ResourceProduct, in.Id)
+	}
+	if err != nil {
+		log.Printf("Failed to find product %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	st, visibility := product.lifecycle()
+	out := &cpb.ProductLifecycle{ProductId: product.ID, Revision: product.Revision}
+	for protoStatus, productStatus := range productStatuses {
+		if productStatus == st {
+			out.Status = protoStatus
+		}
+	}
+	for protoVisibility, productVisibility := range productVisibilities {
+		if productVisibility == visibility {
+			out.Visibility = protoVisibility
+		}
+	}
+	return out, nil
+}
+
+// SetProductLifecycle publishes and hides products, so only admins may call it.
+func (s *Server) SetProductLifecycle(ctx context.Context, in *cpb.ProductLifecycle) (*pb.Empty, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		log.Printf("Rejected the lifecycle of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	revision, err := writeRevision(ctx, in.Revision)
+	if err != nil {
+		log.Printf("Rejected the lifecycle of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	st, visibility := productStatuses[in.Status], productVisibilities[in.Visibility]
+	if err := s.ProductService.SetProductLifecycle(ctx, in.ProductId, revision, st, visibility); err != nil {
+		log.Printf("Failed to set the lifecycle of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Product %v - status %v, visibility %v.", in.ProductId, in.Status, in.Visibility)
+	return new(pb.Empty), nil
+}
+
+func (s *Server) GetProductAvailability(ctx context.Context, in *pb.ProductId) (*cpb.ProductAvailability, error) {
+	product, err := s.ProductService.GetProductByID(ctx, in.Id)
+	if err == nil && !s.visible(ctx, product) {
+		err = NotFoundError(ResourceProduct, in.Id)
+	}
+	if err != nil {
+		log.Printf("Failed to find product %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	return &cpb.ProductAvailability{
+		ProductId:      product.ID,
+		AvailableFrom:  timeToProto(product.AvailableFrom),
+		AvailableUntil: timeToProto(product.AvailableUntil),
+		Revision:       product.Revision,
+	}, nil
+}
+
+// SetProductAvailability schedules when the public sees a product, so only
+// admins may call it.
+func (s *Server) SetProductAvailability(ctx context.Context, in *cpb.ProductAvailability) (*pb.Empty, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		log.Printf("Rejected availability of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	var violations []FieldViolation
+	bounds := []struct {
+		field string
+		value *timestamppb.Timestamp
+	}{{"available_from", in.AvailableFrom}, {"available_until", in.AvailableUntil}}
+	for _, bound := range bounds {
+		if bound.value != nil && !bound.value.IsValid() {
+			violations = append(violations, FieldViolation{Field: bound.field, Description: "must be a valid timestamp"})
+		}
+	}
+	if len(violations) > 0 {
+		log.Printf("Rejected availability of product %v. Error: %v", in.ProductId, violations)
+		return nil, toStatus(InvalidArgumentError("invalid product availability", violations...))
+	}
+	revision, err := writeRevision(ctx, in.Revision)
+	if err != nil {
+		log.Printf("Rejected availability of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	from, until := protoToTime(in.AvailableFrom), protoToTime(in.AvailableUntil)
+	if err := s.ProductService.SetProductAvailability(ctx, in.ProductId, revision, from, until); err != nil {
+		log.Printf("Failed to set the availability of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Product %v - available from %v until %v.", in.ProductId, from, until)
+	return new(pb.Empty), nil
+}
+
+func (s *Server) WatchProductChanges(in *pb.Empty, stream cpb.Catalog_WatchProductChangesServer) error {
+	if s.Changes == nil {
+		return toStatus(&Error{Kind: KindUnavailable, Message: "product changes are not published"})
+	}
+	changes, unsubscribe := s.Changes.Subscribe(DefaultChangeBuffer)
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case change, ok := <-changes:
+			if !ok {
+				log.Printf("Product change subscriber fell behind, ending the stream.")
+				return toStatus(&Error{Kind: KindUnavailable, Message: "fell behind the product changes, resubscribe and reload"})
+			}
+			if err := stream.Send(productChangeToProto(change)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) SetProductOptions(ctx context.Context, in *cpb.ProductOptions) (*pb.Empty, error) {
+	options := make([]DbProductOption, len(in.Options))
+	for i, option := range in.Options {
+		options[i] = DbProductOption{Name: option.Name, Values: option.Values}
+	}
+	if violations := s.validator().OptionViolations(options); len(violations) > 0 {
+		log.Printf("Rejected options of product %v. Error: %v", in.ProductId, violations)
+		return nil, toStatus(InvalidArgumentError("invalid options", violations...))
+	}
+	if err := s.VariantService.SetProductOptions(ctx, in.ProductId, options); err != nil {
+		log.Printf("Failed to set options of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Product %v - %v options set.", in.ProductId, len(options))
+	return new(pb.Empty), nil
+}
+
+// variantFromProto converts and validates a variant. A price without a
+// currency is read in the catalog currency.
+func (s *Server) variantFromProto(in *cpb.Variant) (*DbVariant, error) {
+	variant := &DbVariant{ID: in.Id, ProductID: in.ProductId, Sku: in.Sku, Image: in.Image, Options: VariantOptions(in.Options)}
+	var violations []FieldViolation
+	if in.Price != nil {
+		price := in.Price
+		if price.Currency == "" {
+			price = &cpb.Price{Currency: s.currency(), AmountMinor: price.AmountMinor, Amount: price.Amount}
+		}
+		money, err := protoToMoney(price)
+		if err != nil {
+			violations = append(violations, FieldViolation{Field: "price.amount", Description: err.Error()})
+		}
+		variant.Price = money
+	}
+	violations = append(violations, s.validator().VariantViolations(variant)...)
+	if len(violations) > 0 {
+		return nil, InvalidArgumentError("invalid variant", violations...)
+	}
+	return variant, nil
+}
+
+func (s *Server) CreateVariant(ctx context.Context, in *cpb.Variant) (*cpb.VariantId, error) {
+	variant, err := s.variantFromProto(in)
+	if err != nil {
+		log.Printf("Rejected variant %v of product %v. Error: %v", in.Sku, in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	id, err := s.VariantService.CreateVariant(ctx, variant)
+	if err != nil {
+		log.Printf("Failed to add variant %v of product %v. Error: %v", in.Sku, in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Variant %v : %v of product %v - Added.", id, in.Sku, in.ProductId)
+	return &cpb.VariantId{Id: id}, nil
+}
+
+func (s *Server) GetVariant(ctx context.Context, in *cpb.VariantId) (*cpb.Variant, error) {
+	variant, err := s.VariantService.GetVariantByID(ctx, in.Id)
+	if err == nil {
+		err = s.requireVisible(ctx, variant.ProductID)
+	}
+	if err != nil {
+		log.Printf("Failed to find variant %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	return variantToProto(variant), nil
+}
+
+func (s *Server) UpdateVariant(ctx context.Context, in *cpb.Variant) (*pb.Empty, error) {
+	variant, err := s.variantFromProto(in)
+	if err != nil {
+		log.Printf("Rejected variant %v : %v. Error: %v", in.Id, in.Sku, err)
+		return nil, toStatus(err)
+	}
+	if err := s.VariantService.UpdateVariant(ctx, variant); err != nil {
+		log.Printf("Failed to update variant %v : %v. Error: %v", in.Id, in.Sku, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Variant %v : %v - Updated.", in.Id, in.Sku)
+	return new(pb.Empty), nil
+}
+
+func (s *Server) DeleteVariant(ctx context.Context, in *cpb.VariantId) (*pb.Empty, error) {
+	if err := s.VariantService.DeleteVariantByID(ctx, in.Id); err != nil {
+		log.Printf("Failed to delete variant %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	return new(pb.Empty), nil
+}
+
+func (s *Server) GetProductVariants(ctx context.Context, in *pb.ProductId) (*cpb.ProductVariants, error) {
+	product, err := s.VariantService.GetProductVariants(ctx, in.Id)
+	if err == nil && !s.visible(ctx, product.Product) {
+		err = NotFoundError(ResourceProduct, in.Id)
+	}
+	if err != nil {
+		log.Printf("Failed to get variants of product %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	out := &cpb.ProductVariants{
+		Product:  productToProto(product.Product),
+		Options:  make([]*cpb.ProductOption, len(product.Options)),
+		Variants: make([]*cpb.Variant, len(product.Variants)),
+	}
+	for i, option := range product.Options {
+		out.Options[i] = &cpb.ProductOption{Name: option.Name, Values: option.Values}
+	}
+	for i, variant := range product.Variants {
+		out.Variants[i] = variantToProto(variant)
+	}
+	return out, nil
+}
+
+func (s *Server) CreateCategory(ctx context.Context, in *cpb.Category) (*cpb.CategoryId, error) {
+	category := protoToCategory(in)
+	if violations := s.validator().CategoryViolations(category); len(violations) > 0 {
+		log.Printf("Rejected category %v. Error: %v", in.Slug, violations)
+		return nil, toStatus(InvalidArgumentError("invalid category", violations...))
+	}
+	id, err := s.CategoryService.CreateCategory(ctx, category)
+	if err != nil {
+		log.Printf("Failed to add category %v. Error: %v", in.Slug, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Category %v : %v - Added.", id, in.Slug)
+	return &cpb.CategoryId{Id: id}, nil
+}
+
+func (s *Server) GetCategory(ctx context.Context, in *cpb.CategoryId) (*cpb.Category, error) {
+	category, err := s.CategoryService.GetCategoryByID(ctx, in.Id)
+	if err != nil {
+		log.Printf("Failed to find category %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	return categoryToProto(category), nil
+}
+
+func (s *Server) UpdateCategory(ctx context.Context, in *cpb.Category) (*pb.Empty, error) {
+	category := protoToCategory(in)
+	if violations := s.validator().CategoryViolations(category); len(violations) > 0 {
+		log.Printf("Rejected category %v : %v. Error: %v", in.Id, in.Slug, violations)
+		return nil, toStatus(InvalidArgumentError("invalid category", violations...))
+	}
+	if err := s.CategoryService.UpdateCategory(ctx, category); err != nil {
+		log.Printf("Failed to update category %v : %v. Error: %v", in.Id, in.Slug, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Category %v : %v - Updated.", in.Id, in.Slug)
+	return new(pb.Empty), nil
+}
+
+func (s *Server) MoveCategory(ctx context.Context, in *cpb.MoveCategoryRequest) (*pb.Empty, error) {
+	if err := s.CategoryService.MoveCategory(ctx, in.Id, optionalID(in.ParentId), int(in.Position)); err != nil {
+		log.Printf("Failed to move category %v to %v. Error: %v", in.Id, in.ParentId, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Category %v - Moved to %v.", in.Id, in.ParentId)
+	return new(pb.Empty), nil
+}
+
+func (s *Server) DeleteCategory(ctx context.Context, in *cpb.CategoryId) (*pb.Empty, error) {
+	if err := s.CategoryService.DeleteCategoryByID(ctx, in.Id); err != nil {
+		log.Printf("Failed to delete category %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	return new(pb.Empty), nil
+}
+
+func (s *Server) GetCategoryTree(ctx context.Context, in *pb.Empty) (*cpb.CategoryTree, error) {
+	roots, err := s.CategoryService.GetCategoryTree(ctx)
+	if err != nil {
+		log.Printf("Failed to obtain category tree. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	return &cpb.CategoryTree{Categories: categoryNodesToProto(roots)}, nil
+}
+
+func (s *Server) AssignProducts(ctx context.Context, in *cpb.CategoryProducts) (*pb.Empty, error) {
+	if err := s.CategoryService.AssignProducts(ctx, in.CategoryId, in.ProductIds); err != nil {
+		log.Printf("Failed to assign products %v to category %v. Error: %v", in.ProductIds, in.CategoryId, err)
+		return nil, toStatus(err)
+	}
+	return new(pb.Empty), nil
+}
+
+func (s *Server) UnassignProducts(ctx context.Context, in *cpb.CategoryProducts) (*pb.Empty, error) {
+	if err := s.CategoryService.UnassignProducts(ctx, in.CategoryId, in.ProductIds); err != nil {
+		log.Printf("Failed to unassign products %v from category %v. Error: %v", in.ProductIds, in.CategoryId, err)
+		return nil, toStatus(err)
+	}
+	return new(pb.Empty), nil
+}
+
+func (s *Server) CreateAttribute(ctx context.Context, in *cpb.Attribute) (*cpb.AttributeId, error) {
+	attribute := protoToAttribute(in)
+	if violations := s.validator().AttributeViolations(attribute); len(violations) > 0 {
+		log.Printf("Rejected attribute %v. Error: %v", in.Code, violations)
+		return nil, toStatus(InvalidArgumentError("invalid attribute", violations...))
+	}
+	id, err := s.AttributeService.CreateAttribute(ctx, attribute)
+	if err != nil {
+		log.Printf("Failed to add attribute %v. Error: %v", in.Code, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Attribute %v : %v - Added.", id, in.Code)
+	return &cpb.AttributeId{Id: id}, nil
+}
+
+func (s *Server) GetAttribute(ctx context.Context, in *cpb.AttributeId) (*cpb.Attribute, error) {
+	attribute, err := s.AttributeService.GetAttributeByID(ctx, in.Id)
+	if err != nil {
+		log.Printf("Failed to find attribute %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	return attributeToProto(attribute), nil
+}
+
+func (s *Server) UpdateAttribute(ctx context.Context, in *cpb.Attribute) (*pb.Empty, error) {
+	attribute := protoToAttribute(in)
+	if violations := s.validator().AttributeViolations(attribute); len(violations) > 0 {
+		log.Printf("Rejected attribute %v : %v. Error: %v", in.Id, in.Code, violations)
+		return nil, toStatus(InvalidArgumentError("invalid attribute", violations...))
+	}
+	if err := s.AttributeService.UpdateAttribute(ctx, attribute); err != nil {
+		log.Printf("Failed to update attribute %v : %v. Error: %v", in.Id, in.Code, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Attribute %v : %v - Updated.", in.Id, in.Code)
+	return new(pb.Empty), nil
+}
+
+func (s *Server) ListAttributes(ctx context.Context, in *pb.Empty) (*cpb.AttributeList, error) {
+	attributes, err := s.AttributeService.ListAttributes(ctx)
+	if err != nil {
+		log.Printf("Failed to obtain attribute list. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	out := &cpb.AttributeList{Attributes: make([]*cpb.Attribute, len(attributes))}
+	for i, attribute := range attributes {
+		out.Attributes[i] = attributeToProto(attribute)
+	}
+	return out, nil
+}
+
+func (s *Server) CreateAttributeSet(ctx context.Context, in *cpb.AttributeSet) (*cpb.AttributeSetId, error) {
+	set := &DbAttributeSet{Name: in.Name, AttributeIDs: in.AttributeIds}
+	if violations := s.validator().AttributeSetViolations(set); len(violations) > 0 {
+		log.Printf("Rejected attribute set %v. Error: %v", in.Name, violations)
+		return nil, toStatus(InvalidArgumentError("invalid attribute set", violations...))
+	}
+	id, err := s.AttributeService.CreateAttributeSet(ctx, set)
+	if err != nil {
+		log.Printf("Failed to add attribute set %v. Error: %v", in.Name, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Attribute set %v : %v - Added.", id, in.Name)
+	return &cpb.AttributeSetId{Id: id}, nil
+}
+
+func (s *Server) GetAttributeSet(ctx context.Context, in *cpb.AttributeSetId) (*cpb.AttributeSet, error) {
+	set, err := s.AttributeService.GetAttributeSetByID(ctx, in.Id)
+	if err != nil {
+		log.Printf("Failed to find attribute set %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	return &cpb.AttributeSet{Id: set.ID, Name: set.Name, AttributeIds: set.AttributeIDs}, nil
+}
+
+func (s *Server) UpdateAttributeSet(ctx context.Context, in *cpb.AttributeSet) (*pb.Empty, error) {
+	set := &DbAttributeSet{ID: in.Id, Name: in.Name, AttributeIDs: in.AttributeIds}
+	if violations := s.validator().AttributeSetViolations(set); len(violations) > 0 {
+		log.Printf("Rejected attribute set %v : %v. Error: %v", in.Id, in.Name, violations)
+		return nil, toStatus(InvalidArgumentError("invalid attribute set", violations...))
+	}
+	if err := s.AttributeService.UpdateAttributeSet(ctx, set); err != nil {
+		log.Printf("Failed to update attribute set %v : %v. Error: %v", in.Id, in.Name, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Attribute set %v : %v - Updated.", in.Id, in.Name)
+	return new(pb.Empty), nil
+}
+
+func (s *Server) SetProductAttributes(ctx context.Context, in *cpb.ProductAttributes) (*pb.Empty, error) {
+	attributes := &ProductAttributes{ProductID: in.ProductId, AttributeSetID: in.AttributeSetId}
+	for _, value := range in.Values {
+		attributes.Values = append(attributes.Values, AttributeValue{Code: value.Code, Values: value.Values})
+	}
+	if err := s.AttributeService.SetProductAttributes(ctx, attributes); err != nil {
+		log.Printf("Failed to set attributes of product %v. Error: %v", in.ProductId, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Product %v - %v attributes set.", in.ProductId, len(in.Values))
+	return new(pb.Empty), nil
+}
+
+func (s *Server) GetProductAttributes(ctx context.Context, in *pb.ProductId) (*cpb.ProductAttributes, error) {
+	err := s.requireVisible(ctx, in.Id)
+	var attributes *ProductAttributes
+	if err == nil {
+		attributes, err = s.AttributeService.GetProductAttributes(ctx, in.Id)
+	}
+	if err != nil {
+		log.Printf("Failed to get attributes of product %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	out := &cpb.ProductAttributes{ProductId: attributes.ProductID, AttributeSetId: attributes.AttributeSetID}
+	for _, value := range attributes.Values {
+		out.Values = append(out.Values, &cpb.AttributeValue{Code: value.Code, Values: value.Values})
+	}
+	return out, nil
+}
+
+func (s *Server) CreateSynonymSet(ctx context.Context, in *cpb.SynonymSet) (*cpb.SynonymSetId, error) {
+	set := protoToSynonymSet(in)
+	if violations := s.validator().SynonymSetViolations(set); len(violations) > 0 {
+		log.Printf("Rejected synonym set %v. Error: %v", in.Terms, violations)
+		return nil, toStatus(InvalidArgumentError("invalid synonym set", violations...))
+	}
+	id, err := s.DictionaryService.CreateSynonymSet(ctx, set)
+	if err != nil {
+		log.Printf("Failed to add synonym set %v. Error: %v", in.Terms, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Synonym set %v : %v - Added.", id, in.Terms)
+	return &cpb.SynonymSetId{Id: id}, nil
+}
+
+func (s *Server) GetSynonymSet(ctx context.Context, in *cpb.SynonymSetId) (*cpb.SynonymSet, error) {
+	set, err := s.DictionaryService.GetSynonymSetByID(ctx, in.Id)
+	if err != nil {
+		log.Printf("Failed to find synonym set %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	return synonymSetToProto(set), nil
+}
+
+func (s *Server) UpdateSynonymSet(ctx context.Context, in *cpb.SynonymSet) (*pb.Empty, error) {
+	set := protoToSynonymSet(in)
+	if violations := s.validator().SynonymSetViolations(set); len(violations) > 0 {
+		log.Printf("Rejected synonym set %v : %v. Error: %v", in.Id, in.Terms, violations)
+		return nil, toStatus(InvalidArgumentError("invalid synonym set", violations...))
+	}
+	if err := s.DictionaryService.UpdateSynonymSet(ctx, set); err != nil {
+		log.Printf("Failed to update synonym set %v : %v. Error: %v", in.Id, in.Terms, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Synonym set %v : %v - Updated.", in.Id, in.Terms)
+	return new(pb.Empty), nil
+}
+
+func (s *Server) DeleteSynonymSet(ctx context.Context, in *cpb.SynonymSetId) (*pb.Empty, error) {
+	if err := s.DictionaryService.DeleteSynonymSetByID(ctx, in.Id); err != nil {
+		log.Printf("Failed to delete synonym set %v. Error: %v", in.Id, err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Synonym set %v - Deleted.", in.Id)
+	return new(pb.Empty), nil
+}
+
+func (s *Server) ListSynonymSets(ctx context.Context, in *pb.Empty) (*cpb.SynonymSetList, error) {
+	sets, err := s.DictionaryService.ListSynonymSets(ctx)
+	if err != nil {
+		log.Printf("Failed to obtain synonym set list. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	out := &cpb.SynonymSetList{SynonymSets: make([]*cpb.SynonymSet, len(sets))}
+	for i, set := range sets {
+		out.SynonymSets[i] = synonymSetToProto(set)
+	}
+	return out, nil
+}
+
+func (s *Server) GetStopWords(ctx context.Context, in *pb.Empty) (*cpb.StopWords, error) {
+	words, err := s.DictionaryService.GetStopWords(ctx)
+	if err != nil {
+		log.Printf("Failed to obtain stop words. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	return &cpb.StopWords{Words: words}, nil
+}
+
+func (s *Server) SetStopWords(ctx context.Context, in *cpb.StopWords) (*pb.Empty, error) {
+	if violations := s.validator().StopWordViolations(in.Words); len(violations) > 0 {
+		log.Printf("Rejected stop words. Error: %v", violations)
+		return nil, toStatus(InvalidArgumentError("invalid stop words", violations...))
+	}
+	if err := s.DictionaryService.SetStopWords(ctx, in.Words); err != nil {
+		log.Printf("Failed to set stop words. Error: %v", err)
+		return nil, toStatus(err)
+	}
+	log.Printf("Stop words - %v set.", len(in.Words))
+	return new(pb.Empty), nil
+}
+
+// protoToProduct reads the float price of the product API in currency.
+// Non-finite prices become zero and must be rejected beforehand.
+func protoToProduct(product *pb.Product, currency string) *DbProduct {
+	price, _ := MoneyFromFloat32(product.Price, currency)
+	price.Currency = currency
 	return &DbProduct{
 		ID:          product.Id,
 		Name:        product.Name,
 		Sku:         product.Sku,
 		Description: product.Description,
-		Price:       product.Price,
+		Price:       price,
 		Image:       product.Image,
 	}
 }
@@ -97,7 +1302,182 @@ func productToProto(dbProduct *DbProduct) *pb.Product {
 		Name:        dbProduct.Name,
 		Sku:         dbProduct.Sku,
 		Description: dbProduct.Description,
-		Price:       dbProduct.Price,
+		Price:       dbProduct.Price.Float32(),
 		Image:       dbProduct.Image,
 	}
 }
+
+func variantToProto(variant *DbVariant) *cpb.Variant {
+	out := &cpb.Variant{
+		Id:        variant.ID,
+		ProductId: variant.ProductID,
+		Sku:       variant.Sku,
+		Image:     variant.Image,
+		Options:   variant.Options,
+	}
+	if variant.HasPrice() {
+		out.Price = moneyToProto(variant.Price)
+	}
+	return out
+}
+
+// optionalID maps the zero id of the API to nil.
+func optionalID(id uint64) *uint64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+func protoToCategory(category *cpb.Category) *DbCategory {
+	return &DbCategory{
+		ID:       category.Id,
+		ParentID: optionalID(category.ParentId),
+		Name:     category.Name,
+		Slug:     category.Slug,
+		Position: int(category.Position),
+	}
+}
+
+func categoryToProto(category *DbCategory) *cpb.Category {
+	out := &cpb.Category{
+		Id:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		Position: int32(category.Position),
+		Path:     category.Path,
+	}
+	if category.ParentID != nil {
+		out.ParentId = *category.ParentID
+	}
+	return out
+}
+
+func categoryNodesToProto(nodes []*CategoryNode) []*cpb.Category {
+	out := make([]*cpb.Category, len(nodes))
+	for i, node := range nodes {
+		out[i] = categoryToProto(node.DbCategory)
+		out[i].Children = categoryNodesToProto(node.Children)
+	}
+	return out
+}
+
+// Unspecified lifecycle values map to the empty status and visibility.
+var productStatuses = map[cpb.ProductStatus]ProductStatus{
+	cpb.ProductStatus_PRODUCT_STATUS_DRAFT:    ProductDraft,
+	cpb.ProductStatus_PRODUCT_STATUS_ACTIVE:   ProductActive,
+	cpb.ProductStatus_PRODUCT_STATUS_ARCHIVED: ProductArchived,
+}
+
+var productVisibilities = map[cpb.ProductVisibility]ProductVisibility{
+	cpb.ProductVisibility_PRODUCT_VISIBILITY_CATALOG: VisibilityCatalog,
+	cpb.ProductVisibility_PRODUCT_VISIBILITY_SEARCH:  VisibilitySearch,
+	cpb.ProductVisibility_PRODUCT_VISIBILITY_BOTH:    VisibilityBoth,
+	cpb.ProductVisibility_PRODUCT_VISIBILITY_HIDDEN:  VisibilityHidden,
+}
+
+var attributeTypes = map[cpb.AttributeType]AttributeType{
+	cpb.AttributeType_ATTRIBUTE_TYPE_STRING:     AttributeString,
+	cpb.AttributeType_ATTRIBUTE_TYPE_INT:        AttributeInt,
+	cpb.AttributeType_ATTRIBUTE_TYPE_DECIMAL:    AttributeDecimal,
+	cpb.AttributeType_ATTRIBUTE_TYPE_BOOL:       AttributeBool,
+	cpb.AttributeType_ATTRIBUTE_TYPE_ENUM:       AttributeEnum,
+	cpb.AttributeType_ATTRIBUTE_TYPE_MULTI_ENUM: AttributeMultiEnum,
+}
+
+func protoToAttribute(attribute *cpb.Attribute) *DbAttribute {
+	out := &DbAttribute{
+		ID:       attribute.Id,
+		Code:     attribute.Code,
+		Name:     attribute.Name,
+		Type:     attributeTypes[attribute.Type],
+		Required: attribute.Required,
+		Options:  attribute.Options,
+	}
+	if rules := attribute.Rules; rules != nil {
+		out.Rules = AttributeRules{MaxLength: int(rules.MaxLength), Pattern: rules.Pattern, Min: rules.Min, Max: rules.Max}
+	}
+	return out
+}
+
+func attributeToProto(attribute *DbAttribute) *cpb.Attribute {
+	out := &cpb.Attribute{
+		Id:       attribute.ID,
+		Code:     attribute.Code,
+		Name:     attribute.Name,
+		Required: attribute.Required,
+		Options:  attribute.Options,
+		Rules: &cpb.AttributeRules{
+			MaxLength: int32(attribute.Rules.MaxLength),
+			Pattern:   attribute.Rules.Pattern,
+			Min:       attribute.Rules.Min,
+			Max:       attribute.Rules.Max,
+		},
+	}
+	for protoType, attributeType := range attributeTypes {
+		if attributeType == attribute.Type {
+			out.Type = protoType
+		}
+	}
+	return out
+}
+
+var synonymTypes = map[cpb.SynonymType]SynonymType{
+	cpb.SynonymType_SYNONYM_TYPE_EQUIVALENT: SynonymEquivalent,
+	cpb.SynonymType_SYNONYM_TYPE_ONE_WAY:    SynonymOneWay,
+}
+
+func protoToSynonymSet(set *cpb.SynonymSet) *DbSynonymSet {
+	return &DbSynonymSet{ID: set.Id, Type: synonymTypes[set.Type], Input: set.Input, Terms: set.Terms}
+}
+
+func synonymSetToProto(set *DbSynonymSet) *cpb.SynonymSet {
+	out := &cpb.SynonymSet{Id: set.ID, Input: set.Input, Terms: set.Terms}
+	for protoType, synonymType := range synonymTypes {
+		if synonymType == set.Type {
+			out.Type = protoType
+		}
+	}
+	return out
+}
+
+func moneyToProto(price Money) *cpb.Price {
+	return &cpb.Price{Currency: price.Currency, AmountMinor: price.Amount, Amount: price.Decimal()}
+}
+
+// protoToMoney prefers the decimal amount over amount_minor when both are set.
+func protoToMoney(price *cpb.Price) (Money, error) {
+	if price.Amount == "" {
+		return Money{Amount: price.AmountMinor, Currency: price.Currency}, nil
+	}
+	if !ValidCurrency(price.Currency) {
+		// Reported by the validator with the currency field.
+		return Money{Currency: price.Currency}, nil
+	}
+	return ParseMoney(price.Amount, price.Currency)
+}
+
+var productChangeTypes = map[ProductChangeType]cpb.ProductChangeType{
+	ProductAvailable:   cpb.ProductChangeType_PRODUCT_CHANGE_TYPE_AVAILABLE,
+	ProductUnavailable: cpb.ProductChangeType_PRODUCT_CHANGE_TYPE_UNAVAILABLE,
+}
+
+func productChangeToProto(change ProductChange) *cpb.ProductChange {
+	return &cpb.ProductChange{ProductId: change.ProductID, Type: productChangeTypes[change.Type], At: timestamppb.New(change.At)}
+}
+
+// timeToProto converts an optional time, nil stays unset.
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func protoToTime(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	converted := t.AsTime()
+	return &converted
+}
@@ -1,23 +1,36 @@
 package internal
 
 import (
+	cpb "catalog/gen/go/catalog"
 	"context"
+	"database/sql/driver"
 	"fmt"
+	"math"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	pb "github.com/akolpakov-somehash/headless-ecom-protos/gen/go/catalog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 )
 
 func TestServer_AddProduct(t *testing.T) {
 	// given
 	testCases := []struct {
-		name        string
-		product     *pb.Product
-		expectedId  *pb.ProductId
-		expectedErr error
-		setup       func(p *DbProduct) *ProductServiceMock
+		name         string
+		product      *pb.Product
+		expectedId   *pb.ProductId
+		expectedCode codes.Code
+		setup        func(p *DbProduct) *ProductServiceMock
 	}{
 		{
 			name: "Create a new product",
@@ -26,24 +39,64 @@ func TestServer_AddProduct(t *testing.T) {
 				Sku:         "test-sku",
 				Description: "Test Description",
 				Price:       100.0,
-				Image:       "test-image",
+				Image:       "https://cdn.example.com/test-image.jpg",
 			},
-			expectedId:  &pb.ProductId{Id: 1},
-			expectedErr: nil,
+			expectedId:   &pb.ProductId{Id: 1},
+			expectedCode: codes.OK,
 			setup: func(p *DbProduct) *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("CreateProduct", p).Return(uint64(1), nil)
+				mockProductService.On("CreateProduct", mock.Anything, p).Return(uint64(1), nil)
 				return mockProductService
 			},
 		},
 		{
-			name:        "Create a new product with an error",
-			product:     &pb.Product{}, // empty product
-			expectedId:  nil,
-			expectedErr: fmt.Errorf("failed to add product: %w", gorm.ErrInvalidData),
+			name:         "Create an invalid product",
+			product:      &pb.Product{Price: -1, Image: "not a url"},
+			expectedId:   nil,
+			expectedCode: codes.InvalidArgument,
+			setup: func(p *DbProduct) *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:         "Create a product with a non-finite price",
+			product:      &pb.Product{Name: "Test Product", Sku: "test-sku", Price: float32(math.Inf(1))},
+			expectedId:   nil,
+			expectedCode: codes.InvalidArgument,
+			setup: func(p *DbProduct) *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:         "Create a new product with an error",
+			product:      &pb.Product{Name: "Test Product", Sku: "test-sku"},
+			expectedId:   nil,
+			expectedCode: codes.Internal,
+			setup: func(p *DbProduct) *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("CreateProduct", mock.Anything, p).Return(uint64(ErrorId), gorm.ErrInvalidData)
+				return mockProductService
+			},
+		},
+		{
+			name:         "Create a duplicate product",
+			product:      &pb.Product{Name: "Test Product", Sku: "test-sku"},
+			expectedId:   nil,
+			expectedCode: codes.AlreadyExists,
+			setup: func(p *DbProduct) *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("CreateProduct", mock.Anything, p).Return(uint64(ErrorId), AlreadyExistsError(ResourceProduct, p.Sku))
+				return mockProductService
+			},
+		},
+		{
+			name:         "Create a product while the database is down",
+			product:      &pb.Product{Name: "Test Product", Sku: "test-sku"},
+			expectedId:   nil,
+			expectedCode: codes.Unavailable,
 			setup: func(p *DbProduct) *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("CreateProduct", p).Return(uint64(ErrorId), gorm.ErrInvalidData)
+				mockProductService.On("CreateProduct", mock.Anything, p).Return(uint64(ErrorId), UnavailableError(driver.ErrBadConn))
 				return mockProductService
 			},
 		},
@@ -51,7 +104,7 @@ func TestServer_AddProduct(t *testing.T) {
 
 	for _, tc := range testCases {
 		// when
-		mockProductService := tc.setup(protoToProduct(tc.product))
+		mockProductService := tc.setup(protoToProduct(tc.product, DefaultCurrency))
 		server := &Server{
 			ProductService: mockProductService,
 		}
@@ -60,18 +113,49 @@ func TestServer_AddProduct(t *testing.T) {
 		productId, err := server.AddProduct(ctx, tc.product)
 
 		// then
-		assert.Equal(t, tc.expectedErr, err)
+		assert.Equal(t, tc.expectedCode, status.Code(err))
 		assert.Equal(t, tc.expectedId, productId)
+		mockProductService.AssertExpectations(t)
 	}
 }
 
+func TestServer_AddProduct_IdempotencyKey(t *testing.T) {
+	// given
+	product := &pb.Product{Name: "Test Product", Sku: "test-sku", Price: 10}
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyHeader, key))
+	}
+	first, err := idempotencyKey(withKey("retry-1"), product)
+	assert.NoError(t, err)
+	again, err := idempotencyKey(withKey("retry-1"), &pb.Product{Name: "Test Product", Sku: "test-sku", Price: 10})
+	assert.NoError(t, err)
+	changed, err := idempotencyKey(withKey("retry-1"), &pb.Product{Name: "Test Product", Sku: "test-sku", Price: 12})
+	assert.NoError(t, err)
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("CreateProductOnce", mock.Anything, *first, protoToProduct(product, DefaultCurrency)).Return(uint64(5), true, nil)
+	server := &Server{ProductService: mockProductService}
+
+	// when
+	res, err := server.AddProduct(withKey("retry-1"), product)
+	_, tooLong := server.AddProduct(withKey(strings.Repeat("k", MaxIdempotencyKeyLength+1)), product)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.ProductId{Id: 5}, res)
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, first.RequestHash, changed.RequestHash)
+	assert.Equal(t, codes.InvalidArgument, status.Code(tooLong))
+	mockProductService.AssertExpectations(t)
+}
+
 func TestServer_UpdateProductInfo(t *testing.T) {
 	// given
 	testCases := []struct {
 		name           string
 		product        *pb.Product
 		expecterResult *pb.Empty
-		expectedErr    error
+		expectedCode   codes.Code
+		noRevision     bool
 		setup          func(p *DbProduct) *ProductServiceMock
 	}{
 		{
@@ -82,62 +166,127 @@ func TestServer_UpdateProductInfo(t *testing.T) {
 				Sku:         "test-sku",
 				Description: "Test Description",
 				Price:       100.0,
-				Image:       "test-image",
+				Image:       "https://cdn.example.com/test-image.jpg",
 			},
 			expecterResult: new(pb.Empty),
-			expectedErr:    nil,
+			expectedCode:   codes.OK,
 			setup: func(p *DbProduct) *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("GetProductByID", p.ID).Return(p, nil)
-				mockProductService.On("UpdateProduct", p).Return(nil)
+				mockProductService.On("InTransaction", mock.Anything).Return(nil)
+				mockProductService.On("GetProductByID", mock.Anything, p.ID).Return(p, nil)
+				mockProductService.On("UpdateProduct", mock.Anything, p).Return(nil)
 				return mockProductService
 			},
 		},
 		{
-			name: "Update a product with an error",
+			name: "Update a product with invalid fields",
 			product: &pb.Product{
 				Id: 1,
 			},
-			expectedErr:    fmt.Errorf("failed to update product: %w", gorm.ErrInvalidData),
+			expectedCode:   codes.InvalidArgument,
+			expecterResult: nil,
+			setup: func(p *DbProduct) *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name: "Update a product with an error",
+			product: &pb.Product{
+				Id:   1,
+				Name: "Test Product",
+				Sku:  "test-sku",
+			},
+			expectedCode:   codes.Internal,
 			expecterResult: nil,
 			setup: func(p *DbProduct) *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("GetProductByID", p.ID).Return(p, nil)
-				mockProductService.On("UpdateProduct", p).Return(gorm.ErrInvalidData)
+				mockProductService.On("InTransaction", mock.Anything).Return(nil)
+				mockProductService.On("GetProductByID", mock.Anything, p.ID).Return(p, nil)
+				mockProductService.On("UpdateProduct", mock.Anything, p).Return(gorm.ErrInvalidData)
 				return mockProductService
 			},
 		},
 		{
 			name: "Update a missing product",
 			product: &pb.Product{
-				Id: 1,
+				Id:   1,
+				Name: "Test Product",
+				Sku:  "test-sku",
+			},
+			expectedCode:   codes.NotFound,
+			expecterResult: nil,
+			setup: func(p *DbProduct) *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("InTransaction", mock.Anything).Return(nil)
+				mockProductService.On("GetProductByID", mock.Anything, p.ID).Return(nil, NotFoundError(ResourceProduct, p.ID))
+				return mockProductService
+			},
+		},
+		{
+			name: "Update a product changed in the meantime",
+			product: &pb.Product{
+				Id:   1,
+				Name: "Test Product",
+				Sku:  "test-sku",
 			},
-			expectedErr:    fmt.Errorf("product not found: failed to get a product 1: record not found"),
+			expectedCode:   codes.Aborted,
 			expecterResult: nil,
 			setup: func(p *DbProduct) *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("GetProductByID", p.ID).Return(nil, fmt.Errorf("failed to get a product %d: %w", p.ID, gorm.ErrRecordNotFound))
+				mockProductService.On("InTransaction", mock.Anything).Return(nil)
+				mockProductService.On("GetProductByID", mock.Anything, p.ID).Return(p, nil)
+				mockProductService.On("UpdateProduct", mock.Anything, p).Return(revisionConflict(p.ID, 2, 1))
 				return mockProductService
 			},
 		},
+		{
+			name: "Update a product while the database is down",
+			product: &pb.Product{
+				Id:   1,
+				Name: "Test Product",
+				Sku:  "test-sku",
+			},
+			expectedCode:   codes.Unavailable,
+			expecterResult: nil,
+			setup: func(p *DbProduct) *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("InTransaction", mock.Anything).Return(UnavailableError(driver.ErrBadConn))
+				return mockProductService
+			},
+		},
+		{
+			name: "Update a product without a revision",
+			product: &pb.Product{
+				Id:   1,
+				Name: "Test Product",
+				Sku:  "test-sku",
+			},
+			expectedCode:   codes.FailedPrecondition,
+			expecterResult: nil,
+			noRevision:     true,
+			setup: func(p *DbProduct) *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		// when
-		mockProductService := tc.setup(protoToProduct(tc.product))
+		p := protoToProduct(tc.product, DefaultCurrency)
+		p.Revision = 1
+		mockProductService := tc.setup(p)
 		server := &Server{
 			ProductService: mockProductService,
 		}
 
-		ctx := context.Background()
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ifMatchHeader, `"1"`))
+		if tc.noRevision {
+			ctx = context.Background()
+		}
 		res, err := server.UpdateProduct(ctx, tc.product)
 
 		// then
-		if tc.expectedErr != nil {
-			assert.Equal(t, tc.expectedErr.Error(), err.Error())
-		} else {
-			assert.Nil(t, err)
-		}
+		assert.Equal(t, tc.expectedCode, status.Code(err))
 		assert.Equal(t, tc.expecterResult, res)
 	}
 }
@@ -148,7 +297,8 @@ func TestServer_DeleteProduct(t *testing.T) {
 		name           string
 		productId      *pb.ProductId
 		expectedResult *pb.Empty
-		expectedErr    error
+		expectedCode   codes.Code
+		ifMatch        string
 		setup          func(id uint64) *ProductServiceMock
 	}{
 		{
@@ -157,10 +307,11 @@ func TestServer_DeleteProduct(t *testing.T) {
 				Id: 1,
 			},
 			expectedResult: new(pb.Empty),
-			expectedErr:    nil,
+			expectedCode:   codes.OK,
+			ifMatch:        "3",
 			setup: func(id uint64) *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("DeleteProductByID", id).Return(nil)
+				mockProductService.On("DeleteProductByID", mock.Anything, id, uint64(3)).Return(nil)
 				return mockProductService
 			},
 		},
@@ -170,13 +321,51 @@ func TestServer_DeleteProduct(t *testing.T) {
 				Id: 1,
 			},
 			expectedResult: nil,
-			expectedErr:    gorm.ErrRecordNotFound,
+			expectedCode:   codes.NotFound,
+			ifMatch:        "3",
+			setup: func(id uint64) *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("DeleteProductByID", mock.Anything, id, uint64(3)).Return(NotFoundError(ResourceProduct, id))
+				return mockProductService
+			},
+		},
+		{
+			name: "Delete a product changed in the meantime",
+			productId: &pb.ProductId{
+				Id: 1,
+			},
+			expectedResult: nil,
+			expectedCode:   codes.Aborted,
+			ifMatch:        "3",
 			setup: func(id uint64) *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("DeleteProductByID", id).Return(gorm.ErrRecordNotFound)
+				mockProductService.On("DeleteProductByID", mock.Anything, id, uint64(3)).Return(revisionConflict(id, 4, 3))
 				return mockProductService
 			},
 		},
+		{
+			name: "Delete a product without a revision",
+			productId: &pb.ProductId{
+				Id: 1,
+			},
+			expectedResult: nil,
+			expectedCode:   codes.FailedPrecondition,
+			setup: func(id uint64) *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name: "Delete a product with a malformed revision",
+			productId: &pb.ProductId{
+				Id: 1,
+			},
+			expectedResult: nil,
+			expectedCode:   codes.InvalidArgument,
+			ifMatch:        "W/latest",
+			setup: func(id uint64) *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -187,21 +376,127 @@ func TestServer_DeleteProduct(t *testing.T) {
 		}
 
 		ctx := context.Background()
+		if tc.ifMatch != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(ifMatchHeader, tc.ifMatch))
+		}
 		res, err := server.DeleteProduct(ctx, tc.productId)
 
 		// then
-		assert.Equal(t, tc.expectedErr, err)
+		assert.Equal(t, tc.expectedCode, status.Code(err))
 		assert.Equal(t, tc.expectedResult, res)
+		mockProductService.AssertExpectations(t)
 	}
 }
 
+func TestServer_BatchGetProducts(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductsByIDs", mock.Anything, []uint64{1, 2, 3}).
+		Return([]*DbProduct{{ID: 1, Name: "Running Shirt"}, {ID: 2, Name: "Secret Launch", Status: ProductDraft}}, []uint64{3}, nil)
+	server := &Server{ProductService: mockProductService}
+
+	// when
+	res, err := server.BatchGetProducts(context.Background(), &cpb.BatchGetProductsRequest{Ids: []uint64{1, 2, 3}})
+	_, tooMany := server.BatchGetProducts(context.Background(), &cpb.BatchGetProductsRequest{Ids: make([]uint64, MaxBatchSize+1)})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.Product{{Id: 1, Name: "Running Shirt"}}, res.Products)
+	assert.Equal(t, []uint64{3, 2}, res.MissingIds)
+	assert.Equal(t, codes.InvalidArgument, status.Code(tooMany))
+	mockProductService.AssertExpectations(t)
+}
+
+func TestServer_BatchCreateProducts(t *testing.T) {
+	valid := &pb.Product{Name: "Desk Lamp", Sku: "DL-1", Price: 25}
+	invalid := &pb.Product{Sku: "NO-NAME"}
+
+	t.Run("Invalid item fails an atomic batch", func(t *testing.T) {
+		// given
+		mockProductService := new(ProductServiceMock)
+		server := &Server{ProductService: mockProductService}
+		// when
+		res, err := server.BatchCreateProducts(context.Background(), &cpb.BatchCreateProductsRequest{Products: []*pb.Product{valid, invalid}})
+		// then
+		assert.Nil(t, res)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.True(t, strings.HasPrefix(status.Convert(err).Message(), "item 1: "))
+		mockProductService.AssertExpectations(t)
+	})
+
+	t.Run("Best effort batch writes the valid items", func(t *testing.T) {
+		// given
+		mockProductService := new(ProductServiceMock)
+		mockProductService.On("BatchCreateProducts", mock.Anything, []*DbProduct{protoToProduct(valid, DefaultCurrency)}, BatchBestEffort).
+			Return([]BatchResult{{ID: 7, Revision: 1}}, nil)
+		server := &Server{ProductService: mockProductService}
+		// when
+		res, err := server.BatchCreateProducts(context.Background(), &cpb.BatchCreateProductsRequest{
+			Products: []*pb.Product{invalid, valid},
+			Mode:     cpb.BatchMode_BATCH_MODE_BEST_EFFORT,
+		})
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, res.Results, 2)
+		assert.Equal(t, int32(codes.InvalidArgument), res.Results[0].Code)
+		assert.Equal(t, &cpb.BatchResult{Id: 7, Revision: 1, Code: int32(codes.OK)}, res.Results[1])
+		mockProductService.AssertExpectations(t)
+	})
+}
+
+func TestServer_BatchUpdateProducts(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("BatchUpdateProducts", mock.Anything, []*DbProduct{{ID: 1, Name: "Desk Lamp", Sku: "DL-1", Price: Money{Currency: "USD"}, Revision: 3}}, BatchBestEffort).
+		Return([]BatchResult{{ID: 1, Err: revisionConflict(1, 4, 3)}}, nil)
+	server := &Server{ProductService: mockProductService}
+
+	// when
+	res, err := server.BatchUpdateProducts(context.Background(), &cpb.BatchUpdateProductsRequest{
+		Updates: []*cpb.ProductUpdate{
+			{Product: &pb.Product{Id: 1, Name: "Desk Lamp", Sku: "DL-1"}, Revision: 3},
+			{Product: &pb.Product{Id: 2, Name: "Coffee Mug", Sku: "MG-1"}},
+		},
+		Mode: cpb.BatchMode_BATCH_MODE_BEST_EFFORT,
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, res.Results, 2)
+	assert.Equal(t, int32(codes.Aborted), res.Results[0].Code)
+	assert.Equal(t, &cpb.BatchResult{Id: 2, Code: int32(codes.FailedPrecondition), Message: "the revision of the product is required"}, res.Results[1])
+	mockProductService.AssertExpectations(t)
+}
+
+func TestServer_BatchDeleteProducts(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("BatchDeleteProducts", mock.Anything, []ProductRevision{{ID: 1, Revision: 2}, {ID: 2, Revision: 4}}, BatchAtomic).
+		Return([]BatchResult{{ID: 1}, {ID: 2}}, nil)
+	server := &Server{ProductService: mockProductService}
+
+	// when
+	res, err := server.BatchDeleteProducts(context.Background(), &cpb.BatchDeleteProductsRequest{
+		Deletes: []*cpb.ProductDelete{{Id: 1, Revision: 2}, {Id: 2, Revision: 4}},
+	})
+	_, unconditional := server.BatchDeleteProducts(context.Background(), &cpb.BatchDeleteProductsRequest{
+		Deletes: []*cpb.ProductDelete{{Id: 1}},
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []*cpb.BatchResult{{Id: 1, Code: int32(codes.OK)}, {Id: 2, Code: int32(codes.OK)}}, res.Results)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(unconditional))
+	mockProductService.AssertExpectations(t)
+}
+
 func TestServer_GetProductInfo(t *testing.T) {
 	// given
 	testCases := []struct {
 		name           string
 		productId      *pb.ProductId
 		expectedResult *pb.Product
-		expectedErr    error
+		expectedCode   codes.Code
 		setup          func(p *DbProduct) *ProductServiceMock
 	}{
 		{
@@ -215,12 +510,25 @@ func TestServer_GetProductInfo(t *testing.T) {
 				Sku:         "test-sku",
 				Description: "Test Description",
 				Price:       100.0,
-				Image:       "test-image",
+				Image:       "https://cdn.example.com/test-image.jpg",
 			},
-			expectedErr: nil,
+			expectedCode: codes.OK,
 			setup: func(p *DbProduct) *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("GetProductByID", p.ID).Return(p, nil)
+				mockProductService.On("GetProductByID", mock.Anything, p.ID).Return(p, nil)
+				return mockProductService
+			},
+		},
+		{
+			name: "Get a product after the client deadline",
+			productId: &pb.ProductId{
+				Id: 3,
+			},
+			expectedResult: nil,
+			expectedCode:   codes.DeadlineExceeded,
+			setup: func(p *DbProduct) *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductByID", mock.Anything, p.ID).Return(nil, fmt.Errorf("failed to get a product %d: %w", p.ID, context.DeadlineExceeded))
 				return mockProductService
 			},
 		},
@@ -230,10 +538,10 @@ func TestServer_GetProductInfo(t *testing.T) {
 				Id: 2,
 			},
 			expectedResult: nil,
-			expectedErr:    fmt.Errorf("product not found: failed to get a product 2: record not found"),
+			expectedCode:   codes.NotFound,
 			setup: func(p *DbProduct) *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("GetProductByID", p.ID).Return(nil, fmt.Errorf("failed to get a product %d: %w", p.ID, gorm.ErrRecordNotFound))
+				mockProductService.On("GetProductByID", mock.Anything, p.ID).Return(nil, NotFoundError(ResourceProduct, p.ID))
 				return mockProductService
 			},
 		},
@@ -243,9 +551,9 @@ func TestServer_GetProductInfo(t *testing.T) {
 		// when
 		var mockProductService *ProductServiceMock
 		if tc.expectedResult != nil {
-			mockProductService = tc.setup(protoToProduct(tc.expectedResult))
+			mockProductService = tc.setup(protoToProduct(tc.expectedResult, DefaultCurrency))
 		} else {
-			mockProductService = tc.setup(protoToProduct(&pb.Product{Id: tc.productId.Id}))
+			mockProductService = tc.setup(protoToProduct(&pb.Product{Id: tc.productId.Id}, DefaultCurrency))
 		}
 		server := &Server{
 			ProductService: mockProductService,
@@ -255,11 +563,63 @@ func TestServer_GetProductInfo(t *testing.T) {
 		res, err := server.GetProductInfo(ctx, tc.productId)
 
 		// then
-		if tc.expectedErr != nil {
-			assert.Equal(t, tc.expectedErr.Error(), err.Error())
-		} else {
-			assert.Nil(t, err)
+		assert.Equal(t, tc.expectedCode, status.Code(err))
+		assert.Equal(t, tc.expectedResult, res)
+	}
+}
+
+func TestServer_GetProductBySku(t *testing.T) {
+	// given
+	testCases := []struct {
+		name           string
+		sku            *cpb.ProductSku
+		expectedResult *pb.Product
+		expectedCode   codes.Code
+		setup          func(sku string) *ProductServiceMock
+	}{
+		{
+			name:           "Get a product by SKU",
+			sku:            &cpb.ProductSku{Sku: "test-sku"},
+			expectedResult: &pb.Product{Id: 1, Name: "Test Product", Sku: "test-sku"},
+			expectedCode:   codes.OK,
+			setup: func(sku string) *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductBySKU", mock.Anything, sku).Return(&DbProduct{ID: 1, Name: "Test Product", Sku: sku}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:         "Get a product by unknown SKU",
+			sku:          &cpb.ProductSku{Sku: "missing-sku"},
+			expectedCode: codes.NotFound,
+			setup: func(sku string) *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductBySKU", mock.Anything, sku).Return(nil, NotFoundError(ResourceProduct, sku))
+				return mockProductService
+			},
+		},
+		{
+			name:         "Get a product by empty SKU",
+			sku:          &cpb.ProductSku{},
+			expectedCode: codes.InvalidArgument,
+			setup: func(sku string) *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		// when
+		mockProductService := tc.setup(tc.sku.Sku)
+		server := &Server{
+			ProductService: mockProductService,
 		}
+
+		ctx := context.Background()
+		res, err := server.GetProductBySku(ctx, tc.sku)
+
+		// then
+		assert.Equal(t, tc.expectedCode, status.Code(err))
 		assert.Equal(t, tc.expectedResult, res)
 	}
 }
@@ -269,7 +629,7 @@ func TestServer_GetProductList(t *testing.T) {
 	testCases := []struct {
 		name           string
 		expectedResult *pb.ProductList
-		expectedErr    error
+		expectedCode   codes.Code
 		setup          func() *ProductServiceMock
 	}{
 		{
@@ -282,20 +642,20 @@ func TestServer_GetProductList(t *testing.T) {
 						Sku:         "test-sku",
 						Description: "Test Description",
 						Price:       100.0,
-						Image:       "test-image",
+						Image:       "https://cdn.example.com/test-image.jpg",
 					},
 				},
 			},
-			expectedErr: nil,
+			expectedCode: codes.OK,
 			setup: func() *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("GetAllProducts").Return([]*DbProduct{{
+				mockProductService.On("GetAllProducts", mock.Anything).Return([]*DbProduct{{
 					ID:          1,
 					Name:        "Test Product",
 					Sku:         "test-sku",
 					Description: "Test Description",
-					Price:       100.0,
-					Image:       "test-image",
+					Price:       Money{Amount: 10000, Currency: "USD"},
+					Image:       "https://cdn.example.com/test-image.jpg",
 				}}, nil)
 				return mockProductService
 			},
@@ -303,10 +663,10 @@ func TestServer_GetProductList(t *testing.T) {
 		{
 			name:           "Get a product list with an error",
 			expectedResult: nil,
-			expectedErr:    fmt.Errorf("failed to obtain product list: %w", gorm.ErrInvalidData),
+			expectedCode:   codes.Unavailable,
 			setup: func() *ProductServiceMock {
 				mockProductService := new(ProductServiceMock)
-				mockProductService.On("GetAllProducts").Return(nil, gorm.ErrInvalidData)
+				mockProductService.On("GetAllProducts", mock.Anything).Return(nil, UnavailableError(driver.ErrBadConn))
 				return mockProductService
 			},
 		},
@@ -323,11 +683,955 @@ func TestServer_GetProductList(t *testing.T) {
 		res, err := server.GetProductList(ctx, new(pb.Empty))
 
 		// then
-		if tc.expectedErr != nil {
-			assert.Equal(t, tc.expectedErr.Error(), err.Error())
-		} else {
-			assert.Nil(t, err)
+		assert.Equal(t, tc.expectedCode, status.Code(err))
+		assert.Equal(t, tc.expectedResult, res)
+	}
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	// given
+	draft := &DbProduct{ID: 1, Name: "Draft", Sku: "draft", Status: ProductDraft, Visibility: VisibilityBoth}
+	searchOnly := &DbProduct{ID: 2, Name: "Search Only", Sku: "search-only", Status: ProductActive, Visibility: VisibilitySearch}
+	live := &DbProduct{ID: 3, Name: "Live", Sku: "live", Status: ProductActive, Visibility: VisibilityBoth}
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(draft, nil)
+	mockProductService.On("GetProductBySKU", mock.Anything, "search-only").Return(searchOnly, nil)
+	mockProductService.On("GetAllProducts", mock.Anything).Return([]*DbProduct{draft, searchOnly, live}, nil)
+	server := &Server{ProductService: mockProductService, AdminToken: "secret"}
+	public := context.Background()
+	admin := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+	wrongToken := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer guess"))
+
+	// when
+	_, publicErr := server.GetProductInfo(public, &pb.ProductId{Id: 1})
+	_, wrongTokenErr := server.GetProductInfo(wrongToken, &pb.ProductId{Id: 1})
+	adminProduct, adminErr := server.GetProductInfo(admin, &pb.ProductId{Id: 1})
+	bySku, bySkuErr := server.GetProductBySku(public, &cpb.ProductSku{Sku: "search-only"})
+	publicList, publicListErr := server.GetProductList(public, new(pb.Empty))
+	adminList, adminListErr := server.GetProductList(admin, new(pb.Empty))
+
+	// then
+	assert.Equal(t, codes.NotFound, status.Code(publicErr))
+	assert.Equal(t, codes.NotFound, status.Code(wrongTokenErr))
+	assert.NoError(t, adminErr)
+	assert.Equal(t, uint64(1), adminProduct.Id)
+	assert.NoError(t, bySkuErr)
+	assert.Equal(t, uint64(2), bySku.Id)
+	assert.NoError(t, publicListErr)
+	assert.Len(t, publicList.Products, 1)
+	assert.Contains(t, publicList.Products, uint64(3))
+	assert.NoError(t, adminListErr)
+	assert.Len(t, adminList.Products, 3)
+}
+
+func TestServer_SetProductLifecycle(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("SetProductLifecycle", mock.Anything, uint64(1), uint64(3), ProductActive, ProductVisibility("")).Return(nil)
+	mockProductService.On("SetProductLifecycle", mock.Anything, uint64(2), uint64(1), ProductDraft, VisibilityHidden).
+		Return(InvalidArgumentError("invalid status transition", FieldViolation{Field: "status", Description: "cannot change from active to draft"}))
+	mockProductService.On("GetProductByID", mock.Anything, uint64(3)).Return(&DbProduct{ID: 3}, nil)
+	server := &Server{ProductService: mockProductService, AdminToken: "secret"}
+	admin := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+
+	// when
+	_, err := server.SetProductLifecycle(admin, &cpb.ProductLifecycle{ProductId: 1, Status: cpb.ProductStatus_PRODUCT_STATUS_ACTIVE, Revision: 3})
+	_, invalidErr := server.SetProductLifecycle(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret", "if-match", "1")), &cpb.ProductLifecycle{
+		ProductId: 2, Status: cpb.ProductStatus_PRODUCT_STATUS_DRAFT, Visibility: cpb.ProductVisibility_PRODUCT_VISIBILITY_HIDDEN,
+	})
+	_, noRevisionErr := server.SetProductLifecycle(admin, &cpb.ProductLifecycle{ProductId: 1, Status: cpb.ProductStatus_PRODUCT_STATUS_ARCHIVED})
+	_, anonymousErr := server.SetProductLifecycle(context.Background(), &cpb.ProductLifecycle{ProductId: 4, Status: cpb.ProductStatus_PRODUCT_STATUS_ARCHIVED})
+	lifecycle, getErr := server.GetProductLifecycle(context.Background(), &pb.ProductId{Id: 3})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(noRevisionErr))
+	assert.Equal(t, codes.Unauthenticated, status.Code(anonymousErr))
+	assert.NoError(t, getErr)
+	assert.Equal(t, &cpb.ProductLifecycle{ProductId: 3, Status: cpb.ProductStatus_PRODUCT_STATUS_ACTIVE, Visibility: cpb.ProductVisibility_PRODUCT_VISIBILITY_BOTH}, lifecycle)
+	mockProductService.AssertExpectations(t)
+}
+
+func TestServer_PatchProduct(t *testing.T) {
+	// given
+	stored := &DbProduct{ID: 1, Name: "Running Shirt", Sku: "RS-1", Description: "Breathable mesh", Price: Money{Amount: 1999, Currency: "USD"}}
+	testCases := []struct {
+		name         string
+		request      *cpb.PatchProductRequest
+		expected     *pb.Product
+		expectedCode codes.Code
+		setup        func() *ProductServiceMock
+	}{
+		{
+			name: "Patch the price",
+			request: &cpb.PatchProductRequest{
+				Product:    &pb.Product{Id: 1, Price: 14.99},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price", "price"}},
+			},
+			expected:     &pb.Product{Id: 1, Name: "Running Shirt", Sku: "RS-1", Description: "Breathable mesh", Price: 14.99},
+			expectedCode: codes.OK,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(stored, nil)
+				mockProductService.On("PatchProduct", mock.Anything, uint64(1), mock.MatchedBy(func(p *DbProduct) bool { return p.Revision == 2 }), []ProductField{FieldPrice}).
+					Return(&DbProduct{ID: 1, Name: "Running Shirt", Sku: "RS-1", Description: "Breathable mesh", Price: Money{Amount: 1499, Currency: "USD"}, Revision: 3}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name: "Unknown path",
+			request: &cpb.PatchProductRequest{
+				Product:    &pb.Product{Id: 1},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"id"}},
+			},
+			expectedCode: codes.InvalidArgument,
+			setup:        func() *ProductServiceMock { return new(ProductServiceMock) },
+		},
+		{
+			name:         "Missing mask",
+			request:      &cpb.PatchProductRequest{Product: &pb.Product{Id: 1, Name: "Shirt"}},
+			expectedCode: codes.InvalidArgument,
+			setup:        func() *ProductServiceMock { return new(ProductServiceMock) },
+		},
+		{
+			name: "Patch leaving the product invalid",
+			request: &cpb.PatchProductRequest{
+				Product:    &pb.Product{Id: 1},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+			},
+			expectedCode: codes.InvalidArgument,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(stored, nil)
+				return mockProductService
+			},
+		},
+		{
+			name: "Patch a missing product",
+			request: &cpb.PatchProductRequest{
+				Product:    &pb.Product{Id: 2, Name: "Shirt"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+			},
+			expectedCode: codes.NotFound,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductByID", mock.Anything, uint64(2)).Return(nil, NotFoundError(ResourceProduct, 2))
+				return mockProductService
+			},
+		},
+		{
+			name: "Patch a product changed in the meantime",
+			request: &cpb.PatchProductRequest{
+				Product:    &pb.Product{Id: 1, Name: "Shirt"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+			},
+			expectedCode: codes.Aborted,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(stored, nil)
+				mockProductService.On("PatchProduct", mock.Anything, uint64(1), mock.Anything, []ProductField{FieldName}).
+					Return(nil, revisionConflict(1, 3, 2))
+				return mockProductService
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			mockProductService := tc.setup()
+			server := &Server{ProductService: mockProductService}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ifMatchHeader, "2"))
+			res, err := server.PatchProduct(ctx, tc.request)
+
+			// then
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Equal(t, tc.expected, res)
+			mockProductService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestServer_Availability(t *testing.T) {
+	// given
+	ended := time.Now().Add(-time.Hour)
+	expired := &DbProduct{ID: 1, Name: "Summer Sale", Status: ProductActive, Visibility: VisibilityBoth, AvailableUntil: &ended}
+	live := &DbProduct{ID: 2, Name: "Live", Status: ProductActive, Visibility: VisibilityBoth}
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(expired, nil)
+	mockProductService.On("GetAllProducts", mock.Anything).Return([]*DbProduct{expired, live}, nil)
+	mockProductService.On("GetProductPrices", mock.Anything, uint64(1)).Return([]Money{{Amount: 1999, Currency: "USD"}}, nil)
+	mockVariantService := new(VariantServiceMock)
+	mockVariantService.On("GetProductVariants", mock.Anything, uint64(1)).Return(&ProductVariants{Product: expired}, nil)
+	server := &Server{ProductService: mockProductService, VariantService: mockVariantService, AdminToken: "secret"}
+	public := context.Background()
+	admin := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+
+	// when
+	_, infoErr := server.GetProductInfo(public, &pb.ProductId{Id: 1})
+	_, pricesErr := server.GetProductPrices(public, &pb.ProductId{Id: 1})
+	_, variantsErr := server.GetProductVariants(public, &pb.ProductId{Id: 1})
+	list, listErr := server.GetProductList(public, new(pb.Empty))
+	adminPrices, adminPricesErr := server.GetProductPrices(admin, &pb.ProductId{Id: 1})
+	availability, availabilityErr := server.GetProductAvailability(admin, &pb.ProductId{Id: 1})
+
+	// then
+	assert.Equal(t, codes.NotFound, status.Code(infoErr))
+	assert.Equal(t, codes.NotFound, status.Code(pricesErr))
+	assert.Equal(t, codes.NotFound, status.Code(variantsErr))
+	assert.NoError(t, listErr)
+	assert.Len(t, list.Products, 1)
+	assert.Contains(t, list.Products, uint64(2))
+	assert.NoError(t, adminPricesErr)
+	assert.Len(t, adminPrices.Prices, 1)
+	assert.NoError(t, availabilityErr)
+	assert.Equal(t, &cpb.ProductAvailability{ProductId: 1, AvailableUntil: timestamppb.New(ended)}, availability)
+}
+
+func TestServer_SetProductAvailability(t *testing.T) {
+	// given
+	from := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("SetProductAvailability", mock.Anything, uint64(1), uint64(2), &from, (*time.Time)(nil)).Return(nil)
+	server := &Server{ProductService: mockProductService, AdminToken: "secret"}
+	admin := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+
+	// when
+	_, err := server.SetProductAvailability(admin, &cpb.ProductAvailability{ProductId: 1, AvailableFrom: timestamppb.New(from), Revision: 2})
+	_, invalidErr := server.SetProductAvailability(admin, &cpb.ProductAvailability{ProductId: 1, AvailableUntil: &timestamppb.Timestamp{Nanos: -1}, Revision: 2})
+	_, noRevisionErr := server.SetProductAvailability(admin, &cpb.ProductAvailability{ProductId: 1, AvailableFrom: timestamppb.New(from)})
+	_, anonymousErr := server.SetProductAvailability(context.Background(), &cpb.ProductAvailability{ProductId: 2, AvailableFrom: timestamppb.New(from)})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(noRevisionErr))
+	assert.Equal(t, codes.Unauthenticated, status.Code(anonymousErr))
+	mockProductService.AssertExpectations(t)
+}
+
+type changeStreamMock struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	sent   []*cpb.ProductChange
+}
+
+func (s *changeStreamMock) Context() context.Context {
+	return s.ctx
+}
+
+// Send ends the stream after the first change.
+func (s *changeStreamMock) Send(change *cpb.ProductChange) error {
+	s.sent = append(s.sent, change)
+	s.cancel()
+	return nil
+}
+
+func TestServer_WatchProductChanges(t *testing.T) {
+	// given
+	feed := NewChangeFeed()
+	server := &Server{Changes: feed}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &changeStreamMock{ctx: ctx, cancel: cancel}
+	at := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+
+	// when
+	done := make(chan error)
+	go func() {
+		done <- server.WatchProductChanges(new(pb.Empty), stream)
+	}()
+	assert.Eventually(t, func() bool {
+		feed.mu.Lock()
+		defer feed.mu.Unlock()
+		return len(feed.subscribers) == 1
+	}, time.Second, time.Millisecond)
+	feed.Publish(ProductChange{ProductID: 1, Type: ProductAvailable, At: at})
+	err := <-done
+	disabledErr := (&Server{}).WatchProductChanges(new(pb.Empty), stream)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []*cpb.ProductChange{{ProductId: 1, Type: cpb.ProductChangeType_PRODUCT_CHANGE_TYPE_AVAILABLE, At: timestamppb.New(at)}}, stream.sent)
+	assert.Equal(t, codes.Unavailable, status.Code(disabledErr))
+}
+
+func TestServer_ListProducts(t *testing.T) {
+	// given
+	maxPrice := float32(49.99)
+	testCases := []struct {
+		name           string
+		request        *cpb.ListProductsRequest
+		expectedResult *cpb.ListProductsResponse
+		expectedCode   codes.Code
+		setup          func() *ProductServiceMock
+	}{
+		{
+			name:    "List a page of products",
+			request: &cpb.ListProductsRequest{PageSize: 1, OrderBy: "price desc", MaxPrice: &maxPrice, SkuPrefix: "test"},
+			expectedResult: &cpb.ListProductsResponse{
+				Products:      []*pb.Product{{Id: 1, Name: "Test Product", Sku: "test-sku", Price: 10}},
+				NextPageToken: "next",
+			},
+			expectedCode: codes.OK,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("ListProducts", mock.Anything, ListQuery{PageSize: 1, SortBy: SortByPrice, Descending: true, MaxPrice: &Money{Amount: 4999, Currency: "USD"}, SkuPrefix: "test", Public: true}).
+					Return(&ListPage{Products: []*DbProduct{{ID: 1, Name: "Test Product", Sku: "test-sku", Price: Money{Amount: 1000, Currency: "USD"}}}, NextPageToken: "next"}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:         "List with a malformed order",
+			request:      &cpb.ListProductsRequest{OrderBy: "price up"},
+			expectedCode: codes.InvalidArgument,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:         "List with an invalid page token",
+			request:      &cpb.ListProductsRequest{PageToken: "garbage"},
+			expectedCode: codes.InvalidArgument,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("ListProducts", mock.Anything, ListQuery{PageToken: "garbage", SortBy: SortByID, Public: true}).
+					Return(nil, InvalidArgumentError("invalid page token"))
+				return mockProductService
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		// when
+		mockProductService := tc.setup()
+		server := &Server{
+			ProductService: mockProductService,
 		}
+
+		ctx := context.Background()
+		res, err := server.ListProducts(ctx, tc.request)
+
+		// then
+		assert.Equal(t, tc.expectedCode, status.Code(err))
 		assert.Equal(t, tc.expectedResult, res)
 	}
 }
+
+type exportStreamMock struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*pb.Product
+}
+
+func (s *exportStreamMock) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportStreamMock) Send(product *pb.Product) error {
+	s.sent = append(s.sent, product)
+	return nil
+}
+
+func TestServer_FacetProducts(t *testing.T) {
+	// given
+	from, to := float32(10), float32(20)
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("FacetProducts", mock.Anything, FacetQuery{
+		ListQuery:       ListQuery{SortBy: SortByID, Attributes: map[string][]string{"color": {"red", "blue"}}, Public: true},
+		FacetAttributes: []string{"color"},
+		PriceRanges:     []PriceRange{{From: &Money{Amount: 1000, Currency: "USD"}, To: &Money{Amount: 2000, Currency: "USD"}}},
+	}).Return(&FacetPage{
+		ListPage:    ListPage{Products: []*DbProduct{{ID: 1, Name: "T-shirt", Price: Money{Amount: 1500, Currency: "USD"}}}},
+		Attributes:  []AttributeFacet{{Code: "color", Values: []FacetValue{{Value: "red", Count: 1}}}},
+		PriceRanges: []PriceRangeCount{{PriceRange: PriceRange{From: &Money{Amount: 1000, Currency: "USD"}, To: &Money{Amount: 2000, Currency: "USD"}}, Count: 1}},
+	}, nil)
+	server := &Server{ProductService: mockProductService}
+
+	// when
+	res, err := server.FacetProducts(context.Background(), &cpb.FacetProductsRequest{
+		List:            &cpb.ListProductsRequest{Attributes: []*cpb.AttributeFilter{{Code: "color", Values: []string{"red", "blue"}}}},
+		FacetAttributes: []string{"color"},
+		PriceRanges:     []*cpb.PriceRange{{From: &from, To: &to}},
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, res.Products, 1)
+	assert.Equal(t, []*cpb.AttributeFacet{{Code: "color", Values: []*cpb.FacetValue{{Value: "red", Count: 1}}}}, res.Attributes)
+	assert.Equal(t, []*cpb.PriceRangeCount{{Range: &cpb.PriceRange{From: &from, To: &to}, Count: 1}}, res.PriceRanges)
+	mockProductService.AssertExpectations(t)
+}
+
+func TestServer_SearchProducts(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("SearchProducts", mock.Anything, SearchQuery{Text: "shirt", PageSize: 1, Public: true}).Return(&SearchPage{
+		Hits:          []SearchHit{{ProductID: 1, Score: 2.5}},
+		Products:      []*DbProduct{{ID: 1, Name: "Running Shirt", Price: Money{Amount: 1999, Currency: "USD"}}},
+		TotalHits:     2,
+		NextPageToken: "next",
+	}, nil)
+	mockProductService.On("SearchProducts", mock.Anything, SearchQuery{Text: "mug", Public: true}).Return(nil, &Error{Kind: KindUnavailable, Message: "product search is not enabled"})
+	server := &Server{ProductService: mockProductService}
+
+	// when
+	res, err := server.SearchProducts(context.Background(), &cpb.SearchProductsRequest{Query: "shirt", PageSize: 1})
+	_, disabledErr := server.SearchProducts(context.Background(), &cpb.SearchProductsRequest{Query: "mug"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &cpb.SearchProductsResponse{
+		Hits:          []*cpb.SearchHit{{Product: &pb.Product{Id: 1, Name: "Running Shirt", Price: 19.99}, Score: 2.5}},
+		TotalSize:     2,
+		NextPageToken: "next",
+	}, res)
+	assert.Equal(t, codes.Unavailable, status.Code(disabledErr))
+}
+
+func TestServer_RebuildSearchIndex(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("RebuildSearchIndex", mock.Anything).Return(3, nil).Once()
+	server := &Server{ProductService: mockProductService, AdminToken: "secret"}
+	admin := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+	wrongToken := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer guess"))
+
+	// when
+	res, err := server.RebuildSearchIndex(admin, &pb.Empty{})
+	_, anonymousErr := server.RebuildSearchIndex(context.Background(), &pb.Empty{})
+	_, wrongTokenErr := server.RebuildSearchIndex(wrongToken, &pb.Empty{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), res.Indexed)
+	assert.Equal(t, codes.Unauthenticated, status.Code(anonymousErr))
+	assert.Equal(t, codes.Unauthenticated, status.Code(wrongTokenErr))
+	mockProductService.AssertExpectations(t)
+}
+
+func TestServer_GetProductPrices(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(&DbProduct{ID: 1}, nil)
+	mockProductService.On("GetProductPrices", mock.Anything, uint64(1)).
+		Return([]Money{{Amount: 1999, Currency: "USD"}, {Amount: 3200, Currency: "JPY"}}, nil)
+	server := &Server{ProductService: mockProductService}
+
+	// when
+	res, err := server.GetProductPrices(context.Background(), &pb.ProductId{Id: 1})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &cpb.ProductPrices{ProductId: 1, Prices: []*cpb.Price{
+		{Currency: "USD", AmountMinor: 1999, Amount: "19.99"},
+		{Currency: "JPY", AmountMinor: 3200, Amount: "3200"},
+	}}, res)
+}
+
+func TestServer_SetProductPrices(t *testing.T) {
+	// given
+	testCases := []struct {
+		name         string
+		request      *cpb.ProductPrices
+		anonymous    bool
+		expectedCode codes.Code
+		setup        func() *ProductServiceMock
+	}{
+		{
+			name: "Set prices from decimal and minor amounts",
+			request: &cpb.ProductPrices{ProductId: 1, Revision: 4, Prices: []*cpb.Price{
+				{Currency: "USD", Amount: "18.999"},
+				{Currency: "EUR", AmountMinor: 1749},
+			}},
+			expectedCode: codes.OK,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("SetProductPrices", mock.Anything, uint64(1), uint64(4), []Money{{Amount: 1900, Currency: "USD"}, {Amount: 1749, Currency: "EUR"}}).Return(nil)
+				return mockProductService
+			},
+		},
+		{
+			name: "Set prices with a duplicate currency",
+			request: &cpb.ProductPrices{ProductId: 1, Prices: []*cpb.Price{
+				{Currency: "EUR", AmountMinor: 1749},
+				{Currency: "EUR", AmountMinor: 1800},
+			}},
+			expectedCode: codes.InvalidArgument,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:         "Set prices with a malformed amount",
+			request:      &cpb.ProductPrices{ProductId: 1, Prices: []*cpb.Price{{Currency: "EUR", Amount: "cheap"}}},
+			expectedCode: codes.InvalidArgument,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:         "Set prices without the admin token",
+			request:      &cpb.ProductPrices{ProductId: 1, Prices: []*cpb.Price{{Currency: "EUR", AmountMinor: 1749}}},
+			anonymous:    true,
+			expectedCode: codes.Unauthenticated,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:         "Set prices without a revision",
+			request:      &cpb.ProductPrices{ProductId: 1, Prices: []*cpb.Price{{Currency: "EUR", AmountMinor: 1749}}},
+			expectedCode: codes.FailedPrecondition,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:         "Set prices of an unknown product",
+			request:      &cpb.ProductPrices{ProductId: 2, Revision: 1, Prices: []*cpb.Price{{Currency: "EUR", AmountMinor: 1749}}},
+			expectedCode: codes.NotFound,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("SetProductPrices", mock.Anything, uint64(2), uint64(1), mock.Anything).Return(NotFoundError(ResourceProduct, 2))
+				return mockProductService
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		// when
+		mockProductService := tc.setup()
+		server := &Server{
+			ProductService: mockProductService,
+			AdminToken:     "secret",
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+		if tc.anonymous {
+			ctx = context.Background()
+		}
+
+		_, err := server.SetProductPrices(ctx, tc.request)
+
+		// then
+		assert.Equal(t, tc.expectedCode, status.Code(err), tc.name)
+		mockProductService.AssertExpectations(t)
+	}
+}
+
+func TestServer_ExportProducts(t *testing.T) {
+	// given
+	testCases := []struct {
+		name         string
+		request      *cpb.ExportProductsRequest
+		expectedSent []*pb.Product
+		expectedCode codes.Code
+		setup        func() *ProductServiceMock
+	}{
+		{
+			name:         "Export products",
+			request:      &cpb.ExportProductsRequest{AfterId: 1, BatchSize: 100},
+			expectedSent: []*pb.Product{{Id: 2, Name: "Product 2"}, {Id: 3, Name: "Product 3"}},
+			expectedCode: codes.OK,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("ExportProducts", mock.Anything, uint64(1), 100, mock.Anything).
+					Return([]*DbProduct{{ID: 2, Name: "Product 2"}, {ID: 3, Name: "Product 3"}}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:         "Export canceled by the client",
+			request:      &cpb.ExportProductsRequest{},
+			expectedSent: []*pb.Product{{Id: 1, Name: "Product 1"}},
+			expectedCode: codes.Canceled,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("ExportProducts", mock.Anything, uint64(0), 0, mock.Anything).
+					Return([]*DbProduct{{ID: 1, Name: "Product 1"}}, context.Canceled)
+				return mockProductService
+			},
+		},
+		{
+			name:         "Export while the database is down",
+			request:      &cpb.ExportProductsRequest{},
+			expectedCode: codes.Unavailable,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("ExportProducts", mock.Anything, uint64(0), 0, mock.Anything).
+					Return(nil, UnavailableError(driver.ErrBadConn))
+				return mockProductService
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		// when
+		mockProductService := tc.setup()
+		server := &Server{
+			ProductService: mockProductService,
+		}
+
+		stream := &exportStreamMock{ctx: context.Background()}
+		err := server.ExportProducts(tc.request, stream)
+
+		// then
+		assert.Equal(t, tc.expectedCode, status.Code(err))
+		assert.Equal(t, tc.expectedSent, stream.sent)
+	}
+}
+
+// fanOutProductList is the former GetProductList conversion: one goroutine per
+// product, all serialized on a single mutex. Kept as the benchmark baseline.
+func fanOutProductList(products []*DbProduct) map[uint64]*pb.Product {
+	protoProducts := make(map[uint64]*pb.Product, len(products))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, product := range products {
+		wg.Add(1)
+		go func(product *DbProduct) {
+			defer wg.Done()
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			protoProducts[product.ID] = productToProto(product)
+		}(product)
+	}
+	wg.Wait()
+	return protoProducts
+}
+
+func benchmarkProducts(n int) []*DbProduct {
+	products := make([]*DbProduct, n)
+	for i := range products {
+		products[i] = &DbProduct{
+			ID:          uint64(i + 1),
+			Name:        "Test Product",
+			Sku:         fmt.Sprintf("test-sku-%d", i+1),
+			Description: "Test Description",
+			Price:       Money{Amount: 10000, Currency: "USD"},
+			Image:       "https://cdn.example.com/test-image.jpg",
+		}
+	}
+	return products
+}
+
+var benchmarkSizes = []int{1_000, 10_000, 100_000}
+
+func BenchmarkGetProductList_FanOut(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		products := benchmarkProducts(n)
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				fanOutProductList(products)
+			}
+		})
+	}
+}
+
+func BenchmarkGetProductList_Converter(b *testing.B) {
+	ctx := context.Background()
+	for _, n := range benchmarkSizes {
+		products := benchmarkProducts(n)
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := (*ProductConverter)(nil).ToProtoMap(ctx, products); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetProductList_ConverterWithEnrichment(b *testing.B) {
+	ctx := context.Background()
+	converter := &ProductConverter{Enrichers: []ProductEnricher{
+		func(ctx context.Context, product *pb.Product) error {
+			product.Description = strings.ToUpper(product.Description)
+			return nil
+		},
+	}}
+	for _, n := range benchmarkSizes {
+		products := benchmarkProducts(n)
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := converter.ToProtoMap(ctx, products); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_CreateVariant(t *testing.T) {
+	// given
+	testCases := []struct {
+		name         string
+		variant      *cpb.Variant
+		expectedId   *cpb.VariantId
+		expectedCode codes.Code
+		setup        func() *VariantServiceMock
+	}{
+		{
+			name: "Create a variant with a price in the catalog currency",
+			variant: &cpb.Variant{
+				ProductId: 1,
+				Sku:       "tee-m-red",
+				Price:     &cpb.Price{Amount: "21.99"},
+				Options:   map[string]string{"size": "M", "color": "red"},
+			},
+			expectedId:   &cpb.VariantId{Id: 7},
+			expectedCode: codes.OK,
+			setup: func() *VariantServiceMock {
+				mockVariantService := new(VariantServiceMock)
+				mockVariantService.On("CreateVariant", mock.Anything, &DbVariant{
+					ProductID: 1,
+					Sku:       "tee-m-red",
+					Price:     Money{Amount: 2199, Currency: "USD"},
+					Options:   VariantOptions{"size": "M", "color": "red"},
+				}).Return(uint64(7), nil)
+				return mockVariantService
+			},
+		},
+		{
+			name:         "Create an invalid variant",
+			variant:      &cpb.Variant{ProductId: 1, Sku: "-bad", Price: &cpb.Price{AmountMinor: -1}, Image: "not a url"},
+			expectedCode: codes.InvalidArgument,
+			setup: func() *VariantServiceMock {
+				return new(VariantServiceMock)
+			},
+		},
+		{
+			name:         "Create a variant with a used SKU",
+			variant:      &cpb.Variant{ProductId: 1, Sku: "tee"},
+			expectedCode: codes.AlreadyExists,
+			setup: func() *VariantServiceMock {
+				mockVariantService := new(VariantServiceMock)
+				mockVariantService.On("CreateVariant", mock.Anything, mock.Anything).Return(uint64(ErrorId), AlreadyExistsError(ResourceVariant, "tee"))
+				return mockVariantService
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		// when
+		mockVariantService := tc.setup()
+		server := &Server{VariantService: mockVariantService}
+
+		id, err := server.CreateVariant(context.Background(), tc.variant)
+
+		// then
+		assert.Equal(t, tc.expectedCode, status.Code(err), tc.name)
+		assert.Equal(t, tc.expectedId, id, tc.name)
+		mockVariantService.AssertExpectations(t)
+	}
+}
+
+func TestServer_SetProductOptions(t *testing.T) {
+	// given
+	mockVariantService := new(VariantServiceMock)
+	mockVariantService.On("SetProductOptions", mock.Anything, uint64(1), []DbProductOption{{Name: "size", Values: []string{"S", "M"}}}).Return(nil)
+	server := &Server{VariantService: mockVariantService}
+
+	// when
+	_, err := server.SetProductOptions(context.Background(), &cpb.ProductOptions{ProductId: 1, Options: []*cpb.ProductOption{{Name: "size", Values: []string{"S", "M"}}}})
+	_, invalidErr := server.SetProductOptions(context.Background(), &cpb.ProductOptions{ProductId: 1, Options: []*cpb.ProductOption{{Name: "size"}}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+	mockVariantService.AssertExpectations(t)
+}
+
+func TestServer_GetProductVariants(t *testing.T) {
+	// given
+	mockVariantService := new(VariantServiceMock)
+	mockVariantService.On("GetProductVariants", mock.Anything, uint64(1)).Return(&ProductVariants{
+		Product: &DbProduct{ID: 1, Name: "Tee", Sku: "tee", Price: Money{Amount: 1999, Currency: "USD"}},
+		Options: []DbProductOption{{Name: "size", Values: []string{"S", "M"}}},
+		Variants: []*DbVariant{
+			{ID: 2, ProductID: 1, Sku: "tee-s", Options: VariantOptions{"size": "S"}},
+			{ID: 3, ProductID: 1, Sku: "tee-m", Price: Money{Amount: 2199, Currency: "USD"}, Options: VariantOptions{"size": "M"}},
+		},
+	}, nil)
+	server := &Server{VariantService: mockVariantService}
+
+	// when
+	res, err := server.GetProductVariants(context.Background(), &pb.ProductId{Id: 1})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &cpb.ProductVariants{
+		Product: &pb.Product{Id: 1, Name: "Tee", Sku: "tee", Price: 19.99},
+		Options: []*cpb.ProductOption{{Name: "size", Values: []string{"S", "M"}}},
+		Variants: []*cpb.Variant{
+			{Id: 2, ProductId: 1, Sku: "tee-s", Options: map[string]string{"size": "S"}},
+			{Id: 3, ProductId: 1, Sku: "tee-m", Price: &cpb.Price{Currency: "USD", AmountMinor: 2199, Amount: "21.99"}, Options: map[string]string{"size": "M"}},
+		},
+	}, res)
+}
+
+func TestServer_GetVariant(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(&DbProduct{ID: 1, Status: ProductDraft, Visibility: VisibilityBoth}, nil)
+	mockVariantService := new(VariantServiceMock)
+	mockVariantService.On("GetVariantByID", mock.Anything, uint64(3)).Return(&DbVariant{ID: 3, ProductID: 1, Sku: "tee-m", Options: VariantOptions{"size": "M"}}, nil)
+	server := &Server{ProductService: mockProductService, VariantService: mockVariantService, AdminToken: "secret"}
+	admin := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+
+	// when
+	_, publicErr := server.GetVariant(context.Background(), &cpb.VariantId{Id: 3})
+	res, adminErr := server.GetVariant(admin, &cpb.VariantId{Id: 3})
+
+	// then
+	assert.Equal(t, codes.NotFound, status.Code(publicErr))
+	assert.Equal(t, "product 1 not found", status.Convert(publicErr).Message())
+	assert.NoError(t, adminErr)
+	assert.Equal(t, &cpb.Variant{Id: 3, ProductId: 1, Sku: "tee-m", Options: map[string]string{"size": "M"}}, res)
+}
+
+func TestServer_CreateCategory(t *testing.T) {
+	// given
+	parentID := uint64(1)
+	mockCategoryService := new(CategoryServiceMock)
+	mockCategoryService.On("CreateCategory", mock.Anything, &DbCategory{ParentID: &parentID, Name: "T-Shirts", Slug: "t-shirts", Position: 2}).Return(uint64(4), nil)
+	server := &Server{CategoryService: mockCategoryService}
+
+	// when
+	id, err := server.CreateCategory(context.Background(), &cpb.Category{ParentId: 1, Name: "T-Shirts", Slug: "t-shirts", Position: 2})
+	_, invalidErr := server.CreateCategory(context.Background(), &cpb.Category{Name: "T-Shirts", Slug: "T Shirts"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &cpb.CategoryId{Id: 4}, id)
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+	mockCategoryService.AssertExpectations(t)
+}
+
+func TestServer_MoveCategory(t *testing.T) {
+	// given
+	mockCategoryService := new(CategoryServiceMock)
+	mockCategoryService.On("MoveCategory", mock.Anything, uint64(4), (*uint64)(nil), 1).Return(nil)
+	server := &Server{CategoryService: mockCategoryService}
+
+	// when
+	_, err := server.MoveCategory(context.Background(), &cpb.MoveCategoryRequest{Id: 4, Position: 1})
+
+	// then
+	assert.NoError(t, err)
+	mockCategoryService.AssertExpectations(t)
+}
+
+func TestServer_GetCategoryTree(t *testing.T) {
+	// given
+	rootID := uint64(1)
+	mockCategoryService := new(CategoryServiceMock)
+	mockCategoryService.On("GetCategoryTree", mock.Anything).Return([]*CategoryNode{{
+		DbCategory: &DbCategory{ID: 1, Name: "Apparel", Slug: "apparel", Path: "/1/"},
+		Children:   []*CategoryNode{{DbCategory: &DbCategory{ID: 4, ParentID: &rootID, Name: "T-Shirts", Slug: "t-shirts", Path: "/1/4/"}}},
+	}}, nil)
+	server := &Server{CategoryService: mockCategoryService}
+
+	// when
+	res, err := server.GetCategoryTree(context.Background(), &pb.Empty{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &cpb.CategoryTree{Categories: []*cpb.Category{{
+		Id: 1, Name: "Apparel", Slug: "apparel", Path: "/1/",
+		Children: []*cpb.Category{{Id: 4, ParentId: 1, Name: "T-Shirts", Slug: "t-shirts", Path: "/1/4/", Children: []*cpb.Category{}}},
+	}}}, res)
+}
+
+func TestServer_CreateAttribute(t *testing.T) {
+	// given
+	mockAttributeService := new(AttributeServiceMock)
+	mockAttributeService.On("CreateAttribute", mock.Anything, &DbAttribute{Code: "wattage", Name: "Wattage", Type: AttributeInt, Required: true, Rules: AttributeRules{Min: "1"}}).Return(uint64(3), nil)
+	server := &Server{AttributeService: mockAttributeService}
+
+	// when
+	id, err := server.CreateAttribute(context.Background(), &cpb.Attribute{
+		Code: "wattage", Name: "Wattage", Type: cpb.AttributeType_ATTRIBUTE_TYPE_INT, Required: true, Rules: &cpb.AttributeRules{Min: "1"},
+	})
+	_, invalidErr := server.CreateAttribute(context.Background(), &cpb.Attribute{Code: "wattage", Name: "Wattage"})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &cpb.AttributeId{Id: 3}, id)
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+	mockAttributeService.AssertExpectations(t)
+}
+
+func TestServer_GetProductAttributes(t *testing.T) {
+	// given
+	mockAttributeService := new(AttributeServiceMock)
+	mockAttributeService.On("GetProductAttributes", mock.Anything, uint64(1)).Return(&ProductAttributes{ProductID: 1, AttributeSetID: 2, Values: []AttributeValue{{Code: "wattage", Values: []string{"60"}}}}, nil)
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(&DbProduct{ID: 1}, nil)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(2)).Return(nil, NotFoundError(ResourceProduct, 2))
+	server := &Server{ProductService: mockProductService, AttributeService: mockAttributeService}
+
+	// when
+	res, err := server.GetProductAttributes(context.Background(), &pb.ProductId{Id: 1})
+	_, missingErr := server.GetProductAttributes(context.Background(), &pb.ProductId{Id: 2})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &cpb.ProductAttributes{ProductId: 1, AttributeSetId: 2, Values: []*cpb.AttributeValue{{Code: "wattage", Values: []string{"60"}}}}, res)
+	assert.Equal(t, codes.NotFound, status.Code(missingErr))
+}
+
+func TestServer_CreateSynonymSet(t *testing.T) {
+	// given
+	mockDictionaryService := new(DictionaryServiceMock)
+	mockDictionaryService.On("CreateSynonymSet", mock.Anything, &DbSynonymSet{Type: SynonymOneWay, Input: "hoodie", Terms: []string{"sweatshirt"}}).Return(uint64(2), nil)
+	server := &Server{DictionaryService: mockDictionaryService}
+
+	// when
+	id, err := server.CreateSynonymSet(context.Background(), &cpb.SynonymSet{
+		Type: cpb.SynonymType_SYNONYM_TYPE_ONE_WAY, Input: "hoodie", Terms: []string{"sweatshirt"},
+	})
+	_, invalidErr := server.CreateSynonymSet(context.Background(), &cpb.SynonymSet{Terms: []string{"tee", "t-shirt"}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &cpb.SynonymSetId{Id: 2}, id)
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+	mockDictionaryService.AssertExpectations(t)
+}
+
+func TestServer_ListSynonymSets(t *testing.T) {
+	// given
+	mockDictionaryService := new(DictionaryServiceMock)
+	mockDictionaryService.On("ListSynonymSets", mock.Anything).Return([]*DbSynonymSet{{ID: 1, Type: SynonymEquivalent, Terms: []string{"tee", "t-shirt"}}}, nil)
+	server := &Server{DictionaryService: mockDictionaryService}
+
+	// when
+	res, err := server.ListSynonymSets(context.Background(), &pb.Empty{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, &cpb.SynonymSetList{SynonymSets: []*cpb.SynonymSet{{Id: 1, Type: cpb.SynonymType_SYNONYM_TYPE_EQUIVALENT, Terms: []string{"tee", "t-shirt"}}}}, res)
+}
+
+func TestServer_SetStopWords(t *testing.T) {
+	// given
+	mockDictionaryService := new(DictionaryServiceMock)
+	mockDictionaryService.On("SetStopWords", mock.Anything, []string{"the", "a"}).Return(nil)
+	server := &Server{DictionaryService: mockDictionaryService}
+
+	// when
+	_, err := server.SetStopWords(context.Background(), &cpb.StopWords{Words: []string{"the", "a"}})
+	_, invalidErr := server.SetStopWords(context.Background(), &cpb.StopWords{Words: []string{"of the"}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+	mockDictionaryService.AssertExpectations(t)
+}
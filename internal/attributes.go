@@ -0,0 +1,564 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AttributeType is the type of the values of an attribute.
+type AttributeType string
+
+const (
+	AttributeString    AttributeType = "string"
+	AttributeInt       AttributeType = "int"
+	AttributeDecimal   AttributeType = "decimal"
+	AttributeBool      AttributeType = "bool"
+	AttributeEnum      AttributeType = "enum"
+	AttributeMultiEnum AttributeType = "multi_enum"
+)
+
+// maxAttributeValueLength matches the size of the value column.
+const maxAttributeValueLength = 255
+
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// AttributeRules are the per-attribute constraints on values. MaxLength and
+// Pattern apply to strings, Min and Max are decimal bounds of int and decimal
+// attributes. Zero values disable a rule.
+type AttributeRules struct {
+	MaxLength int    `json:"max_length,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	Min       string `json:"min,omitempty"`
+	Max       string `json:"max,omitempty"`
+}
+
+// DbAttribute defines a custom product field such as "material" or "wattage".
+type DbAttribute struct {
+	ID        uint64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Code identifies the attribute in the API, e.g. "material".
+	Code     string        `gorm:"size:64;uniqueIndex"`
+	Name     string        `gorm:"size:255"`
+	Type     AttributeType `gorm:"size:16"`
+	Required bool
+	// Options are the allowed values of enum and multi_enum attributes.
+	Options []string       `gorm:"serializer:json"`
+	Rules   AttributeRules `gorm:"serializer:json"`
+}
+
+func (DbAttribute) TableName() string {
+	return "catalog_attributes"
+}
+
+// DbAttributeSet groups the attributes products of one product line carry.
+type DbAttributeSet struct {
+	ID        uint64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string `gorm:"size:255;uniqueIndex"`
+	// AttributeIDs is filled from catalog_attribute_set_attributes.
+	AttributeIDs []uint64 `gorm:"-"`
+}
+
+func (DbAttributeSet) TableName() string {
+	return "catalog_attribute_sets"
+}
+
+// DbAttributeSetAttribute adds an attribute to an attribute set.
+type DbAttributeSetAttribute struct {
+	AttributeSetID uint64 `gorm:"primaryKey;autoIncrement:false"`
+	AttributeID    uint64 `gorm:"primaryKey;autoIncrement:false;index"`
+}
+
+func (DbAttributeSetAttribute) TableName() string {
+	return "catalog_attribute_set_attributes"
+}
+
+// DbProductAttributeSet assigns the attribute set of a product. It is kept
+// apart from DbProduct so saving a product does not reset it.
+type DbProductAttributeSet struct {
+	ProductID      uint64 `gorm:"primaryKey;autoIncrement:false"`
+	AttributeSetID uint64 `gorm:"index"`
+}
+
+func (DbProductAttributeSet) TableName() string {
+	return "catalog_product_attribute_sets"
+}
+
+// DbProductAttributeValue is one value of a product attribute in its
+// canonical text form. Multi-enum attributes have a row per selected option.
+type DbProductAttributeValue struct {
+	ID          uint64
+	ProductID   uint64 `gorm:"uniqueIndex:idx_product_attribute_value"`
+	AttributeID uint64 `gorm:"uniqueIndex:idx_product_attribute_value;index:idx_attribute_value"`
+	Value       string `gorm:"size:255;uniqueIndex:idx_product_attribute_value;index:idx_attribute_value"`
+}
+
+func (DbProductAttributeValue) TableName() string {
+	return "catalog_product_attribute_values"
+}
+
+// AttributeValue holds the values of one attribute of a product.
+type AttributeValue struct {
+	Code   string
+	Values []string
+}
+
+// ProductAttributes is the attribute set of a product with its values.
+// AttributeSetID is zero for a product without attributes.
+type ProductAttributes struct {
+	ProductID      uint64
+	AttributeSetID uint64
+	Values         []AttributeValue
+}
+
+type AttributeServiceInterface interface {
+	CreateAttribute(ctx context.Context, attribute *DbAttribute) (uint64, error)
+	GetAttributeByID(ctx context.Context, id uint64) (*DbAttribute, error)
+	UpdateAttribute(ctx context.Context, attribute *DbAttribute) error
+	ListAttributes(ctx context.Context) ([]*DbAttribute, error)
+	CreateAttributeSet(ctx context.Context, set *DbAttributeSet) (uint64, error)
+	GetAttributeSetByID(ctx context.Context, id uint64) (*DbAttributeSet, error)
+	UpdateAttributeSet(ctx context.Context, set *DbAttributeSet) error
+	SetProductAttributes(ctx context.Context, attributes *ProductAttributes) error
+	GetProductAttributes(ctx context.Context, productID uint64) (*ProductAttributes, error)
+}
+
+type AttributeService struct {
+	DB DbWrapper
+	// QueryTimeout bounds every single query, zero disables the limit.
+	QueryTimeout time.Duration
+}
+
+// Create a new DbAttribute
+func (a *AttributeService) CreateAttribute(ctx context.Context, attribute *DbAttribute) (uint64, error) {
+	db, ctx, cancel := bindDB(ctx, a.DB, a.QueryTimeout)
+	defer cancel()
+	result := db.Create(attribute)
+	if result.Error != nil {
+		return ErrorId, attributeCodeError(classifyDbError(ctx, fmt.Errorf("failed to create an attribute: %w", result.Error), ResourceAttribute, nil), attribute.Code)
+	}
+	return attribute.ID, nil
+}
+
+// Read a DbAttribute by ID
+func (a *AttributeService) GetAttributeByID(ctx context.Context, id uint64) (*DbAttribute, error) {
+	db, ctx, cancel := bindDB(ctx, a.DB, a.QueryTimeout)
+	defer cancel()
+	attribute := DbAttribute{}
+	result := db.First(&attribute, id)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get an attribute %d: %w", id, result.Error), ResourceAttribute, id)
+	}
+	return &attribute, nil
+}
+
+// Update a DbAttribute. The type cannot change and enum options still used by
+// products cannot be removed, so stored values stay valid.
+func (a *AttributeService) UpdateAttribute(ctx context.Context, attribute *DbAttribute) error {
+	db, ctx, cancel := bindDB(ctx, a.DB, a.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		existing := DbAttribute{}
+		if err := tx.Scopes(forUpdate).First(&existing, attribute.ID).Error; err != nil {
+			return err
+		}
+		if attribute.Type != existing.Type {
+			return InvalidArgumentError("invalid attribute", FieldViolation{Field: "type", Description: fmt.Sprintf("cannot change from %s", existing.Type)})
+		}
+		var removed []string
+		for _, option := range existing.Options {
+			if !containsString(attribute.Options, option) {
+				removed = append(removed, option)
+			}
+		}
+		if len(removed) > 0 {
+			var used []string
+			err := tx.Where("attribute_id = ? AND value IN ?", attribute.ID, removed).
+				Model(&DbProductAttributeValue{}).Distinct("value").Order("value").Pluck("value", &used).Error
+			if err != nil {
+				return err
+			}
+			if len(used) > 0 {
+				return InvalidArgumentError("invalid attribute", FieldViolation{Field: "options", Description: fmt.Sprintf("options %v are still used by products", used)})
+			}
+		}
+		attribute.CreatedAt = existing.CreatedAt
+		return tx.Save(attribute).Error
+	})
+	if err != nil {
+		return attributeCodeError(classifyDbError(ctx, fmt.Errorf("failed to update an attribute %d: %w", attribute.ID, err), ResourceAttribute, attribute.ID), attribute.Code)
+	}
+	return nil
+}
+
+// Get all DbAttributes ordered by code
+func (a *AttributeService) ListAttributes(ctx context.Context) ([]*DbAttribute, error) {
+	db, ctx, cancel := bindDB(ctx, a.DB, a.QueryTimeout)
+	defer cancel()
+	var attributes []*DbAttribute
+	result := db.Scopes(func(db *gorm.DB) *gorm.DB { return db.Order("code") }).Find(&attributes)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get attributes: %w", result.Error), ResourceAttribute, nil)
+	}
+	return attributes, nil
+}
+
+// Create a new DbAttributeSet with its attributes
+func (a *AttributeService) CreateAttributeSet(ctx context.Context, set *DbAttributeSet) (uint64, error) {
+	db, ctx, cancel := bindDB(ctx, a.DB, a.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		if err := tx.Create(set).Error; err != nil {
+			return err
+		}
+		return replaceSetAttributes(tx, set)
+	})
+	if err != nil {
+		return ErrorId, attributeSetNameError(classifyDbError(ctx, fmt.Errorf("failed to create an attribute set: %w", err), ResourceAttributeSet, nil), set.Name)
+	}
+	return set.ID, nil
+}
+
+// Read a DbAttributeSet by ID with its attribute ids
+func (a *AttributeService) GetAttributeSetByID(ctx context.Context, id uint64) (*DbAttributeSet, error) {
+	db, ctx, cancel := bindDB(ctx, a.DB, a.QueryTimeout)
+	defer cancel()
+	set := DbAttributeSet{}
+	if result := db.First(&set, id); result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get an attribute set %d: %w", id, result.Error), ResourceAttributeSet, id)
+	}
+	var rows []DbAttributeSetAttribute
+	if result := db.Where("attribute_set_id = ?", id).Order("attribute_id").Find(&rows); result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get attributes of an attribute set %d: %w", id, result.Error), ResourceAttributeSet, id)
+	}
+	for _, row := range rows {
+		set.AttributeIDs = append(set.AttributeIDs, row.AttributeID)
+	}
+	return &set, nil
+}
+
+// Update the name and attributes of a DbAttributeSet. Values of attributes
+// removed from the set are kept until the product attributes are set again.
+func (a *AttributeService) UpdateAttributeSet(ctx context.Context, set *DbAttributeSet) error {
+	db, ctx, cancel := bindDB(ctx, a.DB, a.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		existing := DbAttributeSet{}
+		if err := tx.First(&existing, set.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Scopes(func(db *gorm.DB) *gorm.DB { return db.Model(&existing) }).Update("name", set.Name).Error; err != nil {
+			return err
+		}
+		return replaceSetAttributes(tx, set)
+	})
+	if err != nil {
+		return attributeSetNameError(classifyDbError(ctx, fmt.Errorf("failed to update an attribute set %d: %w", set.ID, err), ResourceAttributeSet, set.ID), set.Name)
+	}
+	return nil
+}
+
+// Replace the attribute set and all attribute values of a product. Values are
+// validated against the attributes of the set and stored in canonical form.
+// A zero AttributeSetID removes all attributes of the product.
+func (a *AttributeService) SetProductAttributes(ctx context.Context, attributes *ProductAttributes) error {
+	db, ctx, cancel := bindDB(ctx, a.DB, a.QueryTimeout)
+	defer cancel()
+	productID := attributes.ProductID
+	err := db.Transaction(func(tx DbWrapper) error {
+		if err := tx.First(&DbProduct{}, productID).Error; err != nil {
+			return err
+		}
+		var rows []DbProductAttributeValue
+		if attributes.AttributeSetID != 0 {
+			if err := tx.First(&DbAttributeSet{}, attributes.AttributeSetID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+				return NotFoundError(ResourceAttributeSet, attributes.AttributeSetID)
+			} else if err != nil {
+				return err
+			}
+			var defs []*DbAttribute
+			err := tx.Where("sa.attribute_set_id = ?", attributes.AttributeSetID).
+				Joins("JOIN catalog_attribute_set_attributes sa ON sa.attribute_id = catalog_attributes.id").Find(&defs).Error
+			if err != nil {
+				return err
+			}
+			var violations []FieldViolation
+			if rows, violations = attributeValueRows(productID, defs, attributes.Values); len(violations) > 0 {
+				return InvalidArgumentError("invalid attribute values", violations...)
+			}
+		} else if len(attributes.Values) > 0 {
+			return InvalidArgumentError("invalid attribute values", FieldViolation{Field: "attribute_set_id", Description: "is required to set values"})
+		}
+		if err := tx.Where("product_id = ?", productID).Delete(&DbProductAttributeValue{}).Error; err != nil {
+			return err
+		}
+		if attributes.AttributeSetID == 0 {
+			return tx.Where("product_id = ?", productID).Delete(&DbProductAttributeSet{}).Error
+		}
+		assignment := DbProductAttributeSet{ProductID: productID, AttributeSetID: attributes.AttributeSetID}
+		upsert := func(db *gorm.DB) *gorm.DB { return db.Clauses(clause.OnConflict{UpdateAll: true}) }
+		if err := tx.Scopes(upsert).Create(&assignment).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to set attributes of a product %d: %w", productID, err), ResourceProduct, productID)
+	}
+	return nil
+}
+
+// Read the attribute set and values of a product, grouped by attribute code
+func (a *AttributeService) GetProductAttributes(ctx context.Context, productID uint64) (*ProductAttributes, error) {
+	db, ctx, cancel := bindDB(ctx, a.DB, a.QueryTimeout)
+	defer cancel()
+	if result := db.First(&DbProduct{}, productID); result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get a product %d: %w", productID, result.Error), ResourceProduct, productID)
+	}
+	out := &ProductAttributes{ProductID: productID}
+	var assignments []DbProductAttributeSet
+	if result := db.Where("product_id = ?", productID).Find(&assignments); result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get the attribute set of a product %d: %w", productID, result.Error), ResourceProduct, productID)
+	}
+	if len(assignments) == 0 {
+		return out, nil
+	}
+	out.AttributeSetID = assignments[0].AttributeSetID
+	var rows []struct {
+		Code  string
+		Value string
+	}
+	result := db.Scopes(func(db *gorm.DB) *gorm.DB {
+		return db.Model(&DbProductAttributeValue{}).
+			Select("a.code, catalog_product_attribute_values.value").
+			Joins("JOIN catalog_attributes a ON a.id = catalog_product_attribute_values.attribute_id").
+			Where("catalog_product_attribute_values.product_id = ?", productID).
+			Order("a.code, catalog_product_attribute_values.id")
+	}).Find(&rows)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get attribute values of a product %d: %w", productID, result.Error), ResourceProduct, productID)
+	}
+	for _, row := range rows {
+		if n := len(out.Values); n > 0 && out.Values[n-1].Code == row.Code {
+			out.Values[n-1].Values = append(out.Values[n-1].Values, row.Value)
+			continue
+		}
+		out.Values = append(out.Values, AttributeValue{Code: row.Code, Values: []string{row.Value}})
+	}
+	return out, nil
+}
+
+// replaceSetAttributes stores set.AttributeIDs as the attributes of the set.
+func replaceSetAttributes(tx DbWrapper, set *DbAttributeSet) error {
+	ids := uniqueIDs(set.AttributeIDs)
+	if len(ids) > 0 {
+		var found []uint64
+		if err := tx.Where("id IN ?", ids).Model(&DbAttribute{}).Pluck("id", &found).Error; err != nil {
+			return err
+		}
+		if missing := missingID(ids, found); missing != 0 {
+			return NotFoundError(ResourceAttribute, missing)
+		}
+	}
+	if err := tx.Where("attribute_set_id = ?", set.ID).Delete(&DbAttributeSetAttribute{}).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	rows := make([]DbAttributeSetAttribute, len(ids))
+	for i, id := range ids {
+		rows[i] = DbAttributeSetAttribute{AttributeSetID: set.ID, AttributeID: id}
+	}
+	return tx.Create(&rows).Error
+}
+
+// attributeValueRows validates values against the attribute definitions and
+// converts them to rows. Every required attribute must have a value.
+func attributeValueRows(productID uint64, defs []*DbAttribute, values []AttributeValue) ([]DbProductAttributeValue, []FieldViolation) {
+	byCode := make(map[string]*DbAttribute, len(defs))
+	for _, def := range defs {
+		byCode[def.Code] = def
+	}
+	var rows []DbProductAttributeValue
+	var violations []FieldViolation
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		field := "values." + value.Code
+		def, ok := byCode[value.Code]
+		switch {
+		case !ok:
+			violations = append(violations, FieldViolation{Field: field, Description: "is not an attribute of the attribute set"})
+			continue
+		case seen[value.Code]:
+			violations = append(violations, FieldViolation{Field: field, Description: "is set more than once"})
+			continue
+		}
+		seen[value.Code] = true
+		normalized, reason := def.normalizeValues(value.Values)
+		if reason != "" {
+			violations = append(violations, FieldViolation{Field: field, Description: reason})
+			continue
+		}
+		for _, v := range normalized {
+			rows = append(rows, DbProductAttributeValue{ProductID: productID, AttributeID: def.ID, Value: v})
+		}
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Code < defs[j].Code })
+	for _, def := range defs {
+		if def.Required && !seen[def.Code] {
+			violations = append(violations, FieldViolation{Field: "values." + def.Code, Description: "is required"})
+		}
+	}
+	return rows, violations
+}
+
+// normalizeValues checks values against the type and rules of the attribute
+// and returns their canonical form, or the reason they are invalid.
+func (a *DbAttribute) normalizeValues(values []string) ([]string, string) {
+	if len(values) == 0 {
+		return nil, "must have a value"
+	}
+	if a.Type == AttributeMultiEnum {
+		// Kept in the order of the options so equal selections compare equal.
+		var out []string
+		for _, option := range a.Options {
+			if containsString(values, option) {
+				out = append(out, option)
+			}
+		}
+		for _, value := range values {
+			if !containsString(a.Options, value) {
+				return nil, fmt.Sprintf("%q is not one of %v", value, a.Options)
+			}
+		}
+		return out, ""
+	}
+	if len(values) > 1 {
+		return nil, "must have a single value"
+	}
+	value, reason := a.normalizeValue(values[0])
+	if reason != "" {
+		return nil, reason
+	}
+	return []string{value}, ""
+}
+
+func (a *DbAttribute) normalizeValue(value string) (string, string) {
+	switch a.Type {
+	case AttributeString:
+		maxLength := a.Rules.MaxLength
+		if maxLength == 0 {
+			maxLength = maxAttributeValueLength
+		}
+		if utf8.RuneCountInString(value) > maxLength {
+			return "", fmt.Sprintf("must be at most %d characters", maxLength)
+		}
+		if a.Rules.Pattern != "" {
+			pattern, err := regexp.Compile(a.Rules.Pattern)
+			if err != nil || !pattern.MatchString(value) {
+				return "", fmt.Sprintf("must match %s", a.Rules.Pattern)
+			}
+		}
+		return value, ""
+	case AttributeInt:
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return "", "must be an integer"
+		}
+		value = strconv.FormatInt(n, 10)
+		return value, a.boundsViolation(new(big.Rat).SetInt64(n))
+	case AttributeDecimal:
+		r, canonical, ok := parseDecimal(value)
+		if !ok {
+			return "", "must be a decimal number such as 12.5"
+		}
+		return canonical, a.boundsViolation(r)
+	case AttributeBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", "must be true or false"
+		}
+		return strconv.FormatBool(b), ""
+	case AttributeEnum:
+		if !containsString(a.Options, value) {
+			return "", fmt.Sprintf("%q is not one of %v", value, a.Options)
+		}
+		return value, ""
+	}
+	return "", fmt.Sprintf("unsupported attribute type %q", a.Type)
+}
+
+func (a *DbAttribute) boundsViolation(r *big.Rat) string {
+	if min, _, ok := parseDecimal(a.Rules.Min); ok && r.Cmp(min) < 0 {
+		return fmt.Sprintf("must be at least %s", a.Rules.Min)
+	}
+	if max, _, ok := parseDecimal(a.Rules.Max); ok && r.Cmp(max) > 0 {
+		return fmt.Sprintf("must be at most %s", a.Rules.Max)
+	}
+	return ""
+}
+
+// parseDecimal parses a plain decimal such as "-12.50" and returns it with its
+// canonical form without trailing zeros, "-12.5".
+func parseDecimal(s string) (*big.Rat, string, bool) {
+	s = strings.TrimSpace(s)
+	if !decimalPattern.MatchString(s) {
+		return nil, "", false
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, "", false
+	}
+	canonical := s
+	if strings.Contains(canonical, ".") {
+		canonical = strings.TrimRight(strings.TrimRight(canonical, "0"), ".")
+	}
+	canonical = strings.TrimLeft(canonical, "-")
+	canonical = strings.TrimLeft(canonical, "0")
+	if canonical == "" || canonical[0] == '.' {
+		canonical = "0" + canonical
+	}
+	if r.Sign() < 0 {
+		canonical = "-" + canonical
+	}
+	return r, canonical, true
+}
+
+// attributeCodeError makes a duplicate key error point at the attribute code.
+func attributeCodeError(err error, code string) error {
+	var e *Error
+	if !errors.As(err, &e) || e.Kind != KindAlreadyExists {
+		return err
+	}
+	e.ID = code
+	e.Message = fmt.Sprintf("attribute with code %q already exists", code)
+	return e
+}
+
+// attributeSetNameError makes a duplicate key error point at the set name.
+func attributeSetNameError(err error, name string) error {
+	var e *Error
+	if !errors.As(err, &e) || e.Kind != KindAlreadyExists {
+		return err
+	}
+	e.ID = name
+	e.Message = fmt.Sprintf("attribute set %q already exists", name)
+	return e
+}
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDbAttribute_normalizeValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		attribute  DbAttribute
+		values     []string
+		want       []string
+		wantReason bool
+	}{
+		{name: "String", attribute: DbAttribute{Type: AttributeString}, values: []string{"cotton"}, want: []string{"cotton"}},
+		{name: "String too long", attribute: DbAttribute{Type: AttributeString, Rules: AttributeRules{MaxLength: 3}}, values: []string{"cotton"}, wantReason: true},
+		{name: "String not matching the pattern", attribute: DbAttribute{Type: AttributeString, Rules: AttributeRules{Pattern: `^97[89][0-9]{10}$`}}, values: []string{"12345"}, wantReason: true},
+		{name: "Int", attribute: DbAttribute{Type: AttributeInt, Rules: AttributeRules{Min: "1", Max: "3000"}}, values: []string{" 0060"}, want: []string{"60"}},
+		{name: "Int out of bounds", attribute: DbAttribute{Type: AttributeInt, Rules: AttributeRules{Max: "3000"}}, values: []string{"3001"}, wantReason: true},
+		{name: "Decimal", attribute: DbAttribute{Type: AttributeDecimal, Rules: AttributeRules{Min: "0.5"}}, values: []string{"012.50"}, want: []string{"12.5"}},
+		{name: "Negative decimal", attribute: DbAttribute{Type: AttributeDecimal}, values: []string{"-0.250"}, want: []string{"-0.25"}},
+		{name: "Decimal below min", attribute: DbAttribute{Type: AttributeDecimal, Rules: AttributeRules{Min: "0.5"}}, values: []string{"0.49"}, wantReason: true},
+		{name: "Malformed decimal", attribute: DbAttribute{Type: AttributeDecimal}, values: []string{"1e3"}, wantReason: true},
+		{name: "Bool", attribute: DbAttribute{Type: AttributeBool}, values: []string{"TRUE"}, want: []string{"true"}},
+		{name: "Enum", attribute: DbAttribute{Type: AttributeEnum, Options: []string{"red", "blue"}}, values: []string{"blue"}, want: []string{"blue"}},
+		{name: "Unknown enum option", attribute: DbAttribute{Type: AttributeEnum, Options: []string{"red"}}, values: []string{"green"}, wantReason: true},
+		{name: "Several values of a single valued attribute", attribute: DbAttribute{Type: AttributeEnum, Options: []string{"red", "blue"}}, values: []string{"red", "blue"}, wantReason: true},
+		{name: "Multi enum in option order", attribute: DbAttribute{Type: AttributeMultiEnum, Options: []string{"s", "m", "l"}}, values: []string{"l", "s", "l"}, want: []string{"s", "l"}},
+		{name: "No value", attribute: DbAttribute{Type: AttributeString}, values: nil, wantReason: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			values, reason := tt.attribute.normalizeValues(tt.values)
+			//then
+			if tt.wantReason {
+				assert.NotEmpty(t, reason)
+				return
+			}
+			assert.Empty(t, reason)
+			assert.Equal(t, tt.want, values)
+		})
+	}
+}
+
+func TestAttributeService_SetProductAttributes(t *testing.T) {
+	expectSet := func(sqlMock sqlmock.Sqlmock) {
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ?")).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_attribute_sets` WHERE `catalog_attribute_sets`.`id` = ?")).
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "Lamps"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT `catalog_attributes`.`id`,`catalog_attributes`.`created_at`,`catalog_attributes`.`updated_at`,`catalog_attributes`.`code`,`catalog_attributes`.`name`,`catalog_attributes`.`type`,`catalog_attributes`.`required`,`catalog_attributes`.`options`,`catalog_attributes`.`rules` FROM `catalog_attributes` JOIN catalog_attribute_set_attributes sa ON sa.attribute_id = catalog_attributes.id WHERE sa.attribute_set_id = ?")).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "type", "required", "options", "rules"}).
+				AddRow(3, "wattage", "int", true, "null", `{"min":"1"}`).
+				AddRow(4, "color", "multi_enum", false, `["white","black"]`, "{}"))
+	}
+
+	t.Run("Values are replaced in canonical form", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		as := &AttributeService{DB: GormWrapper{DB: db}}
+		expectSet(sqlMock)
+		sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_product_attribute_values` WHERE product_id = ?")).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_product_attribute_sets` (`product_id`,`attribute_set_id`) VALUES (?,?) ON DUPLICATE KEY UPDATE `attribute_set_id`=VALUES(`attribute_set_id`)")).
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_product_attribute_values` (`product_id`,`attribute_id`,`value`) VALUES (?,?,?),(?,?,?),(?,?,?)")).
+			WithArgs(1, 3, "60", 1, 4, "white", 1, 4, "black").
+			WillReturnResult(sqlmock.NewResult(1, 3))
+		sqlMock.ExpectCommit()
+		//when
+		err := as.SetProductAttributes(context.Background(), &ProductAttributes{ProductID: 1, AttributeSetID: 2, Values: []AttributeValue{
+			{Code: "wattage", Values: []string{"060"}},
+			{Code: "color", Values: []string{"black", "white"}},
+		}})
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid values are rejected", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		as := &AttributeService{DB: GormWrapper{DB: db}}
+		expectSet(sqlMock)
+		sqlMock.ExpectRollback()
+		//when
+		err := as.SetProductAttributes(context.Background(), &ProductAttributes{ProductID: 1, AttributeSetID: 2, Values: []AttributeValue{
+			{Code: "color", Values: []string{"red"}},
+			{Code: "isbn", Values: []string{"978"}},
+		}})
+		//then
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, KindInvalidArgument, e.Kind)
+		var fields []string
+		for _, violation := range e.Violations {
+			fields = append(fields, violation.Field)
+		}
+		assert.Equal(t, []string{"values.color", "values.isbn", "values.wattage"}, fields)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestAttributeService_UpdateAttribute(t *testing.T) {
+	t.Run("Options used by products cannot be removed", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		as := &AttributeService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_attributes` WHERE `catalog_attributes`.`id` = ? ORDER BY `catalog_attributes`.`id` LIMIT ? FOR UPDATE")).
+			WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "type", "options"}).AddRow(4, "color", "enum", `["white","black","red"]`))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `value` FROM `catalog_product_attribute_values` WHERE attribute_id = ? AND value IN (?,?) ORDER BY value")).
+			WithArgs(4, "black", "red").
+			WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow("red"))
+		sqlMock.ExpectRollback()
+		//when
+		err := as.UpdateAttribute(context.Background(), &DbAttribute{ID: 4, Code: "color", Name: "Color", Type: AttributeEnum, Options: []string{"white"}})
+		//then
+		assert.Equal(t, KindInvalidArgument, KindOf(err))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Type cannot change", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		as := &AttributeService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_attributes`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code", "type"}).AddRow(4, "wattage", "int"))
+		sqlMock.ExpectRollback()
+		//when
+		err := as.UpdateAttribute(context.Background(), &DbAttribute{ID: 4, Code: "wattage", Name: "Wattage", Type: AttributeDecimal})
+		//then
+		assert.Equal(t, KindInvalidArgument, KindOf(err))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestAttributeService_GetProductAttributes(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	as := &AttributeService{DB: GormWrapper{DB: db}}
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_product_attribute_sets` WHERE product_id = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "attribute_set_id"}).AddRow(1, 2))
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT a.code, catalog_product_attribute_values.value FROM `catalog_product_attribute_values` JOIN catalog_attributes a ON a.id = catalog_product_attribute_values.attribute_id WHERE catalog_product_attribute_values.product_id = ? ORDER BY a.code, catalog_product_attribute_values.id")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"code", "value"}).AddRow("color", "white").AddRow("color", "black").AddRow("wattage", "60"))
+	//when
+	attributes, err := as.GetProductAttributes(context.Background(), 1)
+	//then
+	require.NoError(t, err)
+	assert.Equal(t, &ProductAttributes{ProductID: 1, AttributeSetID: 2, Values: []AttributeValue{
+		{Code: "color", Values: []string{"white", "black"}},
+		{Code: "wattage", Values: []string{"60"}},
+	}}, attributes)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// availableAt reports whether at lies within the availability window. The
+// window includes AvailableFrom and ends right before AvailableUntil.
+func (p *DbProduct) availableAt(at time.Time) bool {
+	if p.AvailableFrom != nil && at.Before(*p.AvailableFrom) {
+		return false
+	}
+	return p.AvailableUntil == nil || at.Before(*p.AvailableUntil)
+}
+
+// Change the availability window of a DbProduct, nil bounds leave it open.
+// The product must still have revision.
+func (p *ProductService) SetProductAvailability(ctx context.Context, id, revision uint64, from, until *time.Time) error {
+	if from != nil && until != nil && !until.After(*from) {
+		return InvalidArgumentError("invalid product availability", FieldViolation{Field: "available_until", Description: "must be after available_from"})
+	}
+
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	product := DbProduct{}
+	var wasPublic bool
+	err := db.Transaction(func(tx DbWrapper) error {
+		if err := tx.Scopes(forUpdate).First(&product, id).Error; err != nil {
+			return err
+		}
+		wasPublic = product.Public(time.Now())
+		stored := product.Revision
+		product.AvailableFrom, product.AvailableUntil, product.UpdatedAt = from, until, time.Now()
+		product.Revision++
+		// Hooks are skipped as the SKU does not change, the map writes nil bounds.
+		updates := map[string]interface{}{"available_from": from, "available_until": until, "revision": gorm.Expr("revision + 1"), "updated_at": product.UpdatedAt}
+		result := tx.Scopes(withoutHooks).Model(&product).Where("revision = ?", revision).Updates(updates)
+		if result.Error == nil && result.RowsAffected == 0 {
+			return revisionConflict(id, stored, revision)
+		}
+		return result.Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to set the availability of a product %d: %w", id, err), ResourceProduct, id)
+	}
+	p.indexProduct(&product)
+	p.publishChange(&product, wasPublic, product.UpdatedAt)
+	return nil
+}
+
+// publishChange reports the product to Changes if it became public or stopped
+// being public at the given time.
+func (p *ProductService) publishChange(product *DbProduct, wasPublic bool, at time.Time) {
+	isPublic := product.Public(at)
+	if isPublic == wasPublic {
+		return
+	}
+	change := ProductChange{ProductID: product.ID, Type: ProductUnavailable, At: at}
+	if isPublic {
+		change.Type = ProductAvailable
+	}
+	p.afterCommit(func() { p.Changes.Publish(change) })
+}
+
+// PublishDueChanges handles the products whose availability window opened or
+// closed after since and up to now: they are indexed again, and a change is
+// published for those that became public or stopped being public. It returns
+// the number of published changes.
+func (p *ProductService) PublishDueChanges(ctx context.Context, since, now time.Time) (int, error) {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	var products []*DbProduct
+	result := db.Scopes(func(db *gorm.DB) *gorm.DB {
+		return db.Where("available_from > ? AND available_from <= ?", since, now).
+			Or("available_until > ? AND available_until <= ?", since, now).
+			Order("id")
+	}).Find(&products)
+	if result.Error != nil {
+		return 0, classifyDbError(ctx, fmt.Errorf("failed to find products crossing their availability window: %w", result.Error), ResourceProduct, nil)
+	}
+	published := 0
+	for _, product := range products {
+		p.indexProduct(product)
+		if wasPublic := product.Public(since); wasPublic != product.Public(now) {
+			p.publishChange(product, wasPublic, now)
+			published++
+		}
+	}
+	return published, nil
+}
+
+// RunScheduler publishes the changes of products crossing their availability
+// window every ScheduleInterval until ctx is done. Boundaries passed while
+// the scheduler was not running are not reported, read paths check the
+// window on their own and are not affected.
+func (p *ProductService) RunScheduler(ctx context.Context) {
+	interval := p.ScheduleInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	since := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			published, err := p.PublishDueChanges(ctx, since, now)
+			if err != nil {
+				// The same window is looked at again on the next tick.
+				log.Printf("Failed to publish availability changes. Error: %v", err)
+				continue
+			}
+			if published > 0 {
+				log.Printf("Published %v availability changes.", published)
+			}
+			since = now
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDbProduct_AvailableAt(t *testing.T) {
+	launch := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	end := launch.Add(72 * time.Hour)
+	tests := []struct {
+		name    string
+		product DbProduct
+		at      time.Time
+		want    bool
+	}{
+		{name: "No window", product: DbProduct{}, at: launch, want: true},
+		{name: "Before the window opens", product: DbProduct{AvailableFrom: &launch}, at: launch.Add(-time.Second)},
+		{name: "When the window opens", product: DbProduct{AvailableFrom: &launch}, at: launch, want: true},
+		{name: "Within the window", product: DbProduct{AvailableFrom: &launch, AvailableUntil: &end}, at: launch.Add(time.Hour), want: true},
+		{name: "When the window closes", product: DbProduct{AvailableFrom: &launch, AvailableUntil: &end}, at: end},
+		{name: "Open start", product: DbProduct{AvailableUntil: &end}, at: launch, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.product.availableAt(tt.at))
+			assert.Equal(t, tt.want, tt.product.Public(tt.at))
+		})
+	}
+}
+
+func TestProductService_SetProductAvailability(t *testing.T) {
+	t.Run("Closed window unpublishes the product", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		feed := NewChangeFeed()
+		changes, unsubscribe := feed.Subscribe(1)
+		defer unsubscribe()
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex(), Changes: feed}
+		until := time.Now().Add(-time.Minute)
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ? AND `catalog_products`.`deleted_at` IS NULL ORDER BY `catalog_products`.`id` LIMIT ? FOR UPDATE")).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status", "visibility"}).AddRow(1, "Advent Calendar", "active", "both"))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `available_from`=?,`available_until`=?,`revision`=revision + 1,`updated_at`=?")).
+			WithArgs(nil, until, sqlmock.AnyArg(), 2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+		//when
+		err := ps.SetProductAvailability(context.Background(), 1, 2, nil, &until)
+		//then
+		require.NoError(t, err)
+		assert.Empty(t, ps.Index.SearchPublic("calendar"))
+		change := <-changes
+		assert.Equal(t, uint64(1), change.ProductID)
+		assert.Equal(t, ProductUnavailable, change.Type)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Changed product is not written", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		from := time.Now().Add(time.Hour)
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status", "visibility", "revision"}).AddRow(1, "active", "both", 3))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `available_from`=?,`available_until`=?,`revision`=revision + 1,`updated_at`=? WHERE revision = ?")).
+			WithArgs(from, nil, sqlmock.AnyArg(), 2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectRollback()
+		//when
+		err := ps.SetProductAvailability(context.Background(), 1, 2, &from, nil)
+		//then
+		assert.Equal(t, KindConflict, KindOf(err))
+		assert.EqualError(t, err, "failed to set the availability of a product 1: product 1 was changed, its revision is 3 not 2")
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Window ending before it starts is rejected without a query", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		from := time.Now()
+		//when
+		err := ps.SetProductAvailability(context.Background(), 1, 1, &from, &from)
+		//then
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, []FieldViolation{{Field: "available_until", Description: "must be after available_from"}}, e.Violations)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestProductService_PublishDueChanges(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	feed := NewChangeFeed()
+	changes, unsubscribe := feed.Subscribe(4)
+	defer unsubscribe()
+	ps := &ProductService{DB: GormWrapper{DB: db}, Suggestions: NewSuggestIndex(), Changes: feed}
+	since := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	now := since.Add(30 * time.Second)
+	opened, closed := since.Add(10*time.Second), since.Add(20*time.Second)
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE ((available_from > ? AND available_from <= ?) OR (available_until > ? AND available_until <= ?)) AND `catalog_products`.`deleted_at` IS NULL ORDER BY id")).
+		WithArgs(since, now, since, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status", "visibility", "available_from", "available_until"}).
+			AddRow(1, "Advent Calendar", "active", "both", opened, nil).
+			AddRow(2, "Pumpkin Spice", "active", "both", nil, closed).
+			AddRow(3, "Draft Launch", "draft", "both", opened, nil))
+	//when
+	published, err := ps.PublishDueChanges(context.Background(), since, now)
+	//then
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, ProductChange{ProductID: 1, Type: ProductAvailable, At: now}, <-changes)
+	assert.Equal(t, ProductChange{ProductID: 2, Type: ProductUnavailable, At: now}, <-changes)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
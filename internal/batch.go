@@ -0,0 +1,235 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// MaxBatchSize is the largest number of items of a batch call.
+const MaxBatchSize = 500
+
+// BatchMode tells how a batch write treats items that fail.
+type BatchMode int
+
+const (
+	// BatchAtomic writes every item or none, the first failing item fails
+	// the whole batch.
+	BatchAtomic BatchMode = iota
+	// BatchBestEffort writes the items that can be written and reports the
+	// others in their results.
+	BatchBestEffort
+)
+
+// BatchResult is the outcome of one item of a batch write. Err is nil when
+// the item was written.
+type BatchResult struct {
+	ID uint64
+	// Revision of the product after the write, zero for deletes.
+	Revision uint64
+	Err      error
+}
+
+// ProductRevision names a product and the revision a write is based on.
+type ProductRevision struct {
+	ID       uint64
+	Revision uint64
+}
+
+// batchAbort is returned by runBatch when an item failed an atomic batch.
+type batchAbort struct {
+	index int
+}
+
+func (e *batchAbort) Error() string {
+	return fmt.Sprintf("item %d of the batch failed", e.index)
+}
+
+// Read the DbProducts with the given IDs in one query. They are returned in
+// the order of ids without repetitions, together with the ids that do not
+// exist.
+func (p *ProductService) GetProductsByIDs(ctx context.Context, ids []uint64) ([]*DbProduct, []uint64, error) {
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	var found []*DbProduct
+	if err := db.Find(&found, ids).Error; err != nil {
+		return nil, nil, classifyDbError(ctx, fmt.Errorf("failed to get products %v: %w", ids, err), ResourceProduct, nil)
+	}
+	byID := make(map[uint64]*DbProduct, len(found))
+	for _, product := range found {
+		byID[product.ID] = product
+	}
+	products := make([]*DbProduct, 0, len(found))
+	var missing []uint64
+	seen := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if product, ok := byID[id]; ok {
+			products = append(products, product)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return products, missing, nil
+}
+
+// Create DbProducts in one transaction, active and visible everywhere unless
+// set otherwise.
+func (p *ProductService) BatchCreateProducts(ctx context.Context, products []*DbProduct, mode BatchMode) ([]BatchResult, error) {
+	for _, product := range products {
+		product.Status, product.Visibility = product.lifecycle()
+		product.Revision = 1
+	}
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	errs, err := runBatch(db, len(products), mode, func(tx DbWrapper, i int) error {
+		return tx.Create(products[i]).Error
+	})
+	results, err := batchResults(ctx, errs, err, func(i int, err error) error {
+		return skuError(classifyDbError(ctx, fmt.Errorf("failed to create a product: %w", err), ResourceProduct, nil), products[i].Sku)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, product := range products {
+		if results[i].Err == nil {
+			results[i].ID, results[i].Revision = product.ID, product.Revision
+			p.indexProduct(product)
+		}
+	}
+	return results, nil
+}
+
+// Update DbProducts in one transaction. Every product must still have its
+// Revision, which is incremented on success. Status, visibility and
+// availability are kept as stored.
+func (p *ProductService) BatchUpdateProducts(ctx context.Context, products []*DbProduct, mode BatchMode) ([]BatchResult, error) {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	ids := make([]uint64, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	// A change of the kept fields in the meantime also changes the revision.
+	var found []*DbProduct
+	if len(ids) > 0 {
+		if err := db.Find(&found, ids).Error; err != nil {
+			return nil, classifyDbError(ctx, fmt.Errorf("failed to get products %v: %w", ids, err), ResourceProduct, nil)
+		}
+	}
+	stored := make(map[uint64]*DbProduct, len(found))
+	for _, product := range found {
+		stored[product.ID] = product
+	}
+	errs, err := runBatch(db, len(products), mode, func(tx DbWrapper, i int) error {
+		product := products[i]
+		existing, ok := stored[product.ID]
+		if !ok {
+			return gorm.ErrRecordNotFound
+		}
+		product.Status, product.Visibility = existing.Status, existing.Visibility
+		product.AvailableFrom, product.AvailableUntil = existing.AvailableFrom, existing.AvailableUntil
+		return updateProduct(tx, product)
+	})
+	results, err := batchResults(ctx, errs, err, func(i int, err error) error {
+		id := products[i].ID
+		return skuError(classifyDbError(ctx, fmt.Errorf("failed to update a product %d: %w", id, err), ResourceProduct, id), products[i].Sku)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, product := range products {
+		results[i].ID = product.ID
+		if results[i].Err == nil {
+			results[i].Revision = product.Revision
+			p.indexProduct(product)
+		}
+	}
+	return results, nil
+}
+
+// Delete DbProducts in one transaction, each only if it still has its
+// revision.
+func (p *ProductService) BatchDeleteProducts(ctx context.Context, products []ProductRevision, mode BatchMode) ([]BatchResult, error) {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	errs, err := runBatch(db, len(products), mode, func(tx DbWrapper, i int) error {
+		return deleteProduct(tx, products[i].ID, products[i].Revision)
+	})
+	results, err := batchResults(ctx, errs, err, func(i int, err error) error {
+		id := products[i].ID
+		return classifyDbError(ctx, fmt.Errorf("failed to delete a product %d: %w", id, err), ResourceProduct, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, product := range products {
+		results[i].ID = product.ID
+		if results[i].Err == nil {
+			p.unindexProduct(product.ID)
+		}
+	}
+	return results, nil
+}
+
+// runBatch calls write for n items in one transaction, every item within a
+// savepoint so a failing item leaves the others untouched. In BatchAtomic
+// mode the first failing item rolls everything back. It returns the error of
+// every item and that of the transaction.
+func runBatch(db DbWrapper, n int, mode BatchMode, write func(tx DbWrapper, i int) error) ([]error, error) {
+	errs := make([]error, n)
+	if n == 0 {
+		return errs, nil
+	}
+	err := db.Transaction(func(tx DbWrapper) error {
+		for i := range errs {
+			errs[i] = tx.Transaction(func(tx DbWrapper) error {
+				return write(tx, i)
+			})
+			if errs[i] != nil && mode == BatchAtomic {
+				return &batchAbort{index: i}
+			}
+		}
+		return nil
+	})
+	return errs, err
+}
+
+// batchResults classifies the item errors of runBatch with itemErr. A failed
+// atomic batch returns the error of the failing item, naming its index.
+func batchResults(ctx context.Context, errs []error, err error, itemErr func(i int, err error) error) ([]BatchResult, error) {
+	results := make([]BatchResult, len(errs))
+	for i := range errs {
+		if errs[i] != nil {
+			results[i].Err = itemErr(i, errs[i])
+		}
+	}
+	var abort *batchAbort
+	switch {
+	case errors.As(err, &abort):
+		return nil, batchItemError(abort.index, results[abort.index].Err)
+	case err != nil:
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to write a batch of products: %w", err), ResourceProduct, nil)
+	}
+	return results, nil
+}
+
+// batchItemError prefixes the public message of err with the index of the
+// item it is about.
+func batchItemError(index int, err error) error {
+	var e *Error
+	if !errors.As(err, &e) {
+		return fmt.Errorf("item %d: %w", index, err)
+	}
+	item := *e
+	item.Message = fmt.Sprintf("item %d: %s", index, e.PublicMessage())
+	return &item
+}
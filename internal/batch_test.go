@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductService_GetProductsByIDs(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	ps := &ProductService{DB: GormWrapper{DB: db}}
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` IN (?,?,?,?) AND `catalog_products`.`deleted_at` IS NULL")).
+		WithArgs(3, 1, 3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Running Shirt").AddRow(3, "Coffee Mug"))
+	//when
+	products, missing, err := ps.GetProductsByIDs(context.Background(), []uint64{3, 1, 3, 2})
+	//then
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	assert.Equal(t, uint64(3), products[0].ID)
+	assert.Equal(t, uint64(1), products[1].ID)
+	assert.Equal(t, []uint64{2}, missing)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestProductService_BatchCreateProducts(t *testing.T) {
+	expectCreate := func(sqlMock sqlmock.Sqlmock, id int64) {
+		sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_products`")).
+			WillReturnResult(sqlmock.NewResult(id, 1))
+	}
+	duplicate := &mysql.MySQLError{Number: mysqlErrDuplicateEntry, Message: "Duplicate entry 'MG-1' for key 'idx_product_active_sku'"}
+
+	t.Run("All products are created and indexed", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex()}
+		sqlMock.ExpectBegin()
+		expectCreate(sqlMock, 4)
+		expectCreate(sqlMock, 5)
+		sqlMock.ExpectCommit()
+		//when
+		results, err := ps.BatchCreateProducts(context.Background(), []*DbProduct{{Name: "Desk Lamp", Sku: "DL-1"}, {Name: "Coffee Mug", Sku: "MG-1"}}, BatchAtomic)
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []BatchResult{{ID: 4, Revision: 1}, {ID: 5, Revision: 1}}, results)
+		assert.Equal(t, []uint64{5}, hitIDs(ps.Index.Search("mug")))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Failing item rolls an atomic batch back", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex()}
+		sqlMock.ExpectBegin()
+		expectCreate(sqlMock, 4)
+		sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_products`")).WillReturnError(duplicate)
+		sqlMock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectRollback()
+		//when
+		results, err := ps.BatchCreateProducts(context.Background(), []*DbProduct{{Name: "Desk Lamp", Sku: "DL-1"}, {Name: "Coffee Mug", Sku: "MG-1"}}, BatchAtomic)
+		//then
+		assert.Nil(t, results)
+		assert.Equal(t, KindAlreadyExists, KindOf(err))
+		assert.Equal(t, `item 1: product with sku "MG-1" already exists`, err.(*Error).PublicMessage())
+		assert.Empty(t, ps.Index.Search("lamp"))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Best effort batch keeps the other items", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_products`")).WillReturnError(duplicate)
+		sqlMock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		expectCreate(sqlMock, 5)
+		sqlMock.ExpectCommit()
+		//when
+		results, err := ps.BatchCreateProducts(context.Background(), []*DbProduct{{Name: "Coffee Mug", Sku: "MG-1"}, {Name: "Desk Lamp", Sku: "DL-1"}}, BatchBestEffort)
+		//then
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, KindAlreadyExists, KindOf(results[0].Err))
+		assert.Equal(t, BatchResult{ID: 5, Revision: 1}, results[1])
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestProductService_BatchUpdateProducts(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	ps := &ProductService{DB: GormWrapper{DB: db}}
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "visibility", "revision"}).AddRow(1, "draft", "catalog", 3))
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectCommit()
+	products := []*DbProduct{{ID: 1, Name: "Running Shirt", Sku: "RS-1", Revision: 3}, {ID: 2, Name: "Coffee Mug", Sku: "MG-1", Revision: 1}}
+	//when
+	results, err := ps.BatchUpdateProducts(context.Background(), products, BatchBestEffort)
+	//then
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, BatchResult{ID: 1, Revision: 4}, results[0])
+	assert.Equal(t, ProductDraft, products[0].Status)
+	assert.Equal(t, uint64(2), results[1].ID)
+	assert.Equal(t, KindNotFound, KindOf(results[1].Err))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestProductService_BatchDeleteProducts(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	ps := &ProductService{DB: GormWrapper{DB: db}}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `deleted_at`=? WHERE revision = ? AND `catalog_products`.`id` = ?")).
+		WithArgs(sqlmock.AnyArg(), 2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `deleted_at`=? WHERE revision = ? AND `catalog_products`.`id` = ?")).
+		WithArgs(sqlmock.AnyArg(), 5, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "revision"}).AddRow(2, 6))
+	sqlMock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+	//when
+	results, err := ps.BatchDeleteProducts(context.Background(), []ProductRevision{{ID: 1, Revision: 2}, {ID: 2, Revision: 5}}, BatchAtomic)
+	//then
+	assert.Nil(t, results)
+	assert.Equal(t, KindConflict, KindOf(err))
+	assert.Contains(t, err.(*Error).PublicMessage(), "item 1: ")
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,339 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxCategoryPathLength matches the size of the path column and bounds the
+// depth of the tree.
+const maxCategoryPathLength = 512
+
+// DbCategory is a node of the category tree. Siblings are ordered by
+// Position. Path is the materialized path of ids from the root down to the
+// category, e.g. "/1/4/9/", so a whole subtree is matched by a single prefix
+// condition.
+type DbCategory struct {
+	ID        uint64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ParentID is nil for root categories.
+	ParentID *uint64 `gorm:"index"`
+	Name     string  `gorm:"size:255"`
+	Slug     string  `gorm:"size:255;uniqueIndex"`
+	Position int     `gorm:"not null;default:0"`
+	Path     string  `gorm:"size:512;index"`
+}
+
+func (DbCategory) TableName() string {
+	return "catalog_categories"
+}
+
+// DbProductCategory assigns a product to a category.
+type DbProductCategory struct {
+	ProductID  uint64 `gorm:"primaryKey;autoIncrement:false"`
+	CategoryID uint64 `gorm:"primaryKey;autoIncrement:false;index"`
+}
+
+func (DbProductCategory) TableName() string {
+	return "catalog_product_categories"
+}
+
+// CategoryNode is a category with its children, as returned by GetCategoryTree.
+type CategoryNode struct {
+	*DbCategory
+	Children []*CategoryNode
+}
+
+type CategoryServiceInterface interface {
+	CreateCategory(ctx context.Context, category *DbCategory) (uint64, error)
+	GetCategoryByID(ctx context.Context, id uint64) (*DbCategory, error)
+	UpdateCategory(ctx context.Context, category *DbCategory) error
+	MoveCategory(ctx context.Context, id uint64, parentID *uint64, position int) error
+	DeleteCategoryByID(ctx context.Context, id uint64) error
+	GetCategoryTree(ctx context.Context) ([]*CategoryNode, error)
+	AssignProducts(ctx context.Context, categoryID uint64, productIDs []uint64) error
+	UnassignProducts(ctx context.Context, categoryID uint64, productIDs []uint64) error
+}
+
+type CategoryService struct {
+	DB DbWrapper
+	// QueryTimeout bounds every single query, zero disables the limit.
+	QueryTimeout time.Duration
+}
+
+// Create a new DbCategory under its parent, or as a root when ParentID is nil
+func (c *CategoryService) CreateCategory(ctx context.Context, category *DbCategory) (uint64, error) {
+	db, ctx, cancel := bindDB(ctx, c.DB, c.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		parentPath, err := lockParentPath(tx, category.ParentID)
+		if err != nil {
+			return err
+		}
+		category.Path = ""
+		if err := tx.Create(category).Error; err != nil {
+			return err
+		}
+		category.Path = fmt.Sprintf("%s%d/", parentPath, category.ID)
+		if len(category.Path) > maxCategoryPathLength {
+			return tooDeepError()
+		}
+		return tx.Scopes(func(db *gorm.DB) *gorm.DB { return db.Model(category) }).UpdateColumn("path", category.Path).Error
+	})
+	if err != nil {
+		return ErrorId, slugError(classifyDbError(ctx, fmt.Errorf("failed to create a category: %w", err), ResourceCategory, nil), category.Slug)
+	}
+	return category.ID, nil
+}
+
+// Read a DbCategory by ID
+func (c *CategoryService) GetCategoryByID(ctx context.Context, id uint64) (*DbCategory, error) {
+	db, ctx, cancel := bindDB(ctx, c.DB, c.QueryTimeout)
+	defer cancel()
+	category := DbCategory{}
+	result := db.First(&category, id)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get a category %d: %w", id, result.Error), ResourceCategory, id)
+	}
+	return &category, nil
+}
+
+// Update the name, slug and position of a DbCategory. Use MoveCategory to
+// change its parent.
+func (c *CategoryService) UpdateCategory(ctx context.Context, category *DbCategory) error {
+	db, ctx, cancel := bindDB(ctx, c.DB, c.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		existing := DbCategory{}
+		if err := tx.First(&existing, category.ID).Error; err != nil {
+			return err
+		}
+		return tx.Scopes(func(db *gorm.DB) *gorm.DB { return db.Model(&existing) }).Select("name", "slug", "position").Updates(category).Error
+	})
+	if err != nil {
+		return slugError(classifyDbError(ctx, fmt.Errorf("failed to update a category %d: %w", category.ID, err), ResourceCategory, category.ID), category.Slug)
+	}
+	return nil
+}
+
+// Move a DbCategory with its subtree under another parent, or to the root
+// when parentID is nil. The paths of the whole subtree are rewritten by a
+// single statement.
+func (c *CategoryService) MoveCategory(ctx context.Context, id uint64, parentID *uint64, position int) error {
+	db, ctx, cancel := bindDB(ctx, c.DB, c.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		category := DbCategory{}
+		if err := tx.Scopes(forUpdate).First(&category, id).Error; err != nil {
+			return err
+		}
+		parentPath, err := lockParentPath(tx, parentID)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(parentPath, category.Path) {
+			return InvalidArgumentError("invalid move", FieldViolation{Field: "parent_id", Description: "must not be the category or one of its descendants"})
+		}
+		newPath := fmt.Sprintf("%s%d/", parentPath, id)
+		var longest int
+		if err := tx.Where("path LIKE ?", category.Path+"%").Model(&DbCategory{}).Select("COALESCE(MAX(LENGTH(path)), 0)").Scan(&longest).Error; err != nil {
+			return err
+		}
+		if longest-len(category.Path)+len(newPath) > maxCategoryPathLength {
+			return tooDeepError()
+		}
+		if newPath != category.Path {
+			err := tx.Where("path LIKE ?", category.Path+"%").Model(&DbCategory{}).
+				UpdateColumn("path", gorm.Expr("CONCAT(?, SUBSTRING(path, ?))", newPath, len(category.Path)+1)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Scopes(func(db *gorm.DB) *gorm.DB { return db.Model(&category) }).
+			Updates(map[string]interface{}{"parent_id": parentID, "position": position}).Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to move a category %d: %w", id, err), ResourceCategory, id)
+	}
+	return nil
+}
+
+// Delete a DbCategory with its whole subtree. Products are only unassigned.
+func (c *CategoryService) DeleteCategoryByID(ctx context.Context, id uint64) error {
+	db, ctx, cancel := bindDB(ctx, c.DB, c.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		category := DbCategory{}
+		if err := tx.Scopes(forUpdate).First(&category, id).Error; err != nil {
+			return err
+		}
+		subtree := func(db *gorm.DB) *gorm.DB {
+			return db.Where("category_id IN (?)", db.Session(&gorm.Session{NewDB: true}).Model(&DbCategory{}).Select("id").Where("path LIKE ?", category.Path+"%"))
+		}
+		if err := tx.Scopes(subtree).Delete(&DbProductCategory{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("path LIKE ?", category.Path+"%").Delete(&DbCategory{}).Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to delete a category %d: %w", id, err), ResourceCategory, id)
+	}
+	return nil
+}
+
+// Read all categories as a forest of root categories, siblings ordered by position
+func (c *CategoryService) GetCategoryTree(ctx context.Context) ([]*CategoryNode, error) {
+	db, ctx, cancel := bindDB(ctx, c.DB, c.QueryTimeout)
+	defer cancel()
+	var categories []*DbCategory
+	result := db.Find(&categories)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get categories: %w", result.Error), ResourceCategory, nil)
+	}
+	return buildCategoryTree(categories), nil
+}
+
+// Assign products to a DbCategory. Products already assigned are skipped.
+func (c *CategoryService) AssignProducts(ctx context.Context, categoryID uint64, productIDs []uint64) error {
+	productIDs = uniqueIDs(productIDs)
+	if len(productIDs) == 0 {
+		return nil
+	}
+	db, ctx, cancel := bindDB(ctx, c.DB, c.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		if err := tx.First(&DbCategory{}, categoryID).Error; err != nil {
+			return err
+		}
+		var found []uint64
+		if err := tx.Where("id IN ?", productIDs).Model(&DbProduct{}).Pluck("id", &found).Error; err != nil {
+			return err
+		}
+		if missing := missingID(productIDs, found); missing != 0 {
+			return NotFoundError(ResourceProduct, missing)
+		}
+		rows := make([]DbProductCategory, len(productIDs))
+		for i, productID := range productIDs {
+			rows[i] = DbProductCategory{ProductID: productID, CategoryID: categoryID}
+		}
+		skipAssigned := func(db *gorm.DB) *gorm.DB { return db.Clauses(clause.OnConflict{DoNothing: true}) }
+		return tx.Scopes(skipAssigned).Create(&rows).Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to assign products to a category %d: %w", categoryID, err), ResourceCategory, categoryID)
+	}
+	return nil
+}
+
+// Remove products from a DbCategory. Products that are not assigned are ignored.
+func (c *CategoryService) UnassignProducts(ctx context.Context, categoryID uint64, productIDs []uint64) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	db, ctx, cancel := bindDB(ctx, c.DB, c.QueryTimeout)
+	defer cancel()
+	result := db.Where("category_id = ? AND product_id IN ?", categoryID, productIDs).Delete(&DbProductCategory{})
+	if result.Error != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to unassign products from a category %d: %w", categoryID, result.Error), ResourceCategory, categoryID)
+	}
+	return nil
+}
+
+// lockParentPath returns the path of the parent category, or "/" for a root,
+// and locks the parent row so it cannot be moved concurrently.
+func lockParentPath(tx DbWrapper, parentID *uint64) (string, error) {
+	if parentID == nil {
+		return "/", nil
+	}
+	parent := DbCategory{}
+	err := tx.Scopes(forUpdate).First(&parent, *parentID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", NotFoundError(ResourceCategory, *parentID)
+	}
+	return parent.Path, err
+}
+
+// buildCategoryTree links categories to their parents. Categories whose
+// parent is missing are treated as roots.
+func buildCategoryTree(categories []*DbCategory) []*CategoryNode {
+	nodes := make(map[uint64]*CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryNode{DbCategory: category}
+	}
+	var roots []*CategoryNode
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	sortCategoryNodes(roots)
+	return roots
+}
+
+func sortCategoryNodes(nodes []*CategoryNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Position != nodes[j].Position {
+			return nodes[i].Position < nodes[j].Position
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	for _, node := range nodes {
+		sortCategoryNodes(node.Children)
+	}
+}
+
+func tooDeepError() error {
+	return InvalidArgumentError("category tree is too deep", FieldViolation{Field: "parent_id", Description: "is nested too deeply"})
+}
+
+// uniqueIDs drops duplicates keeping the first occurrence.
+func uniqueIDs(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	var out []uint64
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// missingID returns the first of ids that is not in found, or 0.
+func missingID(ids, found []uint64) uint64 {
+	present := make(map[uint64]bool, len(found))
+	for _, id := range found {
+		present[id] = true
+	}
+	for _, id := range ids {
+		if !present[id] {
+			return id
+		}
+	}
+	return 0
+}
+
+// slugError makes a duplicate key error point at the slug, the only unique
+// column of a category.
+func slugError(err error, slug string) error {
+	var e *Error
+	if !errors.As(err, &e) || e.Kind != KindAlreadyExists {
+		return err
+	}
+	e.ID = slug
+	e.Message = fmt.Sprintf("category with slug %q already exists", slug)
+	return e
+}
@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	cs := &CategoryService{DB: GormWrapper{DB: db}}
+	parentID := uint64(4)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_categories` WHERE `catalog_categories`.`id` = ? ORDER BY `catalog_categories`.`id` LIMIT ? FOR UPDATE")).
+		WithArgs(4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "path"}).AddRow(4, "/1/4/"))
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_categories`")).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_categories` SET `path`=? WHERE `id` = ?")).
+		WithArgs("/1/4/9/", 9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+	category := &DbCategory{ParentID: &parentID, Name: "T-Shirts", Slug: "t-shirts"}
+	//when
+	id, err := cs.CreateCategory(context.Background(), category)
+	//then
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), id)
+	assert.Equal(t, "/1/4/9/", category.Path)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestCategoryService_MoveCategory(t *testing.T) {
+	t.Run("Subtree paths are rewritten in one statement", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		cs := &CategoryService{DB: GormWrapper{DB: db}}
+		parentID := uint64(2)
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_categories` WHERE `catalog_categories`.`id` = ?")).
+			WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "path"}).AddRow(4, 1, "/1/4/"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_categories` WHERE `catalog_categories`.`id` = ?")).
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "path"}).AddRow(2, nil, "/2/"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(LENGTH(path)), 0) FROM `catalog_categories` WHERE path LIKE ?")).
+			WithArgs("/1/4/%").
+			WillReturnRows(sqlmock.NewRows([]string{"m"}).AddRow(7))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_categories` SET `path`=CONCAT(?, SUBSTRING(path, ?)) WHERE path LIKE ?")).
+			WithArgs("/2/4/", 6, "/1/4/%").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_categories` SET `parent_id`=?,`position`=?,`updated_at`=? WHERE `id` = ?")).
+			WithArgs(&parentID, 3, sqlmock.AnyArg(), 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+		//when
+		err := cs.MoveCategory(context.Background(), 4, &parentID, 3)
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Moving into the own subtree is rejected", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		cs := &CategoryService{DB: GormWrapper{DB: db}}
+		parentID := uint64(9)
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_categories`")).
+			WithArgs(4, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "path"}).AddRow(4, "/1/4/"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_categories`")).
+			WithArgs(9, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "path"}).AddRow(9, "/1/4/9/"))
+		sqlMock.ExpectRollback()
+		//when
+		err := cs.MoveCategory(context.Background(), 4, &parentID, 0)
+		//then
+		assert.Equal(t, KindInvalidArgument, KindOf(err))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestCategoryService_DeleteCategoryByID(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	cs := &CategoryService{DB: GormWrapper{DB: db}}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_categories`")).
+		WithArgs(4, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "path"}).AddRow(4, "/1/4/"))
+	sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_product_categories` WHERE category_id IN (SELECT `id` FROM `catalog_categories` WHERE path LIKE ?)")).
+		WithArgs("/1/4/%").
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_categories` WHERE path LIKE ?")).
+		WithArgs("/1/4/%").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	sqlMock.ExpectCommit()
+	//when
+	err := cs.DeleteCategoryByID(context.Background(), 4)
+	//then
+	assert.NoError(t, err)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestCategoryService_AssignProducts(t *testing.T) {
+	t.Run("Products are assigned once", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		cs := &CategoryService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_categories`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `catalog_products` WHERE id IN (?,?) AND `catalog_products`.`deleted_at` IS NULL")).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_product_categories` (`product_id`,`category_id`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE")).
+			WithArgs(1, 4, 2, 4).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		sqlMock.ExpectCommit()
+		//when
+		err := cs.AssignProducts(context.Background(), 4, []uint64{1, 2, 1})
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown product is reported", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		cs := &CategoryService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_categories`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		sqlMock.ExpectRollback()
+		//when
+		err := cs.AssignProducts(context.Background(), 4, []uint64{1, 2})
+		//then
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, KindNotFound, e.Kind)
+		assert.Equal(t, ResourceProduct, e.Resource)
+		assert.Equal(t, "2", e.ID)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	// given
+	one, two := uint64(1), uint64(2)
+	categories := []*DbCategory{
+		{ID: 3, ParentID: &one, Position: 2},
+		{ID: 1},
+		{ID: 2, ParentID: &one, Position: 1},
+		{ID: 5, ParentID: &two},
+		{ID: 4, Position: -1},
+	}
+	//when
+	roots := buildCategoryTree(categories)
+	//then
+	require.Len(t, roots, 2)
+	assert.Equal(t, uint64(4), roots[0].ID)
+	assert.Equal(t, uint64(1), roots[1].ID)
+	require.Len(t, roots[1].Children, 2)
+	assert.Equal(t, uint64(2), roots[1].Children[0].ID)
+	assert.Equal(t, uint64(3), roots[1].Children[1].ID)
+	require.Len(t, roots[1].Children[0].Children, 1)
+	assert.Equal(t, uint64(5), roots[1].Children[0].Children[0].ID)
+}
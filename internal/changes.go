@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"sync"
+	"time"
+)
+
+// ProductChangeType tells how the public availability of a product changed.
+type ProductChangeType string
+
+const (
+	// ProductAvailable is published when the public starts to see a product.
+	ProductAvailable ProductChangeType = "available"
+	// ProductUnavailable is published when the public stops seeing a product.
+	ProductUnavailable ProductChangeType = "unavailable"
+)
+
+// ProductChange tells downstream caches to invalidate a product.
+type ProductChange struct {
+	ProductID uint64
+	Type      ProductChangeType
+	At        time.Time
+}
+
+// DefaultChangeBuffer is the number of changes a subscriber may fall behind.
+const DefaultChangeBuffer = 256
+
+// ChangeFeed fans product changes out to subscribers in this process. It is
+// safe for concurrent use, a nil feed drops every change.
+type ChangeFeed struct {
+	mu          sync.Mutex
+	subscribers map[chan ProductChange]bool
+}
+
+func NewChangeFeed() *ChangeFeed {
+	return &ChangeFeed{subscribers: map[chan ProductChange]bool{}}
+}
+
+// Subscribe returns a channel receiving every change published from now on
+// and a function ending the subscription. Publishing never waits for a
+// subscriber: one that falls more than buffer changes behind has its
+// channel closed and must resubscribe and reload what it caches.
+func (f *ChangeFeed) Subscribe(buffer int) (<-chan ProductChange, func()) {
+	if buffer <= 0 {
+		buffer = DefaultChangeBuffer
+	}
+	changes := make(chan ProductChange, buffer)
+	f.mu.Lock()
+	f.subscribers[changes] = true
+	f.mu.Unlock()
+	return changes, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.subscribers[changes] {
+			delete(f.subscribers, changes)
+			close(changes)
+		}
+	}
+}
+
+// Publish hands the change to every subscriber.
+func (f *ChangeFeed) Publish(change ProductChange) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for changes := range f.subscribers {
+		select {
+		case changes <- change:
+		default:
+			delete(f.subscribers, changes)
+			close(changes)
+		}
+	}
+}
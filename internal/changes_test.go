@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeFeed(t *testing.T) {
+	t.Run("Changes reach every subscriber", func(t *testing.T) {
+		// given
+		feed := NewChangeFeed()
+		first, unsubscribeFirst := feed.Subscribe(1)
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := feed.Subscribe(1)
+		defer unsubscribeSecond()
+		//when
+		feed.Publish(ProductChange{ProductID: 1, Type: ProductAvailable})
+		//then
+		assert.Equal(t, uint64(1), (<-first).ProductID)
+		assert.Equal(t, uint64(1), (<-second).ProductID)
+	})
+
+	t.Run("Subscriber falling behind is closed", func(t *testing.T) {
+		// given
+		feed := NewChangeFeed()
+		changes, unsubscribe := feed.Subscribe(1)
+		//when
+		feed.Publish(ProductChange{ProductID: 1, Type: ProductAvailable})
+		feed.Publish(ProductChange{ProductID: 2, Type: ProductAvailable})
+		//then
+		assert.Equal(t, uint64(1), (<-changes).ProductID)
+		_, ok := <-changes
+		assert.False(t, ok)
+		unsubscribe()
+	})
+
+	t.Run("Nil feed drops changes", func(t *testing.T) {
+		var feed *ChangeFeed
+		assert.NotPanics(t, func() { feed.Publish(ProductChange{ProductID: 1}) })
+	})
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	pb "github.com/akolpakov-somehash/headless-ecom-protos/gen/go/catalog"
+)
+
+// ProductEnricher adds data to an already converted product, e.g. values
+// looked up in another system. Enrichers may be slow, so they run on the
+// bounded worker pool of ProductConverter.
+type ProductEnricher func(ctx context.Context, product *pb.Product) error
+
+// ProductConverter turns DbProducts into protobuf messages. Plain conversion
+// is a cheap field copy and is done in a single loop; only enrichment is
+// spread over at most Workers goroutines.
+type ProductConverter struct {
+	Enrichers []ProductEnricher
+	// Workers bounds enrichment concurrency, GOMAXPROCS when zero.
+	Workers int
+}
+
+// ToProtoSlice converts products keeping their order.
+func (c *ProductConverter) ToProtoSlice(ctx context.Context, products []*DbProduct) ([]*pb.Product, error) {
+	out := make([]*pb.Product, len(products))
+	for i, product := range products {
+		out[i] = productToProto(product)
+	}
+	if c == nil || len(c.Enrichers) == 0 {
+		return out, nil
+	}
+	if err := c.enrich(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ToProtoMap converts products into the id keyed map used by pb.ProductList.
+func (c *ProductConverter) ToProtoMap(ctx context.Context, products []*DbProduct) (map[uint64]*pb.Product, error) {
+	out := make(map[uint64]*pb.Product, len(products))
+	if c == nil || len(c.Enrichers) == 0 {
+		for _, product := range products {
+			out[product.ID] = productToProto(product)
+		}
+		return out, nil
+	}
+	converted, err := c.ToProtoSlice(ctx, products)
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range converted {
+		out[product.Id] = product
+	}
+	return out, nil
+}
+
+// enrich runs all enrichers over products. Every worker claims the next index
+// from a shared counter and owns that slot, so no locking is needed. The first
+// error cancels the remaining work.
+func (c *ProductConverter) enrich(ctx context.Context, products []*pb.Product) error {
+	workers := c.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(products) {
+		workers = len(products)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		next     atomic.Int64
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(products) || ctx.Err() != nil {
+					return
+				}
+				for _, enricher := range c.Enrichers {
+					if err := enricher(ctx, products[i]); err != nil {
+						errOnce.Do(func() {
+							firstErr = err
+							cancel()
+						})
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
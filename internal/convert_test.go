@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	pb "github.com/akolpakov-somehash/headless-ecom-protos/gen/go/catalog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductConverter_ToProtoSlice(t *testing.T) {
+	// given
+	products := make([]*DbProduct, 100)
+	for i := range products {
+		products[i] = &DbProduct{ID: uint64(i + 1), Name: fmt.Sprintf("Product %d", i+1)}
+	}
+
+	t.Run("Enrichers run on every product keeping the order", func(t *testing.T) {
+		var running, maxRunning atomic.Int32
+		converter := &ProductConverter{Workers: 4, Enrichers: []ProductEnricher{
+			func(ctx context.Context, product *pb.Product) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					m := maxRunning.Load()
+					if n <= m || maxRunning.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				product.Description = "enriched " + product.Name
+				return nil
+			},
+		}}
+		//when
+		out, err := converter.ToProtoSlice(context.Background(), products)
+		//then
+		assert.NoError(t, err)
+		assert.Len(t, out, len(products))
+		for i, product := range out {
+			assert.Equal(t, products[i].ID, product.Id)
+			assert.Equal(t, "enriched "+products[i].Name, product.Description)
+		}
+		assert.LessOrEqual(t, maxRunning.Load(), int32(4))
+	})
+
+	t.Run("First enrichment error is returned", func(t *testing.T) {
+		failure := errors.New("enrichment failed")
+		converter := &ProductConverter{Workers: 4, Enrichers: []ProductEnricher{
+			func(ctx context.Context, product *pb.Product) error {
+				if product.Id == 50 {
+					return failure
+				}
+				return nil
+			},
+		}}
+		//when
+		_, err := converter.ToProtoSlice(context.Background(), products)
+		//then
+		assert.ErrorIs(t, err, failure)
+	})
+
+	t.Run("Nil converter does plain conversion", func(t *testing.T) {
+		var converter *ProductConverter
+		//when
+		out, err := converter.ToProtoMap(context.Background(), products)
+		//then
+		assert.NoError(t, err)
+		assert.Len(t, out, len(products))
+		assert.Equal(t, "Product 7", out[7].Name)
+	})
+}
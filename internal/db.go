@@ -1,92 +1,461 @@
 package internal
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DbProduct struct {
 	gorm.Model
 	ID          uint64
-	Name        string
-	Sku         string
+	Name        string `gorm:"size:255;index"`
+	Sku         string `gorm:"size:255;index:idx_product_sku"` // unique among products that are not deleted, see uniqueActiveSkus
 	Description string
-	Price       float32
-	Image       string
+	// Price is the base price, kept in the catalog currency.
+	Price Money `gorm:"embedded;embeddedPrefix:price_"`
+	Image string
+	// Prices holds the prices in currencies other than Price.Currency.
+	Prices []DbProductPrice `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	// Status and Visibility decide whether and where the public sees the
+	// product. They are changed with SetProductLifecycle only.
+	Status     ProductStatus     `gorm:"size:16;not null;default:active;index"`
+	Visibility ProductVisibility `gorm:"size:16;not null;default:both"`
+	// AvailableFrom and AvailableUntil limit when the public sees the product,
+	// nil leaves the window open. They are changed with SetProductAvailability only.
+	AvailableFrom  *time.Time `gorm:"index"`
+	AvailableUntil *time.Time `gorm:"index"`
+	// Revision is incremented by every write of the product. Updates and
+	// deletes must name the revision they are based on.
+	Revision uint64 `gorm:"not null;default:1"`
 }
 
 func (DbProduct) TableName() string {
 	return "catalog_products"
 }
 
+// BeforeSave keeps SKUs unique across products and variants.
+func (p *DbProduct) BeforeSave(tx *gorm.DB) error {
+	return checkSkuUnused(tx, &DbVariant{}, p.Sku)
+}
+
+// DbProductPrice is the price of a product in one additional currency.
+type DbProductPrice struct {
+	ID        uint64
+	ProductID uint64 `gorm:"uniqueIndex:idx_product_currency"`
+	Currency  string `gorm:"size:3;uniqueIndex:idx_product_currency"`
+	Amount    int64
+}
+
+func (DbProductPrice) TableName() string {
+	return "catalog_product_prices"
+}
+
+func (p DbProductPrice) Money() Money {
+	return Money{Amount: p.Amount, Currency: p.Currency}
+}
+
 const (
 	ErrorId = 0
+
+	DefaultExportBatchSize = 500
+	MaxExportBatchSize     = 5000
 )
 
 type DbWrapper interface {
+	WithContext(context.Context) DbWrapper
 	Create(interface{}) *gorm.DB
 	First(interface{}, ...interface{}) *gorm.DB
 	Save(interface{}) *gorm.DB
 	Delete(interface{}, ...interface{}) *gorm.DB
 	Find(interface{}, ...interface{}) *gorm.DB
+	Where(interface{}, ...interface{}) *gorm.DB
+	Scopes(...func(*gorm.DB) *gorm.DB) *gorm.DB
+	UpdateWhere(interface{}, interface{}, ...interface{}) *gorm.DB
+	Transaction(func(tx DbWrapper) error, ...*sql.TxOptions) error
+	Begin(...*sql.TxOptions) (Tx, error)
+}
+
+// GormWrapper adapts *gorm.DB to DbWrapper.
+type GormWrapper struct {
+	*gorm.DB
+}
+
+func (g GormWrapper) WithContext(ctx context.Context) DbWrapper {
+	return GormWrapper{DB: g.DB.WithContext(ctx)}
+}
+
+// UpdateWhere writes every column of value but created_at to its row,
+// provided the row also matches query.
+func (g GormWrapper) UpdateWhere(value interface{}, query interface{}, args ...interface{}) *gorm.DB {
+	return g.DB.Model(value).Where(query, args...).Select("*").Omit("created_at").Updates(value)
+}
+
+// Transaction runs fc in a transaction, nested calls use a savepoint.
+func (g GormWrapper) Transaction(fc func(tx DbWrapper) error, opts ...*sql.TxOptions) error {
+	return g.DB.Transaction(func(tx *gorm.DB) error {
+		return fc(GormWrapper{DB: tx})
+	}, opts...)
+}
+
+// forUpdate locks the rows a DbWrapper.Scopes query reads until the
+// transaction ends.
+func forUpdate(db *gorm.DB) *gorm.DB {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"})
+}
+
+// withoutHooks skips the hooks of a DbWrapper.Scopes write.
+func withoutHooks(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{SkipHooks: true})
 }
 
 type ProductServiceInterface interface {
-	CreateProduct(product *DbProduct) (uint64, error)
-	GetProductByID(id uint64) (*DbProduct, error)
-	UpdateProduct(product *DbProduct) error
-	DeleteProductByID(id uint64) error
-	GetAllProducts() ([]*DbProduct, error)
+	CreateProduct(ctx context.Context, product *DbProduct) (uint64, error)
+	CreateProductOnce(ctx context.Context, key IdempotencyKey, product *DbProduct) (uint64, bool, error)
+	GetProductByID(ctx context.Context, id uint64) (*DbProduct, error)
+	GetProductBySKU(ctx context.Context, sku string) (*DbProduct, error)
+	GetProductsByIDs(ctx context.Context, ids []uint64) ([]*DbProduct, []uint64, error)
+	UpdateProduct(ctx context.Context, product *DbProduct) error
+	PatchProduct(ctx context.Context, id uint64, patch *DbProduct, fields []ProductField) (*DbProduct, error)
+	DeleteProductByID(ctx context.Context, id uint64, revision uint64) error
+	BatchCreateProducts(ctx context.Context, products []*DbProduct, mode BatchMode) ([]BatchResult, error)
+	BatchUpdateProducts(ctx context.Context, products []*DbProduct, mode BatchMode) ([]BatchResult, error)
+	BatchDeleteProducts(ctx context.Context, products []ProductRevision, mode BatchMode) ([]BatchResult, error)
+	GetAllProducts(ctx context.Context) ([]*DbProduct, error)
+	ListProducts(ctx context.Context, query ListQuery) (*ListPage, error)
+	FacetProducts(ctx context.Context, query FacetQuery) (*FacetPage, error)
+	SearchProducts(ctx context.Context, query SearchQuery) (*SearchPage, error)
+	RebuildSearchIndex(ctx context.Context) (int, error)
+	SuggestProducts(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+	ExportProducts(ctx context.Context, afterID uint64, batchSize int, fn func(*DbProduct) error) error
+	GetProductPrices(ctx context.Context, id uint64) ([]Money, error)
+	SetProductPrices(ctx context.Context, id, revision uint64, prices []Money) error
+	SetProductLifecycle(ctx context.Context, id, revision uint64, status ProductStatus, visibility ProductVisibility) error
+	SetProductAvailability(ctx context.Context, id, revision uint64, from, until *time.Time) error
+	InTransaction(ctx context.Context, fn func(tx ProductServiceInterface) error) error
 }
 
 type ProductService struct {
 	DB DbWrapper
+	// QueryTimeout bounds every single query, zero disables the limit.
+	// The caller's deadline still applies when it is shorter.
+	QueryTimeout time.Duration
+	// Index is kept in sync with created, updated and deleted products and
+	// serves SearchProducts. Search is disabled when nil.
+	Index *SearchIndex
+	// Suggestions is kept in sync like Index and serves SuggestProducts.
+	Suggestions *SuggestIndex
+	// Changes receives a ProductChange whenever a product becomes available
+	// to the public or stops being so. Changes are dropped when nil.
+	Changes *ChangeFeed
+	// ScheduleInterval is how often RunScheduler looks for products crossing
+	// their availability window.
+	ScheduleInterval time.Duration
+	// IdempotencyTTL is how long CreateProductOnce remembers a key,
+	// DefaultIdempotencyTTL when zero.
+	IdempotencyTTL time.Duration
+	// IdempotencyPurgeInterval is how often RunIdempotencyPurge forgets
+	// expired keys.
+	IdempotencyPurgeInterval time.Duration
+
+	// pending collects the effects of a unit of work run by InTransaction.
+	pending *[]func()
 }
 
-// Create a new DbProduct
-func (p *ProductService) CreateProduct(product *DbProduct) (uint64, error) {
-	result := p.DB.Create(product)
+// db binds the wrapper to ctx limited by QueryTimeout. The returned context
+// must be used for error classification and cancel must always be called.
+func (p *ProductService) db(ctx context.Context) (DbWrapper, context.Context, context.CancelFunc) {
+	return bindDB(ctx, p.DB, p.QueryTimeout)
+}
+
+func bindDB(ctx context.Context, db DbWrapper, timeout time.Duration) (DbWrapper, context.Context, context.CancelFunc) {
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	return db.WithContext(ctx), ctx, cancel
+}
+
+// Create a new DbProduct, active and visible everywhere unless set otherwise
+func (p *ProductService) CreateProduct(ctx context.Context, product *DbProduct) (uint64, error) {
+	product.Status, product.Visibility = product.lifecycle()
+	product.Revision = 1
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	result := db.Create(product)
 	if result.Error != nil {
-		return ErrorId, fmt.Errorf("failed to create a product: %w", result.Error)
+		return ErrorId, skuError(classifyDbError(ctx, fmt.Errorf("failed to create a product: %w", result.Error), ResourceProduct, nil), product.Sku)
 	}
+	p.indexProduct(product)
 	return product.ID, nil
 }
 
 // Read a DbProduct by ID
-func (p *ProductService) GetProductByID(id uint64) (*DbProduct, error) {
+func (p *ProductService) GetProductByID(ctx context.Context, id uint64) (*DbProduct, error) {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
 	product := DbProduct{}
-	result := p.DB.First(&product, id)
+	result := db.First(&product, id)
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get a product %d: %w", id, result.Error)
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get a product %d: %w", id, result.Error), ResourceProduct, id)
 	}
 	return &product, nil
 }
 
-// Update a DbProduct
-func (p *ProductService) UpdateProduct(product *DbProduct) error {
-	result := p.DB.Save(product)
+// Read a DbProduct by SKU
+func (p *ProductService) GetProductBySKU(ctx context.Context, sku string) (*DbProduct, error) {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	product := DbProduct{}
+	result := db.First(&product, "sku = ?", sku)
 	if result.Error != nil {
-		return fmt.Errorf("failed to update a product %d: %w", product.ID, result.Error)
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get a product by sku %q: %w", sku, result.Error), ResourceProduct, sku)
 	}
+	return &product, nil
+}
+
+// Update a DbProduct if it still has product.Revision, which is incremented
+// on success
+func (p *ProductService) UpdateProduct(ctx context.Context, product *DbProduct) error {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	if err := updateProduct(db, product); err != nil {
+		return skuError(classifyDbError(ctx, fmt.Errorf("failed to update a product %d: %w", product.ID, err), ResourceProduct, product.ID), product.Sku)
+	}
+	p.indexProduct(product)
 	return nil
 }
 
-// Delete a DbProduct by ID
-func (p *ProductService) DeleteProductByID(id uint64) error {
-	result := p.DB.Delete(&DbProduct{}, id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete a product %d: %w", id, result.Error)
+// updateProduct writes every column of product if it still has
+// product.Revision and increments the revision. The revision is kept when
+// nothing was written.
+func updateProduct(db DbWrapper, product *DbProduct) error {
+	expected := product.Revision
+	product.Revision = expected + 1
+	result := db.UpdateWhere(product, "revision = ?", expected)
+	err := result.Error
+	if err == nil && result.RowsAffected == 0 {
+		err = revisionError(db, product.ID, expected)
 	}
+	if err != nil {
+		product.Revision = expected
+	}
+	return err
+}
+
+// Delete a DbProduct by ID if it still has the given revision
+func (p *ProductService) DeleteProductByID(ctx context.Context, id uint64, revision uint64) error {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	if err := deleteProduct(db, id, revision); err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to delete a product %d: %w", id, err), ResourceProduct, id)
+	}
+	p.unindexProduct(id)
 	return nil
 }
 
+// deleteProduct deletes the product if it still has the given revision.
+func deleteProduct(db DbWrapper, id, revision uint64) error {
+	result := db.Delete(&DbProduct{ID: id}, "revision = ?", revision)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return revisionError(db, id, revision)
+	}
+	return result.Error
+}
+
+// revisionError explains why a write conditioned on the revision matched no
+// row: the product is missing or was changed by someone else.
+func revisionError(db DbWrapper, id, revision uint64) error {
+	product := DbProduct{}
+	if err := db.First(&product, id).Error; err != nil {
+		return err
+	}
+	return revisionConflict(id, product.Revision, revision)
+}
+
+func revisionConflict(id, current, expected uint64) *Error {
+	return ConflictError(ResourceProduct, id, fmt.Sprintf("product %d was changed, its revision is %d not %d", id, current, expected))
+}
+
+// unindexProduct removes a deleted product from the enabled in-memory indexes.
+func (p *ProductService) unindexProduct(id uint64) {
+	p.afterCommit(func() {
+		for _, index := range p.indexes() {
+			index.Remove(id)
+		}
+	})
+}
+
+// indexProduct puts a written product into the enabled in-memory indexes.
+func (p *ProductService) indexProduct(product *DbProduct) {
+	p.afterCommit(func() {
+		for _, index := range p.indexes() {
+			index.Put(product)
+		}
+	})
+}
+
+// indexes returns the enabled in-memory product indexes.
+func (p *ProductService) indexes() []productIndex {
+	var indexes []productIndex
+	if p.Index != nil {
+		indexes = append(indexes, p.Index)
+	}
+	if p.Suggestions != nil {
+		indexes = append(indexes, p.Suggestions)
+	}
+	return indexes
+}
+
 // Get all DbProducts
-func (p *ProductService) GetAllProducts() ([]*DbProduct, error) {
+func (p *ProductService) GetAllProducts(ctx context.Context) ([]*DbProduct, error) {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
 	var products []*DbProduct
-	result := p.DB.Find(&products)
+	result := db.Find(&products)
 	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get products: %w", result.Error)
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get products: %w", result.Error), ResourceProduct, nil)
 	}
 	return products, nil
 }
+
+// List a page of DbProducts using keyset pagination
+func (p *ProductService) ListProducts(ctx context.Context, query ListQuery) (*ListPage, error) {
+	if err := query.normalize(); err != nil {
+		return nil, err
+	}
+	cursor, err := query.decodeCursor()
+	if err != nil {
+		return nil, err
+	}
+	var cursorValue interface{}
+	if cursor != nil {
+		if cursorValue, err = query.cursorValue(cursor); err != nil {
+			return nil, InvalidArgumentError("invalid page token", FieldViolation{Field: "page_token", Description: "is malformed"})
+		}
+	}
+
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	var products []*DbProduct
+	result := db.Scopes(query.scope(cursor, cursorValue)).Find(&products)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to list products: %w", result.Error), ResourceProduct, nil)
+	}
+
+	page := &ListPage{Products: products}
+	if len(products) > query.PageSize {
+		page.Products = products[:query.PageSize]
+		if page.NextPageToken, err = query.encodeCursor(page.Products[query.PageSize-1]); err != nil {
+			return nil, fmt.Errorf("failed to encode page token: %w", err)
+		}
+	}
+	return page, nil
+}
+
+// Walk DbProducts after afterID in id order, reading batchSize rows at a time.
+// The walk stops as soon as ctx is done or fn returns an error. QueryTimeout
+// is not applied as the export is expected to outlive a single query.
+func (p *ProductService) ExportProducts(ctx context.Context, afterID uint64, batchSize int, fn func(*DbProduct) error) error {
+	if batchSize <= 0 {
+		batchSize = DefaultExportBatchSize
+	}
+	if batchSize > MaxExportBatchSize {
+		batchSize = MaxExportBatchSize
+	}
+	var batch []*DbProduct
+	result := p.DB.WithContext(ctx).Where("id > ?", afterID).FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		for _, product := range batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(product); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if result.Error != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to export products after %d: %w", afterID, result.Error), ResourceProduct, nil)
+	}
+	return nil
+}
+
+// Read all prices of a DbProduct, the base price first and the others by currency
+func (p *ProductService) GetProductPrices(ctx context.Context, id uint64) ([]Money, error) {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	product := DbProduct{}
+	if result := db.First(&product, id); result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get a product %d: %w", id, result.Error), ResourceProduct, id)
+	}
+	var rows []DbProductPrice
+	if result := db.Where("product_id = ?", id).Order("currency").Find(&rows); result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get prices of a product %d: %w", id, result.Error), ResourceProduct, id)
+	}
+	prices := []Money{product.Price}
+	for _, row := range rows {
+		prices = append(prices, row.Money())
+	}
+	return prices, nil
+}
+
+// Replace the prices of a DbProduct. The price in the base currency updates the
+// base price, which is kept when omitted; every other currency replaces the
+// existing additional prices. The product must still have revision.
+func (p *ProductService) SetProductPrices(ctx context.Context, id, revision uint64, prices []Money) error {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		product := DbProduct{}
+		if err := tx.First(&product, id).Error; err != nil {
+			return err
+		}
+		updates := map[string]interface{}{"revision": gorm.Expr("revision + 1"), "updated_at": time.Now()}
+		var rows []DbProductPrice
+		for _, price := range prices {
+			if price.Currency != product.Price.Currency {
+				rows = append(rows, DbProductPrice{ProductID: id, Currency: price.Currency, Amount: price.Amount})
+				continue
+			}
+			updates["price_amount"] = price.Amount
+		}
+		// Hooks are skipped as the SKU does not change.
+		result := tx.Scopes(withoutHooks).Model(&product).Where("revision = ?", revision).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return revisionConflict(id, product.Revision, revision)
+		}
+		if err := tx.Where("product_id = ?", id).Delete(&DbProductPrice{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to set prices of a product %d: %w", id, err), ResourceProduct, id)
+	}
+	return nil
+}
+
+// skuError makes a duplicate key error point at the SKU, the only unique
+// column a client can collide on.
+func skuError(err error, sku string) error {
+	var e *Error
+	if !errors.As(err, &e) || e.Kind != KindAlreadyExists {
+		return err
+	}
+	dup := *e
+	dup.ID = sku
+	dup.Message = fmt.Sprintf("product with sku %q already exists", sku)
+	return &dup
+}
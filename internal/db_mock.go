@@ -1,6 +1,10 @@
 package internal
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
@@ -9,6 +13,12 @@ type DbWrapperMock struct {
 	mock.Mock
 }
 
+// WithContext is not recorded: every ProductService call binds a context, and
+// expecting it in each test would only add noise.
+func (d *DbWrapperMock) WithContext(ctx context.Context) DbWrapper {
+	return d
+}
+
 func (d *DbWrapperMock) Create(value interface{}) *gorm.DB {
 	args := d.Called(value)
 	return args.Get(0).(*gorm.DB)
@@ -34,37 +44,422 @@ func (d *DbWrapperMock) Find(dest interface{}, conds ...interface{}) *gorm.DB {
 	return args.Get(0).(*gorm.DB)
 }
 
+func (d *DbWrapperMock) Scopes(funcs ...func(*gorm.DB) *gorm.DB) *gorm.DB {
+	args := d.Called(funcs)
+	return args.Get(0).(*gorm.DB)
+}
+
+func (d *DbWrapperMock) Where(query interface{}, args ...interface{}) *gorm.DB {
+	called := d.Called(query, args)
+	return called.Get(0).(*gorm.DB)
+}
+
+func (d *DbWrapperMock) UpdateWhere(value interface{}, query interface{}, args ...interface{}) *gorm.DB {
+	called := d.Called(value, query, args)
+	return called.Get(0).(*gorm.DB)
+}
+
+// Transaction runs fc on the mock, so the statements of the transaction are
+// expected like any other. An error returned by the expectation fails the
+// transaction before fc runs.
+func (d *DbWrapperMock) Transaction(fc func(tx DbWrapper) error, opts ...*sql.TxOptions) error {
+	if err := d.Called(opts).Error(0); err != nil {
+		return err
+	}
+	return fc(d)
+}
+
+func (d *DbWrapperMock) Begin(opts ...*sql.TxOptions) (Tx, error) {
+	args := d.Called(opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(Tx), args.Error(1)
+}
+
+// TxMock is a Tx whose statements are recorded like those of DbWrapperMock.
+type TxMock struct {
+	DbWrapperMock
+}
+
+func (t *TxMock) WithContext(ctx context.Context) DbWrapper {
+	return t
+}
+
+func (t *TxMock) Commit() error {
+	args := t.Called()
+	return args.Error(0)
+}
+
+func (t *TxMock) Rollback() error {
+	args := t.Called()
+	return args.Error(0)
+}
+
 type ProductServiceMock struct {
 	mock.Mock
 }
 
-func (p *ProductServiceMock) CreateProduct(product *DbProduct) (uint64, error) {
-	args := p.Called(product)
+func (p *ProductServiceMock) CreateProduct(ctx context.Context, product *DbProduct) (uint64, error) {
+	args := p.Called(ctx, product)
 	return args.Get(0).(uint64), args.Error(1)
 }
 
-func (p *ProductServiceMock) GetProductByID(id uint64) (*DbProduct, error) {
-	args := p.Called(id)
+func (p *ProductServiceMock) CreateProductOnce(ctx context.Context, key IdempotencyKey, product *DbProduct) (uint64, bool, error) {
+	args := p.Called(ctx, key, product)
+	return args.Get(0).(uint64), args.Bool(1), args.Error(2)
+}
+
+// InTransaction records the call and, unless it returns an error, runs fn
+// against the mock itself so the calls of fn are expected as usual.
+func (p *ProductServiceMock) InTransaction(ctx context.Context, fn func(tx ProductServiceInterface) error) error {
+	args := p.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(p)
+}
+
+func (p *ProductServiceMock) GetProductByID(ctx context.Context, id uint64) (*DbProduct, error) {
+	args := p.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DbProduct), args.Error(1)
+}
+
+func (p *ProductServiceMock) GetProductBySKU(ctx context.Context, sku string) (*DbProduct, error) {
+	args := p.Called(ctx, sku)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*DbProduct), args.Error(1)
 }
 
-func (p *ProductServiceMock) UpdateProduct(product *DbProduct) error {
-	args := p.Called(product)
+func (p *ProductServiceMock) GetProductsByIDs(ctx context.Context, ids []uint64) ([]*DbProduct, []uint64, error) {
+	args := p.Called(ctx, ids)
+	products, _ := args.Get(0).([]*DbProduct)
+	missing, _ := args.Get(1).([]uint64)
+	return products, missing, args.Error(2)
+}
+
+func (p *ProductServiceMock) UpdateProduct(ctx context.Context, product *DbProduct) error {
+	args := p.Called(ctx, product)
 	return args.Error(0)
 }
 
-func (p *ProductServiceMock) DeleteProductByID(id uint64) error {
-	args := p.Called(id)
+func (p *ProductServiceMock) PatchProduct(ctx context.Context, id uint64, patch *DbProduct, fields []ProductField) (*DbProduct, error) {
+	args := p.Called(ctx, id, patch, fields)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DbProduct), args.Error(1)
+}
+
+func (p *ProductServiceMock) DeleteProductByID(ctx context.Context, id uint64, revision uint64) error {
+	args := p.Called(ctx, id, revision)
 	return args.Error(0)
 }
 
-func (p *ProductServiceMock) GetAllProducts() ([]*DbProduct, error) {
-	args := p.Called()
+func (p *ProductServiceMock) BatchCreateProducts(ctx context.Context, products []*DbProduct, mode BatchMode) ([]BatchResult, error) {
+	args := p.Called(ctx, products, mode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]BatchResult), args.Error(1)
+}
+
+func (p *ProductServiceMock) BatchUpdateProducts(ctx context.Context, products []*DbProduct, mode BatchMode) ([]BatchResult, error) {
+	args := p.Called(ctx, products, mode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]BatchResult), args.Error(1)
+}
+
+func (p *ProductServiceMock) BatchDeleteProducts(ctx context.Context, products []ProductRevision, mode BatchMode) ([]BatchResult, error) {
+	args := p.Called(ctx, products, mode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]BatchResult), args.Error(1)
+}
+
+func (p *ProductServiceMock) GetAllProducts(ctx context.Context) ([]*DbProduct, error) {
+	args := p.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*DbProduct), args.Error(1)
 }
+
+func (p *ProductServiceMock) ListProducts(ctx context.Context, query ListQuery) (*ListPage, error) {
+	args := p.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ListPage), args.Error(1)
+}
+
+func (p *ProductServiceMock) FacetProducts(ctx context.Context, query FacetQuery) (*FacetPage, error) {
+	args := p.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*FacetPage), args.Error(1)
+}
+
+func (p *ProductServiceMock) SearchProducts(ctx context.Context, query SearchQuery) (*SearchPage, error) {
+	args := p.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SearchPage), args.Error(1)
+}
+
+func (p *ProductServiceMock) RebuildSearchIndex(ctx context.Context) (int, error) {
+	args := p.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (p *ProductServiceMock) SuggestProducts(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	args := p.Called(ctx, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Suggestion), args.Error(1)
+}
+
+func (p *ProductServiceMock) ExportProducts(ctx context.Context, afterID uint64, batchSize int, fn func(*DbProduct) error) error {
+	args := p.Called(ctx, afterID, batchSize, fn)
+	if products, ok := args.Get(0).([]*DbProduct); ok {
+		for _, product := range products {
+			if err := fn(product); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (p *ProductServiceMock) GetProductPrices(ctx context.Context, id uint64) ([]Money, error) {
+	args := p.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Money), args.Error(1)
+}
+
+func (p *ProductServiceMock) SetProductPrices(ctx context.Context, id, revision uint64, prices []Money) error {
+	args := p.Called(ctx, id, revision, prices)
+	return args.Error(0)
+}
+
+func (p *ProductServiceMock) SetProductLifecycle(ctx context.Context, id, revision uint64, status ProductStatus, visibility ProductVisibility) error {
+	args := p.Called(ctx, id, revision, status, visibility)
+	return args.Error(0)
+}
+
+func (p *ProductServiceMock) SetProductAvailability(ctx context.Context, id, revision uint64, from, until *time.Time) error {
+	args := p.Called(ctx, id, revision, from, until)
+	return args.Error(0)
+}
+
+type VariantServiceMock struct {
+	mock.Mock
+}
+
+func (v *VariantServiceMock) SetProductOptions(ctx context.Context, productID uint64, options []DbProductOption) error {
+	args := v.Called(ctx, productID, options)
+	return args.Error(0)
+}
+
+func (v *VariantServiceMock) CreateVariant(ctx context.Context, variant *DbVariant) (uint64, error) {
+	args := v.Called(ctx, variant)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (v *VariantServiceMock) GetVariantByID(ctx context.Context, id uint64) (*DbVariant, error) {
+	args := v.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DbVariant), args.Error(1)
+}
+
+func (v *VariantServiceMock) UpdateVariant(ctx context.Context, variant *DbVariant) error {
+	args := v.Called(ctx, variant)
+	return args.Error(0)
+}
+
+func (v *VariantServiceMock) DeleteVariantByID(ctx context.Context, id uint64) error {
+	args := v.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (v *VariantServiceMock) GetProductVariants(ctx context.Context, productID uint64) (*ProductVariants, error) {
+	args := v.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ProductVariants), args.Error(1)
+}
+
+type CategoryServiceMock struct {
+	mock.Mock
+}
+
+func (c *CategoryServiceMock) CreateCategory(ctx context.Context, category *DbCategory) (uint64, error) {
+	args := c.Called(ctx, category)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (c *CategoryServiceMock) GetCategoryByID(ctx context.Context, id uint64) (*DbCategory, error) {
+	args := c.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DbCategory), args.Error(1)
+}
+
+func (c *CategoryServiceMock) UpdateCategory(ctx context.Context, category *DbCategory) error {
+	args := c.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (c *CategoryServiceMock) MoveCategory(ctx context.Context, id uint64, parentID *uint64, position int) error {
+	args := c.Called(ctx, id, parentID, position)
+	return args.Error(0)
+}
+
+func (c *CategoryServiceMock) DeleteCategoryByID(ctx context.Context, id uint64) error {
+	args := c.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (c *CategoryServiceMock) GetCategoryTree(ctx context.Context) ([]*CategoryNode, error) {
+	args := c.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*CategoryNode), args.Error(1)
+}
+
+func (c *CategoryServiceMock) AssignProducts(ctx context.Context, categoryID uint64, productIDs []uint64) error {
+	args := c.Called(ctx, categoryID, productIDs)
+	return args.Error(0)
+}
+
+func (c *CategoryServiceMock) UnassignProducts(ctx context.Context, categoryID uint64, productIDs []uint64) error {
+	args := c.Called(ctx, categoryID, productIDs)
+	return args.Error(0)
+}
+
+type AttributeServiceMock struct {
+	mock.Mock
+}
+
+func (a *AttributeServiceMock) CreateAttribute(ctx context.Context, attribute *DbAttribute) (uint64, error) {
+	args := a.Called(ctx, attribute)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (a *AttributeServiceMock) GetAttributeByID(ctx context.Context, id uint64) (*DbAttribute, error) {
+	args := a.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DbAttribute), args.Error(1)
+}
+
+func (a *AttributeServiceMock) UpdateAttribute(ctx context.Context, attribute *DbAttribute) error {
+	args := a.Called(ctx, attribute)
+	return args.Error(0)
+}
+
+func (a *AttributeServiceMock) ListAttributes(ctx context.Context) ([]*DbAttribute, error) {
+	args := a.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*DbAttribute), args.Error(1)
+}
+
+func (a *AttributeServiceMock) CreateAttributeSet(ctx context.Context, set *DbAttributeSet) (uint64, error) {
+	args := a.Called(ctx, set)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (a *AttributeServiceMock) GetAttributeSetByID(ctx context.Context, id uint64) (*DbAttributeSet, error) {
+	args := a.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DbAttributeSet), args.Error(1)
+}
+
+func (a *AttributeServiceMock) UpdateAttributeSet(ctx context.Context, set *DbAttributeSet) error {
+	args := a.Called(ctx, set)
+	return args.Error(0)
+}
+
+func (a *AttributeServiceMock) SetProductAttributes(ctx context.Context, attributes *ProductAttributes) error {
+	args := a.Called(ctx, attributes)
+	return args.Error(0)
+}
+
+func (a *AttributeServiceMock) GetProductAttributes(ctx context.Context, productID uint64) (*ProductAttributes, error) {
+	args := a.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ProductAttributes), args.Error(1)
+}
+
+type DictionaryServiceMock struct {
+	mock.Mock
+}
+
+func (d *DictionaryServiceMock) CreateSynonymSet(ctx context.Context, set *DbSynonymSet) (uint64, error) {
+	args := d.Called(ctx, set)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (d *DictionaryServiceMock) GetSynonymSetByID(ctx context.Context, id uint64) (*DbSynonymSet, error) {
+	args := d.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DbSynonymSet), args.Error(1)
+}
+
+func (d *DictionaryServiceMock) UpdateSynonymSet(ctx context.Context, set *DbSynonymSet) error {
+	args := d.Called(ctx, set)
+	return args.Error(0)
+}
+
+func (d *DictionaryServiceMock) DeleteSynonymSetByID(ctx context.Context, id uint64) error {
+	args := d.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (d *DictionaryServiceMock) ListSynonymSets(ctx context.Context) ([]*DbSynonymSet, error) {
+	args := d.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*DbSynonymSet), args.Error(1)
+}
+
+func (d *DictionaryServiceMock) GetStopWords(ctx context.Context) ([]string, error) {
+	args := d.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (d *DictionaryServiceMock) SetStopWords(ctx context.Context, words []string) error {
+	args := d.Called(ctx, words)
+	return args.Error(0)
+}
@@ -1,10 +1,20 @@
 package internal
 
 import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
@@ -12,10 +22,11 @@ func TestTestProductService_CreateProduct(t *testing.T) {
 	// given
 	productId := uint64(1)
 	tests := []struct {
-		name    string
-		product DbProduct
-		setup   func(p *DbProduct) *ProductService
-		wantErr bool
+		name     string
+		product  DbProduct
+		setup    func(p *DbProduct) *ProductService
+		wantErr  bool
+		wantKind ErrorKind
 	}{
 		{
 			name: "Create a new product",
@@ -23,7 +34,7 @@ func TestTestProductService_CreateProduct(t *testing.T) {
 				Name:        "Test Product",
 				Sku:         "test-sku",
 				Description: "Test Description",
-				Price:       10.0,
+				Price:       Money{Amount: 1000, Currency: "USD"},
 				Image:       "test.jpg",
 				ID:          productId, //have to set it manually
 			},
@@ -49,10 +60,11 @@ func TestTestProductService_CreateProduct(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ps := tt.setup(&tt.product)
 			//when
-			id, err := ps.CreateProduct(&tt.product)
+			id, err := ps.CreateProduct(context.Background(), &tt.product)
 			//then
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Equal(t, tt.wantKind, KindOf(err))
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, productId, id)
@@ -62,6 +74,37 @@ func TestTestProductService_CreateProduct(t *testing.T) {
 	}
 }
 
+func TestProductService_CreateProductSku(t *testing.T) {
+	t.Run("SKU of an existing product", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_products`")).
+			WillReturnError(&mysql.MySQLError{Number: mysqlErrDuplicateEntry, Message: "Duplicate entry 'MG-1' for key 'idx_product_active_sku'"})
+		sqlMock.ExpectRollback()
+		//when
+		_, err := ps.CreateProduct(context.Background(), &DbProduct{Name: "Coffee Mug", Sku: "MG-1"})
+		//then
+		require.Equal(t, KindAlreadyExists, KindOf(err))
+		assert.Equal(t, `product with sku "MG-1" already exists`, err.(*Error).PublicMessage())
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Wrapped duplicate is not changed in place", func(t *testing.T) {
+		// given
+		duplicate := AlreadyExistsError(ResourceProduct, "")
+		//when
+		err := skuError(fmt.Errorf("failed to create a product: %w", duplicate), "MG-1")
+		//then
+		require.Equal(t, KindAlreadyExists, KindOf(err))
+		assert.Equal(t, `product with sku "MG-1" already exists`, err.(*Error).PublicMessage())
+		assert.Empty(t, duplicate.Message)
+	})
+}
+
 func TestProductService_GetProductByID(t *testing.T) {
 	// given
 	productID := uint64(1)
@@ -103,10 +146,11 @@ func TestProductService_GetProductByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			//when
 			ps := tt.setup(tt.id)
-			product, err := ps.GetProductByID(tt.id)
+			product, err := ps.GetProductByID(context.Background(), tt.id)
 			//then
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Equal(t, KindNotFound, KindOf(err))
 			} else {
 				assert.Equal(t, &tt.product, product)
 				assert.NoError(t, err)
@@ -115,34 +159,112 @@ func TestProductService_GetProductByID(t *testing.T) {
 	}
 }
 
-func TestProductService_UpdateProduct(t *testing.T) {
+func TestProductService_GetProductBySKU(t *testing.T) {
 	// given
-	productID := uint64(1)
+	sku := "test-sku"
 	tests := []struct {
 		name    string
 		product DbProduct
-		setup   func(p *DbProduct) *ProductService
+		setup   func(sku string) *ProductService
 		wantErr bool
+	}{
+		{
+			name:    "Fetch existing product",
+			product: DbProduct{ID: 1, Sku: sku},
+			setup: func(sku string) *ProductService {
+				dbWrapper := new(DbWrapperMock)
+				dbWrapper.On("First", &DbProduct{}, []interface{}{"sku = ?", sku}).Run(func(args mock.Arguments) {
+					arg := args.Get(0).(*DbProduct)
+					arg.ID = 1
+					arg.Sku = sku
+				}).Return(&gorm.DB{}).Once()
+				return &ProductService{DB: dbWrapper}
+			},
+			wantErr: false,
+		},
+		{
+			name: "Fetch non-existing product",
+			setup: func(sku string) *ProductService {
+				dbWrapper := new(DbWrapperMock)
+				dbWrapper.On("First", &DbProduct{}, []interface{}{"sku = ?", sku}).Return(&gorm.DB{Error: gorm.ErrRecordNotFound}).Once()
+				return &ProductService{DB: dbWrapper}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			ps := tt.setup(sku)
+			product, err := ps.GetProductBySKU(context.Background(), sku)
+			//then
+			if tt.wantErr {
+				assert.Equal(t, KindNotFound, KindOf(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &tt.product, product)
+			}
+		})
+	}
+}
+
+func TestProductService_UpdateProduct(t *testing.T) {
+	// given
+	productID := uint64(1)
+	tests := []struct {
+		name     string
+		product  DbProduct
+		setup    func(p *DbProduct) *ProductService
+		wantErr  bool
+		wantKind ErrorKind
 	}{
 		{
 			name:    "Update existing product",
-			product: DbProduct{ID: productID, Name: "Updated Product"},
+			product: DbProduct{ID: productID, Name: "Updated Product", Revision: 3},
 			setup: func(p *DbProduct) *ProductService {
 				dbWrapper := new(DbWrapperMock)
-				dbWrapper.On("Save", p).Return(&gorm.DB{}).Once()
+				dbWrapper.On("UpdateWhere", p, "revision = ?", []interface{}{uint64(3)}).Return(&gorm.DB{RowsAffected: 1}).Once()
 				return &ProductService{DB: dbWrapper}
 			},
 			wantErr: false,
 		},
+		{
+			name:    "Update product changed in the meantime",
+			product: DbProduct{ID: productID, Name: "Updated Product", Revision: 3},
+			setup: func(p *DbProduct) *ProductService {
+				dbWrapper := new(DbWrapperMock)
+				dbWrapper.On("UpdateWhere", p, "revision = ?", []interface{}{uint64(3)}).Return(&gorm.DB{}).Once()
+				dbWrapper.On("First", &DbProduct{}, []interface{}{p.ID}).Run(func(args mock.Arguments) {
+					args.Get(0).(*DbProduct).Revision = 5
+				}).Return(&gorm.DB{}).Once()
+				return &ProductService{DB: dbWrapper}
+			},
+			wantErr:  true,
+			wantKind: KindConflict,
+		},
 		{
 			name:    "Update non-existing product",
-			product: DbProduct{ID: productID, Name: "Updated Product"},
+			product: DbProduct{ID: productID, Name: "Updated Product", Revision: 3},
 			setup: func(p *DbProduct) *ProductService {
 				dbWrapper := new(DbWrapperMock)
-				dbWrapper.On("Save", p).Return(&gorm.DB{Error: gorm.ErrRecordNotFound}).Once()
+				dbWrapper.On("UpdateWhere", p, "revision = ?", []interface{}{uint64(3)}).Return(&gorm.DB{}).Once()
+				dbWrapper.On("First", &DbProduct{}, []interface{}{p.ID}).Return(&gorm.DB{Error: gorm.ErrRecordNotFound}).Once()
 				return &ProductService{DB: dbWrapper}
 			},
-			wantErr: true,
+			wantErr:  true,
+			wantKind: KindNotFound,
+		},
+		{
+			name:    "Update product while the database is down",
+			product: DbProduct{ID: productID, Name: "Updated Product", Revision: 3},
+			setup: func(p *DbProduct) *ProductService {
+				dbWrapper := new(DbWrapperMock)
+				dbWrapper.On("UpdateWhere", p, "revision = ?", []interface{}{uint64(3)}).Return(&gorm.DB{Error: driver.ErrBadConn}).Once()
+				return &ProductService{DB: dbWrapper}
+			},
+			wantErr:  true,
+			wantKind: KindUnavailable,
 		},
 	}
 
@@ -150,13 +272,17 @@ func TestProductService_UpdateProduct(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			//when
 			ps := tt.setup(&tt.product)
-			err := ps.UpdateProduct(&tt.product)
+			err := ps.UpdateProduct(context.Background(), &tt.product)
 			//then
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Equal(t, tt.wantKind, KindOf(err))
+				assert.Equal(t, uint64(3), tt.product.Revision)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, uint64(4), tt.product.Revision)
 			}
+			ps.DB.(*DbWrapperMock).AssertExpectations(t)
 		})
 	}
 }
@@ -169,26 +295,54 @@ func TestProductService_DeleteProductByID(t *testing.T) {
 		productID uint64
 		setup     func(id uint64) *ProductService
 		wantErr   bool
+		wantKind  ErrorKind
 	}{
 		{
 			name:      "Delete existing product",
 			productID: productID,
 			setup: func(id uint64) *ProductService {
 				dbWrapper := new(DbWrapperMock)
-				dbWrapper.On("Delete", &DbProduct{}, []interface{}{id}).Return(&gorm.DB{}).Once()
+				dbWrapper.On("Delete", &DbProduct{ID: id}, []interface{}{"revision = ?", uint64(2)}).Return(&gorm.DB{RowsAffected: 1}).Once()
 				return &ProductService{DB: dbWrapper}
 			},
 			wantErr: false,
 		},
+		{
+			name:      "Delete product changed in the meantime",
+			productID: productID,
+			setup: func(id uint64) *ProductService {
+				dbWrapper := new(DbWrapperMock)
+				dbWrapper.On("Delete", &DbProduct{ID: id}, []interface{}{"revision = ?", uint64(2)}).Return(&gorm.DB{}).Once()
+				dbWrapper.On("First", &DbProduct{}, []interface{}{id}).Run(func(args mock.Arguments) {
+					args.Get(0).(*DbProduct).Revision = 3
+				}).Return(&gorm.DB{}).Once()
+				return &ProductService{DB: dbWrapper}
+			},
+			wantErr:  true,
+			wantKind: KindConflict,
+		},
 		{
 			name:      "Delete non-existing product",
 			productID: productID,
 			setup: func(id uint64) *ProductService {
 				dbWrapper := new(DbWrapperMock)
-				dbWrapper.On("Delete", &DbProduct{}, []interface{}{id}).Return(&gorm.DB{Error: gorm.ErrRecordNotFound}).Once()
+				dbWrapper.On("Delete", &DbProduct{ID: id}, []interface{}{"revision = ?", uint64(2)}).Return(&gorm.DB{}).Once()
+				dbWrapper.On("First", &DbProduct{}, []interface{}{id}).Return(&gorm.DB{Error: gorm.ErrRecordNotFound}).Once()
 				return &ProductService{DB: dbWrapper}
 			},
-			wantErr: true,
+			wantErr:  true,
+			wantKind: KindNotFound,
+		},
+		{
+			name:      "Delete product while the database is down",
+			productID: productID,
+			setup: func(id uint64) *ProductService {
+				dbWrapper := new(DbWrapperMock)
+				dbWrapper.On("Delete", &DbProduct{ID: id}, []interface{}{"revision = ?", uint64(2)}).Return(&gorm.DB{Error: driver.ErrBadConn}).Once()
+				return &ProductService{DB: dbWrapper}
+			},
+			wantErr:  true,
+			wantKind: KindUnavailable,
 		},
 	}
 
@@ -196,13 +350,164 @@ func TestProductService_DeleteProductByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			//when
 			ps := tt.setup(tt.productID)
-			err := ps.DeleteProductByID(tt.productID)
+			err := ps.DeleteProductByID(context.Background(), tt.productID, 2)
+			//then
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantKind, KindOf(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			ps.DB.(*DbWrapperMock).AssertExpectations(t)
+		})
+	}
+}
+
+func TestProductService_UpdateProductStatements(t *testing.T) {
+	// given
+	tests := []struct {
+		name     string
+		setup    func(sqlMock sqlmock.Sqlmock)
+		wantErr  bool
+		wantKind ErrorKind
+	}{
+		{
+			name: "Update existing product",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET")).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectCommit()
+			},
+		},
+		{
+			name: "Update product changed in the meantime",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				sqlMock.ExpectCommit()
+				sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ?")).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "revision"}).AddRow(1, 4))
+			},
+			wantErr:  true,
+			wantKind: KindConflict,
+		},
+		{
+			name: "Update non-existing product",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				sqlMock.ExpectCommit()
+				sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ?")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantErr:  true,
+			wantKind: KindNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqlMock := newSqlMockDB(t)
+			tt.setup(sqlMock)
+			ps := &ProductService{DB: GormWrapper{DB: db}}
+			product := &DbProduct{ID: 1, Name: "Updated Product", Sku: "updated", Revision: 3}
+			//when
+			err := ps.UpdateProduct(context.Background(), product)
+			//then
+			if tt.wantErr {
+				assert.Equal(t, tt.wantKind, KindOf(err))
+				assert.Equal(t, uint64(3), product.Revision)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(4), product.Revision)
+			}
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductService_DeleteProductByIDStatements(t *testing.T) {
+	// given
+	tests := []struct {
+		name     string
+		setup    func(sqlMock sqlmock.Sqlmock)
+		wantErr  bool
+		wantKind ErrorKind
+	}{
+		{
+			name: "Delete existing product",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `deleted_at`=? WHERE revision = ? AND `catalog_products`.`id` = ? AND `catalog_products`.`deleted_at` IS NULL")).
+					WithArgs(sqlmock.AnyArg(), 2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectCommit()
+			},
+		},
+		{
+			name: "Delete product changed in the meantime",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `deleted_at`=?")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				sqlMock.ExpectCommit()
+				sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "revision"}).AddRow(1, 3))
+			},
+			wantErr:  true,
+			wantKind: KindConflict,
+		},
+		{
+			name: "Delete non-existing product",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `deleted_at`=?")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				sqlMock.ExpectCommit()
+				sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantErr:  true,
+			wantKind: KindNotFound,
+		},
+		{
+			name: "Delete product while the database is down",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `deleted_at`=?")).
+					WillReturnError(driver.ErrBadConn)
+				sqlMock.ExpectRollback()
+			},
+			wantErr:  true,
+			wantKind: KindUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, sqlMock := newSqlMockDB(t)
+			tt.setup(sqlMock)
+			ps := &ProductService{DB: GormWrapper{DB: db}}
+			//when
+			err := ps.DeleteProductByID(context.Background(), 1, 2)
 			//then
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Equal(t, tt.wantKind, KindOf(err))
 			} else {
 				assert.NoError(t, err)
 			}
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
 		})
 	}
 }
@@ -246,7 +551,7 @@ func TestProductService_GetAllProducts(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			//when
 			ps := tt.setup(tt.products)
-			products, err := ps.GetAllProducts()
+			products, err := ps.GetAllProducts(context.Background())
 			//then
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -259,6 +564,159 @@ func TestProductService_GetAllProducts(t *testing.T) {
 	}
 }
 
+func TestProductService_ExportProducts(t *testing.T) {
+	t.Run("Export in batches after the given id", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE id > ? AND `catalog_products`.`deleted_at` IS NULL ORDER BY `catalog_products`.`id` LIMIT ?")).
+			WithArgs(10, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11).AddRow(12))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE id > ? AND `catalog_products`.`id` > ? AND `catalog_products`.`deleted_at` IS NULL ORDER BY `catalog_products`.`id` LIMIT ?")).
+			WithArgs(10, 12, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(13))
+		var ids []uint64
+		//when
+		err := ps.ExportProducts(context.Background(), 10, 2, func(product *DbProduct) error {
+			ids = append(ids, product.ID)
+			return nil
+		})
+		//then
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{11, 12, 13}, ids)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Export stops when the client goes away", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		var ids []uint64
+		//when
+		err := ps.ExportProducts(ctx, 0, 2, func(product *DbProduct) error {
+			ids = append(ids, product.ID)
+			cancel()
+			return nil
+		})
+		//then
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []uint64{1}, ids)
+	})
+}
+
+func TestProductService_GetProductPrices(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	ps := &ProductService{DB: GormWrapper{DB: db}}
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ? AND `catalog_products`.`deleted_at` IS NULL ORDER BY `catalog_products`.`id` LIMIT ?")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency"}).AddRow(1, 1999, "USD"))
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_product_prices` WHERE product_id = ? ORDER BY currency")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "currency", "amount"}).AddRow(1, 1, "EUR", 1849).AddRow(2, 1, "JPY", 3200))
+	//when
+	prices, err := ps.GetProductPrices(context.Background(), 1)
+	//then
+	require.NoError(t, err)
+	assert.Equal(t, []Money{{Amount: 1999, Currency: "USD"}, {Amount: 1849, Currency: "EUR"}, {Amount: 3200, Currency: "JPY"}}, prices)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestProductService_SetProductPrices(t *testing.T) {
+	t.Run("Base price is updated and other prices replaced", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ?")).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency"}).AddRow(1, 1999, "USD"))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `price_amount`=?,`revision`=revision + 1,`updated_at`=?")).
+			WithArgs(1899, sqlmock.AnyArg(), 1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_product_prices` WHERE product_id = ?")).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_product_prices` (`product_id`,`currency`,`amount`) VALUES (?,?,?)")).
+			WithArgs(1, "EUR", 1749).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		sqlMock.ExpectCommit()
+		//when
+		err := ps.SetProductPrices(context.Background(), 1, 1, []Money{{Amount: 1899, Currency: "USD"}, {Amount: 1749, Currency: "EUR"}})
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown product rolls back", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		sqlMock.ExpectRollback()
+		//when
+		err := ps.SetProductPrices(context.Background(), 1, 1, []Money{{Amount: 1749, Currency: "EUR"}})
+		//then
+		assert.Equal(t, KindNotFound, KindOf(err))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Changed product is not written", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency", "revision"}).AddRow(1, 1999, "USD", 3))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `revision`=revision + 1,`updated_at`=? WHERE revision = ?")).
+			WithArgs(sqlmock.AnyArg(), 2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectRollback()
+		//when
+		err := ps.SetProductPrices(context.Background(), 1, 2, []Money{{Amount: 1749, Currency: "EUR"}})
+		//then
+		assert.Equal(t, KindConflict, KindOf(err))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestProductService_QueryTimeout(t *testing.T) {
+	t.Run("Slow query is aborted after QueryTimeout", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, QueryTimeout: 20 * time.Millisecond}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillDelayFor(time.Second).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		//when
+		_, err := ps.GetProductByID(context.Background(), 1)
+		//then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(toStatus(err)))
+	})
+
+	t.Run("Canceled request is reported as such", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, QueryTimeout: time.Second}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		//when
+		_, err := ps.GetAllProducts(ctx)
+		//then
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, codes.Canceled, status.Code(toStatus(err)))
+	})
+}
+
 func TestDbProduct_TableName(t *testing.T) {
 	// given
 	dbProduct := DbProduct{}
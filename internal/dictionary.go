@@ -0,0 +1,328 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SynonymType tells in which direction the terms of a synonym set match.
+type SynonymType string
+
+const (
+	// SynonymEquivalent makes every term of the set match all others.
+	SynonymEquivalent SynonymType = "equivalent"
+	// SynonymOneWay makes the input also match the terms but not vice versa.
+	SynonymOneWay SynonymType = "one_way"
+)
+
+// maxSynonymWords is the longest term of a synonym set in words.
+const maxSynonymWords = 3
+
+// DbSynonymSet is a group of search terms that match each other, e.g.
+// "tee" and "t-shirt".
+type DbSynonymSet struct {
+	ID        uint64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Type      SynonymType `gorm:"size:16"`
+	// Input is the term expanded by a one-way set, empty for equivalent sets.
+	Input string   `gorm:"size:255"`
+	Terms []string `gorm:"serializer:json"`
+}
+
+func (DbSynonymSet) TableName() string {
+	return "catalog_synonym_sets"
+}
+
+// DbStopWord is a word left out of search queries, such as "the".
+type DbStopWord struct {
+	Word string `gorm:"size:64;primaryKey"`
+}
+
+func (DbStopWord) TableName() string {
+	return "catalog_stop_words"
+}
+
+// SearchDictionary holds the synonyms and stop words applied to search
+// queries. It is immutable once built.
+type SearchDictionary struct {
+	stopWords map[string]bool
+	// synonyms maps the stemmed words of a term to the phrases it also matches.
+	synonyms map[string][][]string
+}
+
+// queryUnit is a query word, or the words of a synonym term, matched
+// together with the phrases it is a synonym of.
+type queryUnit struct {
+	words        []string
+	alternatives [][]string
+}
+
+func NewSearchDictionary(sets []*DbSynonymSet, stopWords []string) *SearchDictionary {
+	d := &SearchDictionary{stopWords: map[string]bool{}, synonyms: map[string][][]string{}}
+	for _, word := range stopWords {
+		for _, token := range tokenize(word) {
+			d.stopWords[token] = true
+		}
+	}
+	for _, set := range sets {
+		terms := make([][]string, 0, len(set.Terms))
+		for _, term := range set.Terms {
+			if words := tokenize(term); len(words) > 0 {
+				terms = append(terms, words)
+			}
+		}
+		switch set.Type {
+		case SynonymEquivalent:
+			for i, term := range terms {
+				for j, other := range terms {
+					if i != j {
+						d.addSynonym(term, other)
+					}
+				}
+			}
+		case SynonymOneWay:
+			if input := tokenize(set.Input); len(input) > 0 {
+				for _, term := range terms {
+					d.addSynonym(input, term)
+				}
+			}
+		}
+	}
+	return d
+}
+
+func (d *SearchDictionary) addSynonym(term, phrase []string) {
+	key := synonymKey(term)
+	if key == synonymKey(phrase) {
+		return
+	}
+	for _, existing := range d.synonyms[key] {
+		if synonymKey(existing) == synonymKey(phrase) {
+			return
+		}
+	}
+	d.synonyms[key] = append(d.synonyms[key], phrase)
+}
+
+// synonymKey matches terms after stemming, so "tees" finds the synonyms of "tee".
+func synonymKey(words []string) string {
+	stems := make([]string, len(words))
+	for i, word := range words {
+		stems[i] = stem(word)
+	}
+	return strings.Join(stems, " ")
+}
+
+// analyze groups the query words into units, preferring the longest synonym
+// terms, and drops stop words unless the query has nothing else.
+func (d *SearchDictionary) analyze(words []string) []queryUnit {
+	if d == nil {
+		units := make([]queryUnit, len(words))
+		for i, word := range words {
+			units[i] = queryUnit{words: []string{word}}
+		}
+		return units
+	}
+	var units, stopped []queryUnit
+	for i := 0; i < len(words); {
+		unit := queryUnit{words: words[i : i+1]}
+		for n := min(maxSynonymWords, len(words)-i); n > 0; n-- {
+			if alternatives, ok := d.synonyms[synonymKey(words[i:i+n])]; ok {
+				unit = queryUnit{words: words[i : i+n], alternatives: alternatives}
+				break
+			}
+		}
+		i += len(unit.words)
+		if len(unit.alternatives) == 0 && d.stopWords[unit.words[0]] {
+			stopped = append(stopped, unit)
+			continue
+		}
+		units = append(units, unit)
+	}
+	if len(units) == 0 {
+		return stopped
+	}
+	return units
+}
+
+type DictionaryServiceInterface interface {
+	CreateSynonymSet(ctx context.Context, set *DbSynonymSet) (uint64, error)
+	GetSynonymSetByID(ctx context.Context, id uint64) (*DbSynonymSet, error)
+	UpdateSynonymSet(ctx context.Context, set *DbSynonymSet) error
+	DeleteSynonymSetByID(ctx context.Context, id uint64) error
+	ListSynonymSets(ctx context.Context) ([]*DbSynonymSet, error)
+	GetStopWords(ctx context.Context) ([]string, error)
+	SetStopWords(ctx context.Context, words []string) error
+}
+
+// DictionaryService manages synonyms and stop words and publishes them to
+// the search index. Writes are applied right away, Run picks up changes made
+// by other instances.
+type DictionaryService struct {
+	DB DbWrapper
+	// QueryTimeout bounds every single query, zero disables the limit.
+	QueryTimeout time.Duration
+	Index        *SearchIndex
+	// RefreshInterval is how often Run reloads the dictionary.
+	RefreshInterval time.Duration
+}
+
+// Create a new DbSynonymSet
+func (d *DictionaryService) CreateSynonymSet(ctx context.Context, set *DbSynonymSet) (uint64, error) {
+	db, ctx, cancel := bindDB(ctx, d.DB, d.QueryTimeout)
+	defer cancel()
+	result := db.Create(set)
+	if result.Error != nil {
+		return ErrorId, classifyDbError(ctx, fmt.Errorf("failed to create a synonym set: %w", result.Error), ResourceSynonymSet, nil)
+	}
+	d.refresh(ctx)
+	return set.ID, nil
+}
+
+// Read a DbSynonymSet by ID
+func (d *DictionaryService) GetSynonymSetByID(ctx context.Context, id uint64) (*DbSynonymSet, error) {
+	db, ctx, cancel := bindDB(ctx, d.DB, d.QueryTimeout)
+	defer cancel()
+	set := DbSynonymSet{}
+	result := db.First(&set, id)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get a synonym set %d: %w", id, result.Error), ResourceSynonymSet, id)
+	}
+	return &set, nil
+}
+
+// Update a DbSynonymSet
+func (d *DictionaryService) UpdateSynonymSet(ctx context.Context, set *DbSynonymSet) error {
+	db, ctx, cancel := bindDB(ctx, d.DB, d.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		existing := DbSynonymSet{}
+		if err := tx.Scopes(forUpdate).First(&existing, set.ID).Error; err != nil {
+			return err
+		}
+		set.CreatedAt = existing.CreatedAt
+		return tx.Save(set).Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to update a synonym set %d: %w", set.ID, err), ResourceSynonymSet, set.ID)
+	}
+	d.refresh(ctx)
+	return nil
+}
+
+// Delete a DbSynonymSet by ID
+func (d *DictionaryService) DeleteSynonymSetByID(ctx context.Context, id uint64) error {
+	db, ctx, cancel := bindDB(ctx, d.DB, d.QueryTimeout)
+	defer cancel()
+	result := db.Delete(&DbSynonymSet{}, id)
+	if result.Error != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to delete a synonym set %d: %w", id, result.Error), ResourceSynonymSet, id)
+	}
+	if result.RowsAffected == 0 {
+		return NotFoundError(ResourceSynonymSet, id)
+	}
+	d.refresh(ctx)
+	return nil
+}
+
+// Get all DbSynonymSets ordered by ID
+func (d *DictionaryService) ListSynonymSets(ctx context.Context) ([]*DbSynonymSet, error) {
+	db, ctx, cancel := bindDB(ctx, d.DB, d.QueryTimeout)
+	defer cancel()
+	var sets []*DbSynonymSet
+	result := db.Scopes(func(db *gorm.DB) *gorm.DB { return db.Order("id") }).Find(&sets)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get synonym sets: %w", result.Error), ResourceSynonymSet, nil)
+	}
+	return sets, nil
+}
+
+// Get the stop words in alphabetical order
+func (d *DictionaryService) GetStopWords(ctx context.Context) ([]string, error) {
+	db, ctx, cancel := bindDB(ctx, d.DB, d.QueryTimeout)
+	defer cancel()
+	var words []string
+	result := db.Scopes(func(db *gorm.DB) *gorm.DB { return db.Model(&DbStopWord{}).Order("word") }).Pluck("word", &words)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get stop words: %w", result.Error), ResourceStopWord, nil)
+	}
+	return words, nil
+}
+
+// Replace the stop words, duplicates are stored once
+func (d *DictionaryService) SetStopWords(ctx context.Context, words []string) error {
+	rows := make([]DbStopWord, 0, len(words))
+	seen := map[string]bool{}
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); !seen[word] {
+			seen[word] = true
+			rows = append(rows, DbStopWord{Word: word})
+		}
+	}
+	db, ctx, cancel := bindDB(ctx, d.DB, d.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		if err := tx.Where("1 = 1").Delete(&DbStopWord{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to set stop words: %w", err), ResourceStopWord, nil)
+	}
+	d.refresh(ctx)
+	return nil
+}
+
+// Reload reads the synonyms and stop words and publishes them to the index.
+func (d *DictionaryService) Reload(ctx context.Context) error {
+	sets, err := d.ListSynonymSets(ctx)
+	if err != nil {
+		return err
+	}
+	words, err := d.GetStopWords(ctx)
+	if err != nil {
+		return err
+	}
+	if d.Index != nil {
+		d.Index.SetDictionary(NewSearchDictionary(sets, words))
+	}
+	return nil
+}
+
+// refresh reloads and logs failures. After a write a failure only delays the
+// change until the next refresh, so the write itself still succeeds.
+func (d *DictionaryService) refresh(ctx context.Context) {
+	if err := d.Reload(ctx); err != nil {
+		log.Printf("Failed to reload the search dictionary. Error: %v", err)
+	}
+}
+
+// Run reloads the dictionary every RefreshInterval until ctx is done.
+func (d *DictionaryService) Run(ctx context.Context) {
+	interval := d.RefreshInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	d.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.refresh(ctx)
+		}
+	}
+}
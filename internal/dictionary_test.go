@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSearchDictionary() *SearchDictionary {
+	return NewSearchDictionary([]*DbSynonymSet{
+		{ID: 1, Type: SynonymEquivalent, Terms: []string{"tee", "t-shirt"}},
+		{ID: 2, Type: SynonymOneWay, Input: "hoodie", Terms: []string{"sweatshirt"}},
+		{ID: 3, Type: SynonymEquivalent, Terms: []string{"mug", "coffee cup"}},
+	}, []string{"the", "a"})
+}
+
+func TestSearchIndex_SearchWithDictionary(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected []uint64
+	}{
+		{name: "Equivalent synonym", query: "tee", expected: []uint64{1}},
+		{name: "Equivalent synonym of several words", query: "coffee cup", expected: []uint64{4}},
+		{name: "Part of a synonym term", query: "cups", expected: []uint64{}},
+		{name: "Synonym combined with other words", query: "cotton tees", expected: []uint64{1}},
+		{name: "One-way synonym, direct match ranks first", query: "hoodie", expected: []uint64{3, 2}},
+		{name: "One-way synonym is not reversed", query: "sweatshirt", expected: []uint64{2}},
+		{name: "Stop words are left out", query: "the mug", expected: []uint64{4}},
+		{name: "Only stop words", query: "the", expected: []uint64{4}},
+	}
+	index := NewSearchIndex()
+	index.Put(&DbProduct{ID: 1, Name: "Cotton T-Shirt"})
+	index.Put(&DbProduct{ID: 2, Name: "Grey Sweatshirt"})
+	index.Put(&DbProduct{ID: 3, Name: "Hoodie Hanger"})
+	index.Put(&DbProduct{ID: 4, Name: "The Mug"})
+	index.SetDictionary(newTestSearchDictionary())
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			//when
+			hits := index.Search(tc.query)
+			//then
+			assert.Equal(t, tc.expected, hitIDs(hits))
+		})
+	}
+}
+
+func TestSearchDictionary_Analyze(t *testing.T) {
+	testCases := []struct {
+		name       string
+		dictionary *SearchDictionary
+		words      []string
+		expected   []queryUnit
+	}{
+		{
+			name:     "No dictionary",
+			words:    []string{"the", "tee"},
+			expected: []queryUnit{{words: []string{"the"}}, {words: []string{"tee"}}},
+		},
+		{
+			name:       "Longest term wins",
+			dictionary: newTestSearchDictionary(),
+			words:      []string{"coffee", "cups", "the"},
+			expected:   []queryUnit{{words: []string{"coffee", "cups"}, alternatives: [][]string{{"mug"}}}},
+		},
+		{
+			name:       "Stemmed synonym",
+			dictionary: newTestSearchDictionary(),
+			words:      []string{"tees"},
+			expected:   []queryUnit{{words: []string{"tees"}, alternatives: [][]string{{"t", "shirt"}}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			//when
+			units := tc.dictionary.analyze(tc.words)
+			//then
+			assert.Equal(t, tc.expected, units)
+		})
+	}
+}
+
+func TestDictionaryService_Reload(t *testing.T) {
+	t.Run("Publishes the stored dictionary", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		index := NewSearchIndex()
+		index.Put(&DbProduct{ID: 1, Name: "Cotton T-Shirt"})
+		ds := &DictionaryService{DB: GormWrapper{DB: db}, Index: index}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_synonym_sets` ORDER BY id")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "type", "input", "terms"}).
+				AddRow(1, "equivalent", "", `["tee","t-shirt"]`))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT `word` FROM `catalog_stop_words` ORDER BY word")).
+			WillReturnRows(sqlmock.NewRows([]string{"word"}).AddRow("the"))
+		//when
+		err := ds.Reload(context.Background())
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1}, hitIDs(index.Search("the tee")))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Keeps the current dictionary on failure", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		index := NewSearchIndex()
+		index.Put(&DbProduct{ID: 1, Name: "Cotton T-Shirt"})
+		index.SetDictionary(newTestSearchDictionary())
+		ds := &DictionaryService{DB: GormWrapper{DB: db}, Index: index}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_synonym_sets` ORDER BY id")).
+			WillReturnError(errors.New("connection refused"))
+		//when
+		err := ds.Reload(context.Background())
+		//then
+		assert.Error(t, err)
+		assert.Equal(t, []uint64{1}, hitIDs(index.Search("tee")))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestDictionaryService_SetStopWords(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	ds := &DictionaryService{DB: GormWrapper{DB: db}}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_stop_words` WHERE 1 = 1")).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_stop_words` (`word`) VALUES (?),(?)")).
+		WithArgs("the", "a").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	sqlMock.ExpectCommit()
+	//when
+	err := ds.SetStopWords(context.Background(), []string{"The", " a", "the"})
+	//then
+	require.NoError(t, err)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestDictionaryService_DeleteSynonymSetByID(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	ds := &DictionaryService{DB: GormWrapper{DB: db}}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_synonym_sets` WHERE `catalog_synonym_sets`.`id` = ?")).
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectCommit()
+	//when
+	err := ds.DeleteSynonymSetByID(context.Background(), 7)
+	//then
+	assert.Equal(t, KindNotFound, KindOf(err))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,204 @@
+package internal
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// ErrorKind classifies catalog failures independently of the transport.
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindNotFound
+	KindAlreadyExists
+	KindInvalidArgument
+	KindConflict
+	KindUnavailable
+	KindFailedPrecondition
+	KindUnauthenticated
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not found"
+	case KindAlreadyExists:
+		return "already exists"
+	case KindInvalidArgument:
+		return "invalid argument"
+	case KindConflict:
+		return "conflict"
+	case KindUnavailable:
+		return "unavailable"
+	case KindFailedPrecondition:
+		return "failed precondition"
+	case KindUnauthenticated:
+		return "unauthenticated"
+	default:
+		return "internal"
+	}
+}
+
+const (
+	ResourceProduct      = "product"
+	ResourceVariant      = "variant"
+	ResourceCategory     = "category"
+	ResourceAttribute    = "attribute"
+	ResourceAttributeSet = "attribute_set"
+	ResourceSynonymSet   = "synonym_set"
+	ResourceStopWord     = "stop_word"
+
+	ResourceIdempotencyKey = "idempotency_key"
+
+	// defaultRetryDelay is suggested to clients when the database is unreachable.
+	defaultRetryDelay = time.Second
+)
+
+// MySQL server error numbers we react to.
+const (
+	mysqlErrDuplicateEntry  = 1062
+	mysqlErrTooManyConns    = 1040
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrDeadlock        = 1213
+)
+
+// FieldViolation describes a single invalid field of a request.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is a typed catalog error produced by ProductService and translated
+// into a gRPC status by Server.
+type Error struct {
+	Kind ErrorKind
+	// Resource and ID identify the entity the error is about, e.g. "product" and "42".
+	Resource string
+	ID       string
+	// Message is safe to return to clients. When empty it is derived from Kind.
+	Message    string
+	Violations []FieldViolation
+	RetryDelay time.Duration
+	// Err is the underlying cause, kept for logs only.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.PublicMessage()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// PublicMessage returns the message that can be shown to API clients.
+func (e *Error) PublicMessage() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	switch {
+	case e.Kind == KindInternal:
+		return "internal error"
+	case e.Resource != "" && e.ID != "":
+		return fmt.Sprintf("%s %s %s", e.Resource, e.ID, e.Kind)
+	case e.Resource != "":
+		return fmt.Sprintf("%s %s", e.Resource, e.Kind)
+	default:
+		return e.Kind.String()
+	}
+}
+
+// KindOf returns the kind of a catalog error, KindInternal for anything else.
+func KindOf(err error) ErrorKind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+func NotFoundError(resource string, id interface{}) *Error {
+	return &Error{Kind: KindNotFound, Resource: resource, ID: fmt.Sprint(id)}
+}
+
+func AlreadyExistsError(resource string, id interface{}) *Error {
+	return &Error{Kind: KindAlreadyExists, Resource: resource, ID: fmt.Sprint(id)}
+}
+
+func InvalidArgumentError(message string, violations ...FieldViolation) *Error {
+	return &Error{Kind: KindInvalidArgument, Message: message, Violations: violations}
+}
+
+func ConflictError(resource string, id interface{}, message string) *Error {
+	return &Error{Kind: KindConflict, Resource: resource, ID: fmt.Sprint(id), Message: message}
+}
+
+func UnavailableError(err error) *Error {
+	return &Error{Kind: KindUnavailable, Message: "catalog storage is unavailable", RetryDelay: defaultRetryDelay, Err: err}
+}
+
+// classifyDbError turns a GORM/MySQL error into a typed *Error. err is
+// usually already wrapped with an operation description which is kept as the
+// cause. id may be nil when the entity has no identifier yet. When ctx is done
+// the context error wins, since drivers report aborted queries inconsistently.
+func classifyDbError(ctx context.Context, err error, resource string, id interface{}) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil && !isContextError(err) {
+		return fmt.Errorf("%v: %w", err, ctxErr)
+	}
+	var typed *Error
+	if errors.As(err, &typed) || isContextError(err) {
+		return err
+	}
+	idStr := ""
+	if id != nil {
+		idStr = fmt.Sprint(id)
+	}
+	e := &Error{Kind: KindInternal, Resource: resource, ID: idStr, Err: err}
+
+	var mysqlErr *mysql.MySQLError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		e.Kind = KindNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		e.Kind = KindAlreadyExists
+	case errors.As(err, &mysqlErr):
+		switch mysqlErr.Number {
+		case mysqlErrDuplicateEntry:
+			e.Kind = KindAlreadyExists
+		case mysqlErrLockWaitTimeout, mysqlErrDeadlock:
+			e.Kind = KindConflict
+			e.Message = "concurrent modification, retry the request"
+		case mysqlErrTooManyConns:
+			return UnavailableError(err)
+		}
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, mysql.ErrInvalidConn), errors.As(err, &netErr):
+		return UnavailableError(err)
+	}
+	return e
+}
+
+// isDuplicateOf reports whether err is a MySQL duplicate entry for the named unique index.
+func isDuplicateOf(err error, index string) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry && strings.Contains(mysqlErr.Message, index)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
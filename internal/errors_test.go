@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestClassifyDbError(t *testing.T) {
+	// given
+	tests := []struct {
+		name     string
+		err      error
+		wantKind ErrorKind
+	}{
+		{
+			name:     "Record not found",
+			err:      fmt.Errorf("failed to get a product 1: %w", gorm.ErrRecordNotFound),
+			wantKind: KindNotFound,
+		},
+		{
+			name:     "Duplicate entry",
+			err:      fmt.Errorf("failed to create a product: %w", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}),
+			wantKind: KindAlreadyExists,
+		},
+		{
+			name:     "Deadlock",
+			err:      &mysql.MySQLError{Number: 1213, Message: "Deadlock found"},
+			wantKind: KindConflict,
+		},
+		{
+			name:     "Bad connection",
+			err:      fmt.Errorf("failed to get products: %w", driver.ErrBadConn),
+			wantKind: KindUnavailable,
+		},
+		{
+			name:     "Connection refused",
+			err:      fmt.Errorf("failed to get products: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}),
+			wantKind: KindUnavailable,
+		},
+		{
+			name:     "Too many connections",
+			err:      &mysql.MySQLError{Number: 1040, Message: "Too many connections"},
+			wantKind: KindUnavailable,
+		},
+		{
+			name:     "Invalid connection",
+			err:      mysql.ErrInvalidConn,
+			wantKind: KindUnavailable,
+		},
+		{
+			name:     "Unknown error",
+			err:      gorm.ErrInvalidData,
+			wantKind: KindInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			err := classifyDbError(context.Background(), tt.err, ResourceProduct, uint64(1))
+			//then
+			assert.Equal(t, tt.wantKind, KindOf(err))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	t.Run("Not found carries resource info", func(t *testing.T) {
+		//when
+		st := status.Convert(toStatus(NotFoundError(ResourceProduct, 7)))
+		//then
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "product 7 not found", st.Message())
+		if assert.Len(t, st.Details(), 1) {
+			info := st.Details()[0].(*errdetails.ResourceInfo)
+			assert.Equal(t, ResourceProduct, info.ResourceType)
+			assert.Equal(t, "7", info.ResourceName)
+		}
+	})
+
+	t.Run("Invalid argument carries field violations", func(t *testing.T) {
+		//when
+		st := status.Convert(toStatus(InvalidArgumentError("invalid product", FieldViolation{Field: "name", Description: "must not be empty"})))
+		//then
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		if assert.Len(t, st.Details(), 1) {
+			badRequest := st.Details()[0].(*errdetails.BadRequest)
+			assert.Equal(t, "name", badRequest.FieldViolations[0].Field)
+		}
+	})
+
+	t.Run("Unavailable carries retry info", func(t *testing.T) {
+		//when
+		st := status.Convert(toStatus(classifyDbError(context.Background(), driver.ErrBadConn, ResourceProduct, nil)))
+		//then
+		assert.Equal(t, codes.Unavailable, st.Code())
+		if assert.Len(t, st.Details(), 1) {
+			retry := st.Details()[0].(*errdetails.RetryInfo)
+			assert.Equal(t, defaultRetryDelay, retry.RetryDelay.AsDuration())
+		}
+	})
+
+	t.Run("Untyped errors do not leak", func(t *testing.T) {
+		//when
+		st := status.Convert(toStatus(gorm.ErrInvalidData))
+		//then
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, "internal error", st.Message())
+	})
+}
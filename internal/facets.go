@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MaxPriceRanges limits the price ranges counted by a single facet query.
+const MaxPriceRanges = 20
+
+// PriceRange is the half-open range [From, To) of base prices. A nil bound is
+// unbounded.
+type PriceRange struct {
+	From *Money
+	To   *Money
+}
+
+// FacetQuery is a product listing together with the facets to count over
+// all products matching its filters.
+type FacetQuery struct {
+	ListQuery
+	// FacetAttributes are the codes of the attributes to count values of.
+	// Every enum, multi-enum and bool attribute is counted when empty.
+	FacetAttributes []string
+	PriceRanges     []PriceRange
+}
+
+type FacetValue struct {
+	Value string
+	Count int64
+}
+
+// AttributeFacet counts the matching products per value of an attribute,
+// most frequent values first.
+type AttributeFacet struct {
+	Code   string
+	Values []FacetValue
+}
+
+type PriceRangeCount struct {
+	PriceRange
+	Count int64
+}
+
+// FacetPage is a page of matching products with the facet counts. Facets
+// are disjunctive: the counts of an attribute ignore the filter on that
+// attribute and price ranges ignore the price filter, so they show how many
+// products another choice would match.
+type FacetPage struct {
+	ListPage
+	Attributes  []AttributeFacet
+	PriceRanges []PriceRangeCount
+}
+
+// facetAttributeTypes are counted when no facet attributes are requested.
+var facetAttributeTypes = []AttributeType{AttributeEnum, AttributeMultiEnum, AttributeBool}
+
+// List a page of DbProducts with attribute value and price range counts over
+// all products matching the filters. Counts are aggregated in the database.
+func (p *ProductService) FacetProducts(ctx context.Context, query FacetQuery) (*FacetPage, error) {
+	if violations := priceRangeViolations(query.PriceRanges); len(violations) > 0 {
+		return nil, InvalidArgumentError("invalid facet query", violations...)
+	}
+	page, err := p.ListProducts(ctx, query.ListQuery)
+	if err != nil {
+		return nil, err
+	}
+	out := &FacetPage{ListPage: *page}
+
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	codes, err := facetCodes(db, query.FacetAttributes)
+	if err != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get facet attributes: %w", err), ResourceAttribute, nil)
+	}
+	// Attributes without a filter share one query, each filtered attribute
+	// needs its own as its filter is left out.
+	var unfiltered []string
+	counts := make(map[string][]FacetValue, len(codes))
+	for _, code := range codes {
+		if _, ok := query.Attributes[code]; !ok {
+			unfiltered = append(unfiltered, code)
+			continue
+		}
+		if err := countAttributeValues(db, query.filterScope(false, code), []string{code}, counts); err != nil {
+			return nil, classifyDbError(ctx, fmt.Errorf("failed to count values of attribute %q: %w", code, err), ResourceAttribute, code)
+		}
+	}
+	if len(unfiltered) > 0 {
+		if err := countAttributeValues(db, query.filterScope(false, ""), unfiltered, counts); err != nil {
+			return nil, classifyDbError(ctx, fmt.Errorf("failed to count attribute values: %w", err), ResourceAttribute, nil)
+		}
+	}
+	for _, code := range codes {
+		out.Attributes = append(out.Attributes, AttributeFacet{Code: code, Values: counts[code]})
+	}
+
+	if len(query.PriceRanges) > 0 {
+		if out.PriceRanges, err = countPriceRanges(db, query.filterScope(true, ""), query.PriceRanges); err != nil {
+			return nil, classifyDbError(ctx, fmt.Errorf("failed to count price ranges: %w", err), ResourceProduct, nil)
+		}
+	}
+	return out, nil
+}
+
+// facetCodes resolves the attributes to count, ordered by code. Unknown codes
+// are dropped.
+func facetCodes(db DbWrapper, requested []string) ([]string, error) {
+	var codes []string
+	err := db.Scopes(func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&DbAttribute{})
+		if len(requested) > 0 {
+			return db.Where("code IN ?", requested).Order("code")
+		}
+		return db.Where("type IN ?", facetAttributeTypes).Order("code")
+	}).Pluck("code", &codes).Error
+	return codes, err
+}
+
+// countAttributeValues adds the number of products matching filter per value
+// of the attributes codes to counts.
+func countAttributeValues(db DbWrapper, filter func(*gorm.DB) *gorm.DB, codes []string, counts map[string][]FacetValue) error {
+	var rows []struct {
+		Code  string
+		Value string
+		Count int64
+	}
+	err := db.Scopes(func(db *gorm.DB) *gorm.DB {
+		products := db.Session(&gorm.Session{NewDB: true}).Model(&DbProduct{}).Select("id").Scopes(filter)
+		return db.Table("catalog_product_attribute_values v").
+			Select("a.code, v.value, COUNT(*) AS count").
+			Joins("JOIN catalog_attributes a ON a.id = v.attribute_id").
+			Where("a.code IN ? AND v.product_id IN (?)", codes, products).
+			Group("a.code, v.value").
+			Order("a.code, count DESC, v.value")
+	}).Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		counts[row.Code] = append(counts[row.Code], FacetValue{Value: row.Value, Count: row.Count})
+	}
+	return nil
+}
+
+// countPriceRanges counts the products matching filter in every range with a
+// single conditional aggregate.
+func countPriceRanges(db DbWrapper, filter func(*gorm.DB) *gorm.DB, ranges []PriceRange) ([]PriceRangeCount, error) {
+	columns := make([]string, len(ranges))
+	var args []interface{}
+	for i, r := range ranges {
+		var conditions []string
+		if r.From != nil {
+			conditions = append(conditions, "price_amount >= ?")
+			args = append(args, r.From.Amount)
+		}
+		if r.To != nil {
+			conditions = append(conditions, "price_amount < ?")
+			args = append(args, r.To.Amount)
+		}
+		columns[i] = fmt.Sprintf("COUNT(CASE WHEN %s THEN 1 END)", strings.Join(conditions, " AND "))
+	}
+	currency := ranges[0].currency()
+	row := db.Scopes(func(db *gorm.DB) *gorm.DB {
+		return db.Model(&DbProduct{}).
+			Select(strings.Join(columns, ", "), args...).
+			Where("price_currency = ?", currency).
+			Scopes(filter)
+	}).Row()
+	out := make([]PriceRangeCount, len(ranges))
+	dest := make([]interface{}, len(ranges))
+	for i, r := range ranges {
+		out[i].PriceRange = r
+		dest[i] = &out[i].Count
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r PriceRange) currency() string {
+	if r.From != nil {
+		return r.From.Currency
+	}
+	if r.To != nil {
+		return r.To.Currency
+	}
+	return ""
+}
+
+// priceRangeViolations checks that every range has a bound, From is below To
+// and all bounds share one currency.
+func priceRangeViolations(ranges []PriceRange) []FieldViolation {
+	var violations []FieldViolation
+	if len(ranges) > MaxPriceRanges {
+		return []FieldViolation{{Field: "price_ranges", Description: fmt.Sprintf("must have at most %d ranges", MaxPriceRanges)}}
+	}
+	currency := ""
+	for i, r := range ranges {
+		field := fmt.Sprintf("price_ranges[%d]", i)
+		switch {
+		case r.From == nil && r.To == nil:
+			violations = append(violations, FieldViolation{Field: field, Description: "must have a lower or an upper bound"})
+			continue
+		case r.From != nil && r.To != nil && (r.From.Currency != r.To.Currency || r.From.Amount >= r.To.Amount):
+			violations = append(violations, FieldViolation{Field: field + ".to", Description: "must be above from in the same currency"})
+		}
+		if currency == "" {
+			currency = r.currency()
+		}
+		for _, bound := range []*Money{r.From, r.To} {
+			if bound != nil && bound.Currency != currency {
+				violations = append(violations, FieldViolation{Field: field, Description: "must have the currency of the other ranges"})
+				break
+			}
+		}
+	}
+	return violations
+}
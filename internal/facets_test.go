@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductService_FacetProducts(t *testing.T) {
+	colorFilter := "(id IN (SELECT v.product_id FROM catalog_product_attribute_values v JOIN catalog_attributes a ON a.id = v.attribute_id WHERE a.code = ? AND v.value IN (?)))"
+
+	t.Run("Counts attribute values and price ranges", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		maxPrice := Money{Amount: 5000, Currency: "USD"}
+		from, to := Money{Amount: 1000, Currency: "USD"}, Money{Amount: 2000, Currency: "USD"}
+		query := FacetQuery{
+			ListQuery:   ListQuery{MaxPrice: &maxPrice, Attributes: map[string][]string{"color": {"red"}}},
+			PriceRanges: []PriceRange{{To: &from}, {From: &from, To: &to}, {From: &to}},
+		}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE (price_currency = ? AND price_amount <= ?) AND "+colorFilter+" AND `catalog_products`.`deleted_at` IS NULL ORDER BY id ASC LIMIT ?")).
+			WithArgs("USD", 5000, "color", "red", 51).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "T-shirt M"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT `code` FROM `catalog_attributes` WHERE type IN (?,?,?) ORDER BY code")).
+			WithArgs(AttributeEnum, AttributeMultiEnum, AttributeBool).
+			WillReturnRows(sqlmock.NewRows([]string{"code"}).AddRow("color").AddRow("organic"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT a.code, v.value, COUNT(*) AS count FROM catalog_product_attribute_values v JOIN catalog_attributes a ON a.id = v.attribute_id WHERE a.code IN (?) AND v.product_id IN (SELECT `id` FROM `catalog_products` WHERE (price_currency = ? AND price_amount <= ?) AND `catalog_products`.`deleted_at` IS NULL) GROUP BY a.code, v.value ORDER BY a.code, count DESC, v.value")).
+			WithArgs("color", "USD", 5000).
+			WillReturnRows(sqlmock.NewRows([]string{"code", "value", "count"}).AddRow("color", "red", 1).AddRow("color", "blue", 3))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT a.code, v.value, COUNT(*) AS count FROM catalog_product_attribute_values v JOIN catalog_attributes a ON a.id = v.attribute_id WHERE a.code IN (?) AND v.product_id IN (SELECT `id` FROM `catalog_products` WHERE (price_currency = ? AND price_amount <= ?) AND "+colorFilter+" AND `catalog_products`.`deleted_at` IS NULL) GROUP BY a.code, v.value ORDER BY a.code, count DESC, v.value")).
+			WithArgs("organic", "USD", 5000, "color", "red").
+			WillReturnRows(sqlmock.NewRows([]string{"code", "value", "count"}).AddRow("organic", "true", 1))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(CASE WHEN price_amount < ? THEN 1 END), COUNT(CASE WHEN price_amount >= ? AND price_amount < ? THEN 1 END), COUNT(CASE WHEN price_amount >= ? THEN 1 END) FROM `catalog_products` WHERE price_currency = ? AND "+colorFilter+" AND `catalog_products`.`deleted_at` IS NULL")).
+			WithArgs(1000, 1000, 2000, 2000, "USD", "color", "red").
+			WillReturnRows(sqlmock.NewRows([]string{"a", "b", "c"}).AddRow(2, 1, 0))
+		//when
+		page, err := ps.FacetProducts(context.Background(), query)
+		//then
+		require.NoError(t, err)
+		assert.Len(t, page.Products, 1)
+		assert.Equal(t, []AttributeFacet{
+			{Code: "color", Values: []FacetValue{{Value: "red", Count: 1}, {Value: "blue", Count: 3}}},
+			{Code: "organic", Values: []FacetValue{{Value: "true", Count: 1}}},
+		}, page.Attributes)
+		require.Len(t, page.PriceRanges, 3)
+		assert.Equal(t, []int64{2, 1, 0}, []int64{page.PriceRanges[0].Count, page.PriceRanges[1].Count, page.PriceRanges[2].Count})
+		assert.Equal(t, &from, page.PriceRanges[1].From)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid price ranges", func(t *testing.T) {
+		usd, eur := Money{Amount: 1000, Currency: "USD"}, Money{Amount: 500, Currency: "EUR"}
+		for name, ranges := range map[string][]PriceRange{
+			"unbounded":      {{}},
+			"empty":          {{From: &usd, To: &usd}},
+			"mixed currency": {{To: &usd}, {From: &eur}},
+			"too many":       make([]PriceRange, MaxPriceRanges+1),
+		} {
+			t.Run(name, func(t *testing.T) {
+				// given
+				ps := &ProductService{DB: new(DbWrapperMock)}
+				//when
+				_, err := ps.FacetProducts(context.Background(), FacetQuery{PriceRanges: ranges})
+				//then
+				assert.Equal(t, KindInvalidArgument, KindOf(err))
+			})
+		}
+	})
+}
@@ -0,0 +1,930 @@
+package internal
+
+import (
+	cpb "catalog/gen/go/catalog"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/akolpakov-somehash/headless-ecom-protos/gen/go/catalog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxRequestBodySize limits JSON bodies accepted by the gateway.
+	maxRequestBodySize = 1 << 20
+
+	// metadataHeaderPrefix marks HTTP headers forwarded to handlers as gRPC metadata.
+	metadataHeaderPrefix = "Grpc-Metadata-"
+)
+
+// forwardedHeaders are passed to handlers as gRPC metadata in addition to
+// the Grpc-Metadata-* headers.
+var forwardedHeaders = []string{"Authorization", "If-Match", "Idempotency-Key"}
+
+var (
+	jsonMarshal   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	jsonUnmarshal = protojson.UnmarshalOptions{}
+)
+
+// CORSConfig controls Cross-Origin Resource Sharing of the gateway. CORS is
+// disabled when AllowedOrigins is empty, "*" allows any origin.
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedHeaders []string
+	MaxAge         time.Duration
+}
+
+// Gateway serves the catalog as an HTTP/JSON API under /v1/products by
+// calling the same Server handlers the gRPC transport uses.
+type Gateway struct {
+	server *Server
+	cors   CORSConfig
+	mux    *http.ServeMux
+}
+
+func NewGateway(server *Server, cors CORSConfig) *Gateway {
+	g := &Gateway{server: server, cors: cors, mux: http.NewServeMux()}
+	g.mux.HandleFunc("GET /v1/products", g.listProducts)
+	g.mux.HandleFunc("GET /v1/facets", g.facetProducts)
+	g.mux.HandleFunc("GET /v1/search", g.searchProducts)
+	g.mux.HandleFunc("POST /v1/search/rebuild", g.rebuildSearchIndex)
+	g.mux.HandleFunc("GET /v1/suggest", g.suggestProducts)
+	g.mux.HandleFunc("POST /v1/products", g.addProduct)
+	g.mux.HandleFunc("GET /v1/products/{id}", g.getProduct)
+	g.mux.HandleFunc("PUT /v1/products/{id}", g.updateProduct)
+	g.mux.HandleFunc("PATCH /v1/products/{id}", g.patchProduct)
+	g.mux.HandleFunc("DELETE /v1/products/{id}", g.deleteProduct)
+	g.mux.HandleFunc("GET /v1/products/sku/{sku}", g.getProductBySku)
+	g.mux.HandleFunc("POST /v1/products/batch-get", g.batchGetProducts)
+	g.mux.HandleFunc("POST /v1/products/batch-create", g.batchCreateProducts)
+	g.mux.HandleFunc("POST /v1/products/batch-update", g.batchUpdateProducts)
+	g.mux.HandleFunc("POST /v1/products/batch-delete", g.batchDeleteProducts)
+	g.mux.HandleFunc("GET /v1/products/{id}/{resource}", productResources(map[string]http.HandlerFunc{
+		"prices":       g.getProductPrices,
+		"variants":     g.getProductVariants,
+		"attributes":   g.getProductAttributes,
+		"lifecycle":    g.getProductLifecycle,
+		"availability": g.getProductAvailability,
+	}))
+	g.mux.HandleFunc("PUT /v1/products/{id}/{resource}", productResources(map[string]http.HandlerFunc{
+		"prices":       g.setProductPrices,
+		"options":      g.setProductOptions,
+		"attributes":   g.setProductAttributes,
+		"lifecycle":    g.setProductLifecycle,
+		"availability": g.setProductAvailability,
+	}))
+	g.mux.HandleFunc("POST /v1/variants", g.createVariant)
+	g.mux.HandleFunc("GET /v1/variants/{id}", g.getVariant)
+	g.mux.HandleFunc("PUT /v1/variants/{id}", g.updateVariant)
+	g.mux.HandleFunc("DELETE /v1/variants/{id}", g.deleteVariant)
+	g.mux.HandleFunc("GET /v1/categories", g.getCategoryTree)
+	g.mux.HandleFunc("POST /v1/categories", g.createCategory)
+	g.mux.HandleFunc("GET /v1/categories/{id}", g.getCategory)
+	g.mux.HandleFunc("PUT /v1/categories/{id}", g.updateCategory)
+	g.mux.HandleFunc("DELETE /v1/categories/{id}", g.deleteCategory)
+	g.mux.HandleFunc("POST /v1/categories/{id}/move", g.moveCategory)
+	g.mux.HandleFunc("PUT /v1/categories/{id}/products", g.assignProducts)
+	g.mux.HandleFunc("DELETE /v1/categories/{id}/products", g.unassignProducts)
+	g.mux.HandleFunc("GET /v1/attributes", g.listAttributes)
+	g.mux.HandleFunc("POST /v1/attributes", g.createAttribute)
+	g.mux.HandleFunc("GET /v1/attributes/{id}", g.getAttribute)
+	g.mux.HandleFunc("PUT /v1/attributes/{id}", g.updateAttribute)
+	g.mux.HandleFunc("POST /v1/attribute-sets", g.createAttributeSet)
+	g.mux.HandleFunc("GET /v1/attribute-sets/{id}", g.getAttributeSet)
+	g.mux.HandleFunc("PUT /v1/attribute-sets/{id}", g.updateAttributeSet)
+	g.mux.HandleFunc("GET /v1/synonyms", g.listSynonymSets)
+	g.mux.HandleFunc("POST /v1/synonyms", g.createSynonymSet)
+	g.mux.HandleFunc("GET /v1/synonyms/{id}", g.getSynonymSet)
+	g.mux.HandleFunc("PUT /v1/synonyms/{id}", g.updateSynonymSet)
+	g.mux.HandleFunc("DELETE /v1/synonyms/{id}", g.deleteSynonymSet)
+	g.mux.HandleFunc("GET /v1/stop-words", g.getStopWords)
+	g.mux.HandleFunc("PUT /v1/stop-words", g.setStopWords)
+	return g
+}
+
+// productResources serves the sub-resources of a product under
+// /v1/products/{id}/{resource}. A pattern per resource would conflict with
+// /v1/products/sku/{sku}.
+func productResources(handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[r.PathValue("resource")]
+		if !ok {
+			writeError(w, status.Errorf(codes.NotFound, "product resource %q not found", r.PathValue("resource")))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if g.applyCORS(w, r) {
+		return
+	}
+	g.mux.ServeHTTP(w, r)
+}
+
+// applyCORS sets CORS headers and reports whether the request was a
+// preflight that has been answered.
+func (g *Gateway) applyCORS(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || !g.originAllowed(origin) {
+		return false
+	}
+	h := w.Header()
+	h.Set("Access-Control-Allow-Origin", origin)
+	h.Add("Vary", "Origin")
+	h.Set("Access-Control-Expose-Headers", "ETag")
+	if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+		return false
+	}
+	h.Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE")
+	allowedHeaders := g.cors.AllowedHeaders
+	if len(allowedHeaders) == 0 {
+		allowedHeaders = []string{"Content-Type", "Authorization", "If-Match", "Idempotency-Key"}
+	}
+	h.Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders, ", "))
+	if g.cors.MaxAge > 0 {
+		h.Set("Access-Control-Max-Age", strconv.Itoa(int(g.cors.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+func (g *Gateway) originAllowed(origin string) bool {
+	for _, allowed := range g.cors.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Gateway) listProducts(w http.ResponseWriter, r *http.Request) {
+	in, violations := parseListRequest(r.URL.Query())
+	if len(violations) > 0 {
+		writeError(w, toStatus(InvalidArgumentError("invalid query parameters", violations...)))
+		return
+	}
+	res, err := g.server.ListProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+// facetProducts takes the listing parameters plus repeated facet=<code> and
+// price_range=<from>-<to> parameters where either bound may be omitted.
+func (g *Gateway) facetProducts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	list, violations := parseListRequest(q)
+	in := &cpb.FacetProductsRequest{List: list, FacetAttributes: q["facet"]}
+	for i, v := range q["price_range"] {
+		priceRange := &cpb.PriceRange{}
+		from, to, found := strings.Cut(v, "-")
+		for _, bound := range []struct {
+			in  string
+			out **float32
+		}{{from, &priceRange.From}, {to, &priceRange.To}} {
+			if bound.in == "" {
+				continue
+			}
+			f, err := strconv.ParseFloat(bound.in, 32)
+			if err != nil {
+				found = false
+				break
+			}
+			price := float32(f)
+			*bound.out = &price
+		}
+		if !found {
+			violations = append(violations, FieldViolation{Field: fmt.Sprintf("price_range[%d]", i), Description: "must be \"<from>-<to>\""})
+		}
+		in.PriceRanges = append(in.PriceRanges, priceRange)
+	}
+	if len(violations) > 0 {
+		writeError(w, toStatus(InvalidArgumentError("invalid query parameters", violations...)))
+		return
+	}
+	res, err := g.server.FacetProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) searchProducts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	in := &cpb.SearchProductsRequest{Query: q.Get("q"), PageToken: q.Get("page_token")}
+	if v := q.Get("page_size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			writeError(w, toStatus(InvalidArgumentError("invalid query parameters", FieldViolation{Field: "page_size", Description: "must be an integer"})))
+			return
+		}
+		in.PageSize = int32(n)
+	}
+	res, err := g.server.SearchProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) suggestProducts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	in := &cpb.SuggestProductsRequest{Prefix: q.Get("prefix")}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			writeError(w, toStatus(InvalidArgumentError("invalid query parameters", FieldViolation{Field: "limit", Description: "must be an integer"})))
+			return
+		}
+		in.Limit = int32(n)
+	}
+	res, err := g.server.SuggestProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) rebuildSearchIndex(w http.ResponseWriter, r *http.Request) {
+	res, err := g.server.RebuildSearchIndex(incomingContext(w, r), &pb.Empty{})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+// parseListRequest reads the listing parameters. Attribute filters are
+// repeated attr.<code>=<value> parameters.
+func parseListRequest(q url.Values) (*cpb.ListProductsRequest, []FieldViolation) {
+	in := &cpb.ListProductsRequest{
+		PageToken:    q.Get("page_token"),
+		OrderBy:      q.Get("order_by"),
+		SkuPrefix:    q.Get("sku_prefix"),
+		NameContains: q.Get("name_contains"),
+	}
+	var violations []FieldViolation
+	if v := q.Get("category_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			violations = append(violations, FieldViolation{Field: "category_id", Description: "must be a positive integer"})
+		}
+		in.CategoryId = id
+	}
+	if v := q.Get("page_size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			violations = append(violations, FieldViolation{Field: "page_size", Description: "must be an integer"})
+		}
+		in.PageSize = int32(n)
+	}
+	for field, dest := range map[string]**float32{"min_price": &in.MinPrice, "max_price": &in.MaxPrice} {
+		if v := q.Get(field); v != "" {
+			f, err := strconv.ParseFloat(v, 32)
+			if err != nil {
+				violations = append(violations, FieldViolation{Field: field, Description: "must be a number"})
+				continue
+			}
+			price := float32(f)
+			*dest = &price
+		}
+	}
+	var attrCodes []string
+	for key := range q {
+		if code, ok := strings.CutPrefix(key, "attr."); ok {
+			attrCodes = append(attrCodes, code)
+		}
+	}
+	sort.Strings(attrCodes)
+	for _, code := range attrCodes {
+		in.Attributes = append(in.Attributes, &cpb.AttributeFilter{Code: code, Values: q["attr."+code]})
+	}
+	return in, violations
+}
+
+func (g *Gateway) addProduct(w http.ResponseWriter, r *http.Request) {
+	in := &pb.Product{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.AddProduct(incomingContext(w, r), in)
+	writeResponse(w, http.StatusCreated, res, err)
+}
+
+func (g *Gateway) getProduct(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetProductInfo(incomingContext(w, r), &pb.ProductId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) getProductBySku(w http.ResponseWriter, r *http.Request) {
+	res, err := g.server.GetProductBySku(incomingContext(w, r), &cpb.ProductSku{Sku: r.PathValue("sku")})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) updateProduct(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &pb.Product{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.Id = id
+	res, err := g.server.UpdateProduct(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) patchProduct(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.PatchProductRequest{}
+	if !readBody(w, r, in) {
+		return
+	}
+	if in.Product == nil {
+		in.Product = &pb.Product{}
+	}
+	in.Product.Id = id
+	res, err := g.server.PatchProduct(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) deleteProduct(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	_, err := g.server.DeleteProduct(incomingContext(w, r), &pb.ProductId{Id: id})
+	writeResponse(w, http.StatusNoContent, nil, err)
+}
+
+func (g *Gateway) batchGetProducts(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.BatchGetProductsRequest{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.BatchGetProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) batchCreateProducts(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.BatchCreateProductsRequest{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.BatchCreateProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) batchUpdateProducts(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.BatchUpdateProductsRequest{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.BatchUpdateProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) batchDeleteProducts(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.BatchDeleteProductsRequest{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.BatchDeleteProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) getProductPrices(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetProductPrices(incomingContext(w, r), &pb.ProductId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) setProductPrices(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.ProductPrices{}
+	if !readBody(w, r, in) || !readRevision(w, r, &in.Revision) {
+		return
+	}
+	in.ProductId = id
+	res, err := g.server.SetProductPrices(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) getProductLifecycle(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetProductLifecycle(incomingContext(w, r), &pb.ProductId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) setProductLifecycle(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.ProductLifecycle{}
+	if !readBody(w, r, in) || !readRevision(w, r, &in.Revision) {
+		return
+	}
+	in.ProductId = id
+	res, err := g.server.SetProductLifecycle(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) getProductAvailability(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetProductAvailability(incomingContext(w, r), &pb.ProductId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) setProductAvailability(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.ProductAvailability{}
+	if !readBody(w, r, in) || !readRevision(w, r, &in.Revision) {
+		return
+	}
+	in.ProductId = id
+	res, err := g.server.SetProductAvailability(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) setProductOptions(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.ProductOptions{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.ProductId = id
+	res, err := g.server.SetProductOptions(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) getProductVariants(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetProductVariants(incomingContext(w, r), &pb.ProductId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) createVariant(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.Variant{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.CreateVariant(incomingContext(w, r), in)
+	writeResponse(w, http.StatusCreated, res, err)
+}
+
+func (g *Gateway) getVariant(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetVariant(incomingContext(w, r), &cpb.VariantId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) updateVariant(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.Variant{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.Id = id
+	res, err := g.server.UpdateVariant(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) deleteVariant(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	_, err := g.server.DeleteVariant(incomingContext(w, r), &cpb.VariantId{Id: id})
+	writeResponse(w, http.StatusNoContent, nil, err)
+}
+
+func (g *Gateway) getCategoryTree(w http.ResponseWriter, r *http.Request) {
+	res, err := g.server.GetCategoryTree(incomingContext(w, r), new(pb.Empty))
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) createCategory(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.Category{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.CreateCategory(incomingContext(w, r), in)
+	writeResponse(w, http.StatusCreated, res, err)
+}
+
+func (g *Gateway) getCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetCategory(incomingContext(w, r), &cpb.CategoryId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) updateCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.Category{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.Id = id
+	res, err := g.server.UpdateCategory(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) deleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	_, err := g.server.DeleteCategory(incomingContext(w, r), &cpb.CategoryId{Id: id})
+	writeResponse(w, http.StatusNoContent, nil, err)
+}
+
+func (g *Gateway) moveCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.MoveCategoryRequest{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.Id = id
+	res, err := g.server.MoveCategory(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) assignProducts(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.CategoryProducts{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.CategoryId = id
+	res, err := g.server.AssignProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+// unassignProducts reads the products from repeated product_id query parameters.
+func (g *Gateway) unassignProducts(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.CategoryProducts{CategoryId: id}
+	for _, v := range r.URL.Query()["product_id"] {
+		productID, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			writeError(w, toStatus(InvalidArgumentError("invalid query parameters", FieldViolation{Field: "product_id", Description: "must be a positive integer"})))
+			return
+		}
+		in.ProductIds = append(in.ProductIds, productID)
+	}
+	_, err := g.server.UnassignProducts(incomingContext(w, r), in)
+	writeResponse(w, http.StatusNoContent, nil, err)
+}
+
+func (g *Gateway) listAttributes(w http.ResponseWriter, r *http.Request) {
+	res, err := g.server.ListAttributes(incomingContext(w, r), new(pb.Empty))
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) createAttribute(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.Attribute{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.CreateAttribute(incomingContext(w, r), in)
+	writeResponse(w, http.StatusCreated, res, err)
+}
+
+func (g *Gateway) getAttribute(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetAttribute(incomingContext(w, r), &cpb.AttributeId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) updateAttribute(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.Attribute{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.Id = id
+	res, err := g.server.UpdateAttribute(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) createAttributeSet(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.AttributeSet{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.CreateAttributeSet(incomingContext(w, r), in)
+	writeResponse(w, http.StatusCreated, res, err)
+}
+
+func (g *Gateway) getAttributeSet(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetAttributeSet(incomingContext(w, r), &cpb.AttributeSetId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) updateAttributeSet(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.AttributeSet{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.Id = id
+	res, err := g.server.UpdateAttributeSet(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) getProductAttributes(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetProductAttributes(incomingContext(w, r), &pb.ProductId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) setProductAttributes(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.ProductAttributes{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.ProductId = id
+	res, err := g.server.SetProductAttributes(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) listSynonymSets(w http.ResponseWriter, r *http.Request) {
+	res, err := g.server.ListSynonymSets(incomingContext(w, r), new(pb.Empty))
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) createSynonymSet(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.SynonymSet{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.CreateSynonymSet(incomingContext(w, r), in)
+	writeResponse(w, http.StatusCreated, res, err)
+}
+
+func (g *Gateway) getSynonymSet(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	res, err := g.server.GetSynonymSet(incomingContext(w, r), &cpb.SynonymSetId{Id: id})
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) updateSynonymSet(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	in := &cpb.SynonymSet{}
+	if !readBody(w, r, in) {
+		return
+	}
+	in.Id = id
+	res, err := g.server.UpdateSynonymSet(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) deleteSynonymSet(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	_, err := g.server.DeleteSynonymSet(incomingContext(w, r), &cpb.SynonymSetId{Id: id})
+	writeResponse(w, http.StatusNoContent, nil, err)
+}
+
+func (g *Gateway) getStopWords(w http.ResponseWriter, r *http.Request) {
+	res, err := g.server.GetStopWords(incomingContext(w, r), new(pb.Empty))
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+func (g *Gateway) setStopWords(w http.ResponseWriter, r *http.Request) {
+	in := &cpb.StopWords{}
+	if !readBody(w, r, in) {
+		return
+	}
+	res, err := g.server.SetStopWords(incomingContext(w, r), in)
+	writeResponse(w, http.StatusOK, res, err)
+}
+
+// incomingContext exposes selected request headers to the handlers the same
+// way gRPC metadata would arrive, header metadata set by the handlers is
+// written to w.
+func incomingContext(w http.ResponseWriter, r *http.Request) context.Context {
+	md := metadata.MD{}
+	for name, values := range r.Header {
+		if strings.HasPrefix(name, metadataHeaderPrefix) {
+			md.Append(strings.TrimPrefix(name, metadataHeaderPrefix), values...)
+		}
+	}
+	for _, name := range forwardedHeaders {
+		if values := r.Header.Values(name); len(values) > 0 {
+			md.Append(name, values...)
+		}
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	return grpc.NewContextWithServerTransportStream(ctx, &headerStream{w: w, method: r.Method + " " + r.URL.Path})
+}
+
+// headerStream writes header metadata as Grpc-Metadata-* response headers,
+// the product revision also becomes the ETag.
+type headerStream struct {
+	w      http.ResponseWriter
+	method string
+}
+
+func (s *headerStream) Method() string {
+	return s.method
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	h := s.w.Header()
+	for key, values := range md {
+		for _, value := range values {
+			h.Add(metadataHeaderPrefix+key, value)
+		}
+		if key == revisionHeader && len(values) > 0 {
+			h.Set("ETag", strconv.Quote(values[0]))
+		}
+	}
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *headerStream) SetTrailer(metadata.MD) error {
+	return nil
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, toStatus(InvalidArgumentError("invalid id", FieldViolation{Field: "id", Description: "must be a positive integer"})))
+		return 0, false
+	}
+	return id, true
+}
+
+func readBody(w http.ResponseWriter, r *http.Request, dest proto.Message) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err == nil {
+		err = jsonUnmarshal.Unmarshal(body, dest)
+	}
+	if err != nil {
+		writeError(w, toStatus(InvalidArgumentError("invalid request body: "+err.Error())))
+		return false
+	}
+	return true
+}
+
+// readRevision puts the revision in the If-Match header into revision unless
+// the body named one. Without either the server asks for the revision.
+func readRevision(w http.ResponseWriter, r *http.Request, revision *uint64) bool {
+	value := r.Header.Get("If-Match")
+	if *revision != 0 || value == "" {
+		return true
+	}
+	parsed, err := parseIfMatch(value)
+	if err != nil {
+		writeError(w, toStatus(err))
+		return false
+	}
+	*revision = parsed
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, code int, res proto.Message, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if res == nil || code == http.StatusNoContent {
+		w.WriteHeader(code)
+		return
+	}
+	writeJSON(w, code, res)
+}
+
+// writeError writes the google.rpc.Status of err with the HTTP equivalent of its code.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeJSON(w, HTTPStatusFromCode(st.Code()), st.Proto())
+}
+
+func writeJSON(w http.ResponseWriter, code int, message proto.Message) {
+	body, err := jsonMarshal.Marshal(message)
+	if err != nil {
+		log.Printf("Failed to marshal response. Error: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("Failed to write response. Error: %v", err)
+	}
+}
+
+// HTTPStatusFromCode maps gRPC codes to HTTP statuses following google.rpc.Code.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // Client Closed Request
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
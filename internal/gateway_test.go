@@ -0,0 +1,512 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGateway(t *testing.T) {
+	// given
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		ifMatch        string
+		authorization  string
+		expectedStatus int
+		expectedBody   string
+		setup          func() *ProductServiceMock
+	}{
+		{
+			name:           "Get a product",
+			method:         http.MethodGet,
+			path:           "/v1/products/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","name":"Test Product","sku":"test-sku","description":"","price":10,"image":""}`,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(&DbProduct{ID: 1, Name: "Test Product", Sku: "test-sku", Price: Money{Amount: 1000, Currency: "USD"}}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Set product prices",
+			method:         http.MethodPut,
+			path:           "/v1/products/1/prices",
+			body:           `{"prices":[{"currency":"EUR","amount":"17.49"}]}`,
+			ifMatch:        `"2"`,
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{}`,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("SetProductPrices", mock.Anything, uint64(1), uint64(2), []Money{{Amount: 1749, Currency: "EUR"}}).Return(nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Set product prices with a malformed If-Match",
+			method:         http.MethodPut,
+			path:           "/v1/products/1/prices",
+			body:           `{"prices":[{"currency":"EUR","amount":"17.49"}]}`,
+			ifMatch:        "latest",
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusBadRequest,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:           "Product sub-resource and SKU routes do not clash",
+			method:         http.MethodGet,
+			path:           "/v1/products/sku/prices",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"1","name":"Test Product","sku":"prices","description":"","price":10,"image":""}`,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductBySKU", mock.Anything, "prices").Return(&DbProduct{ID: 1, Name: "Test Product", Sku: "prices", Price: Money{Amount: 1000, Currency: "USD"}}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Unknown product sub-resource",
+			method:         http.MethodGet,
+			path:           "/v1/products/1/reviews",
+			expectedStatus: http.StatusNotFound,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:           "Get a missing product",
+			method:         http.MethodGet,
+			path:           "/v1/products/2",
+			expectedStatus: http.StatusNotFound,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("GetProductByID", mock.Anything, uint64(2)).Return(nil, NotFoundError(ResourceProduct, 2))
+				return mockProductService
+			},
+		},
+		{
+			name:           "Get a product with a malformed id",
+			method:         http.MethodGet,
+			path:           "/v1/products/abc",
+			expectedStatus: http.StatusBadRequest,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:           "Create a product",
+			method:         http.MethodPost,
+			path:           "/v1/products",
+			body:           `{"name":"Test Product","sku":"test-sku","price":19.99}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"5"}`,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("CreateProduct", mock.Anything, &DbProduct{Name: "Test Product", Sku: "test-sku", Price: Money{Amount: 1999, Currency: "USD"}}).Return(uint64(5), nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Create a product with a malformed body",
+			method:         http.MethodPost,
+			path:           "/v1/products",
+			body:           `{"name":`,
+			expectedStatus: http.StatusBadRequest,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:           "Update a product",
+			method:         http.MethodPut,
+			path:           "/v1/products/1",
+			body:           `{"name":"Test Product","sku":"test-sku"}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusOK,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("InTransaction", mock.Anything).Return(nil)
+				mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(&DbProduct{ID: 1}, nil)
+				mockProductService.On("UpdateProduct", mock.Anything, &DbProduct{ID: 1, Name: "Test Product", Sku: "test-sku", Price: Money{Currency: "USD"}, Revision: 1}).Return(nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Delete a product",
+			method:         http.MethodDelete,
+			path:           "/v1/products/1",
+			ifMatch:        `"2"`,
+			expectedStatus: http.StatusNoContent,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("DeleteProductByID", mock.Anything, uint64(1), uint64(2)).Return(nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "List products",
+			method:         http.MethodGet,
+			path:           "/v1/products?page_size=1&order_by=name&min_price=5",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"products":[{"id":"1","name":"Test Product","sku":"","description":"","price":10,"image":""}],"next_page_token":"next"}`,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("ListProducts", mock.Anything, ListQuery{PageSize: 1, SortBy: SortByName, MinPrice: &Money{Amount: 500, Currency: "USD"}, Public: true}).
+					Return(&ListPage{Products: []*DbProduct{{ID: 1, Name: "Test Product", Price: Money{Amount: 1000, Currency: "USD"}}}, NextPageToken: "next"}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Search products",
+			method:         http.MethodGet,
+			path:           "/v1/search?q=mug&page_size=5",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"hits":[{"product":{"id":"3","name":"Coffee Mug","sku":"","description":"","price":5,"image":""},"score":1.5}],"total_size":1,"next_page_token":""}`,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("SearchProducts", mock.Anything, SearchQuery{Text: "mug", PageSize: 5, Public: true}).
+					Return(&SearchPage{Hits: []SearchHit{{ProductID: 3, Score: 1.5}}, Products: []*DbProduct{{ID: 3, Name: "Coffee Mug", Price: Money{Amount: 500, Currency: "USD"}}}, TotalHits: 1}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Suggest products",
+			method:         http.MethodGet,
+			path:           "/v1/suggest?prefix=mu&limit=3",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"suggestions":[{"text":"Coffee Mug","kind":"name","product_id":"3"}]}`,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("SuggestProducts", mock.Anything, "mu", 3).
+					Return([]Suggestion{{Text: "Coffee Mug", Kind: SuggestionName, ProductID: 3}}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Rebuild search index",
+			method:         http.MethodPost,
+			path:           "/v1/search/rebuild",
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"indexed":"3"}`,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("RebuildSearchIndex", mock.Anything).Return(3, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Rebuild search index without the admin token",
+			method:         http.MethodPost,
+			path:           "/v1/search/rebuild",
+			expectedStatus: http.StatusUnauthorized,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:           "Facet products",
+			method:         http.MethodGet,
+			path:           "/v1/facets?attr.color=red&attr.color=blue&facet=color&price_range=-10",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"products":[],"next_page_token":"","attributes":[{"code":"color","values":[{"value":"red","count":"2"}]}],"price_ranges":[{"range":{"to":10},"count":"2"}]}`,
+			setup: func() *ProductServiceMock {
+				mockProductService := new(ProductServiceMock)
+				mockProductService.On("FacetProducts", mock.Anything, FacetQuery{
+					ListQuery:       ListQuery{SortBy: SortByID, Attributes: map[string][]string{"color": {"red", "blue"}}, Public: true},
+					FacetAttributes: []string{"color"},
+					PriceRanges:     []PriceRange{{To: &Money{Amount: 1000, Currency: "USD"}}},
+				}).Return(&FacetPage{
+					Attributes:  []AttributeFacet{{Code: "color", Values: []FacetValue{{Value: "red", Count: 2}}}},
+					PriceRanges: []PriceRangeCount{{PriceRange: PriceRange{To: &Money{Amount: 1000, Currency: "USD"}}, Count: 2}},
+				}, nil)
+				return mockProductService
+			},
+		},
+		{
+			name:           "Facet products with a malformed price range",
+			method:         http.MethodGet,
+			path:           "/v1/facets?price_range=10",
+			expectedStatus: http.StatusBadRequest,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+		{
+			name:           "List products with a malformed price",
+			method:         http.MethodGet,
+			path:           "/v1/products?max_price=cheap",
+			expectedStatus: http.StatusBadRequest,
+			setup: func() *ProductServiceMock {
+				return new(ProductServiceMock)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			mockProductService := tc.setup()
+			gateway := NewGateway(&Server{ProductService: mockProductService, AdminToken: "secret"}, CORSConfig{})
+			rec := httptest.NewRecorder()
+			request := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+			if tc.authorization != "" {
+				request.Header.Set("Authorization", tc.authorization)
+			}
+			gateway.ServeHTTP(rec, request)
+
+			// then
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+			if rec.Code >= http.StatusBadRequest {
+				var body map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Contains(t, body, "code")
+			}
+			mockProductService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGateway_CORS(t *testing.T) {
+	// given
+	gateway := NewGateway(&Server{ProductService: new(ProductServiceMock)}, CORSConfig{
+		AllowedOrigins: []string{"https://shop.example.com"},
+		MaxAge:         time.Hour,
+	})
+
+	t.Run("Preflight from an allowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/products", nil)
+		req.Header.Set("Origin", "https://shop.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		rec := httptest.NewRecorder()
+		//when
+		gateway.ServeHTTP(rec, req)
+		//then
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://shop.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "3600", rec.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("Request from an unknown origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/products", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		rec := httptest.NewRecorder()
+		//when
+		gateway.ServeHTTP(rec, req)
+		//then
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestGateway_Variants(t *testing.T) {
+	// given
+	mockVariantService := new(VariantServiceMock)
+	mockVariantService.On("CreateVariant", mock.Anything, &DbVariant{ProductID: 1, Sku: "tee-m", Options: VariantOptions{"size": "M"}}).Return(uint64(3), nil)
+	mockVariantService.On("DeleteVariantByID", mock.Anything, uint64(3)).Return(nil)
+	mockVariantService.On("SetProductOptions", mock.Anything, uint64(1), []DbProductOption{{Name: "size", Values: []string{"S", "M"}}}).Return(nil)
+	mockVariantService.On("GetProductVariants", mock.Anything, uint64(1)).Return(&ProductVariants{
+		Product:  &DbProduct{ID: 1, Name: "Tee"},
+		Variants: []*DbVariant{{ID: 3, ProductID: 1, Sku: "tee-m", Options: VariantOptions{"size": "M"}}},
+	}, nil)
+	gateway := NewGateway(&Server{VariantService: mockVariantService}, CORSConfig{})
+
+	// when
+	options := httptest.NewRecorder()
+	gateway.ServeHTTP(options, httptest.NewRequest(http.MethodPut, "/v1/products/1/options", strings.NewReader(`{"options":[{"name":"size","values":["S","M"]}]}`)))
+	created := httptest.NewRecorder()
+	gateway.ServeHTTP(created, httptest.NewRequest(http.MethodPost, "/v1/variants", strings.NewReader(`{"product_id":"1","sku":"tee-m","options":{"size":"M"}}`)))
+	listed := httptest.NewRecorder()
+	gateway.ServeHTTP(listed, httptest.NewRequest(http.MethodGet, "/v1/products/1/variants", nil))
+	deleted := httptest.NewRecorder()
+	gateway.ServeHTTP(deleted, httptest.NewRequest(http.MethodDelete, "/v1/variants/3", nil))
+
+	// then
+	assert.Equal(t, http.StatusOK, options.Code)
+	assert.Equal(t, http.StatusCreated, created.Code)
+	assert.JSONEq(t, `{"id":"3"}`, created.Body.String())
+	assert.Equal(t, http.StatusOK, listed.Code)
+	assert.Contains(t, listed.Body.String(), `"sku":"tee-m"`)
+	assert.Equal(t, http.StatusNoContent, deleted.Code)
+	mockVariantService.AssertExpectations(t)
+}
+
+func TestGateway_ProductAttributes(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(&DbProduct{ID: 1}, nil)
+	mockAttributeService := new(AttributeServiceMock)
+	mockAttributeService.On("GetProductAttributes", mock.Anything, uint64(1)).Return(&ProductAttributes{ProductID: 1, AttributeSetID: 2, Values: []AttributeValue{{Code: "color", Values: []string{"red"}}}}, nil)
+	gateway := NewGateway(&Server{ProductService: mockProductService, AttributeService: mockAttributeService}, CORSConfig{})
+
+	// when
+	rec := httptest.NewRecorder()
+	gateway.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/products/1/attributes", nil))
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"product_id":"1","attribute_set_id":"2","values":[{"code":"color","values":["red"]}]}`, rec.Body.String())
+	mockAttributeService.AssertExpectations(t)
+}
+
+func TestGateway_Lifecycle(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("SetProductLifecycle", mock.Anything, uint64(1), uint64(3), ProductArchived, VisibilityHidden).Return(nil)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(2)).Return(&DbProduct{ID: 2, Status: ProductDraft, Visibility: VisibilityBoth}, nil)
+	gateway := NewGateway(&Server{ProductService: mockProductService, AdminToken: "secret"}, CORSConfig{})
+
+	// when
+	updated := httptest.NewRecorder()
+	update := httptest.NewRequest(http.MethodPut, "/v1/products/1/lifecycle", strings.NewReader(`{"status":"PRODUCT_STATUS_ARCHIVED","visibility":"PRODUCT_VISIBILITY_HIDDEN"}`))
+	update.Header.Set("Authorization", "Bearer secret")
+	update.Header.Set("If-Match", `"3"`)
+	gateway.ServeHTTP(updated, update)
+	anonymous := httptest.NewRecorder()
+	gateway.ServeHTTP(anonymous, httptest.NewRequest(http.MethodPut, "/v1/products/1/lifecycle", strings.NewReader(`{"status":"PRODUCT_STATUS_ACTIVE"}`)))
+	public := httptest.NewRecorder()
+	gateway.ServeHTTP(public, httptest.NewRequest(http.MethodGet, "/v1/products/2", nil))
+	admin := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/v1/products/2", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	gateway.ServeHTTP(admin, request)
+
+	// then
+	assert.Equal(t, http.StatusOK, updated.Code)
+	assert.Equal(t, http.StatusUnauthorized, anonymous.Code)
+	assert.Equal(t, http.StatusNotFound, public.Code)
+	assert.Equal(t, http.StatusOK, admin.Code)
+	mockProductService.AssertExpectations(t)
+}
+
+func TestGateway_PatchProduct(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(&DbProduct{ID: 1, Name: "Running Shirt", Sku: "RS-1", Description: "Breathable mesh", Price: Money{Amount: 1999, Currency: "USD"}}, nil)
+	mockProductService.On("PatchProduct", mock.Anything, uint64(1), mock.Anything, []ProductField{FieldDescription}).
+		Return(&DbProduct{ID: 1, Name: "Running Shirt", Sku: "RS-1", Revision: 4}, nil)
+	gateway := NewGateway(&Server{ProductService: mockProductService}, CORSConfig{})
+
+	// when
+	patched := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPatch, "/v1/products/1", strings.NewReader(`{"update_mask":"description"}`))
+	request.Header.Set("If-Match", `"3"`)
+	gateway.ServeHTTP(patched, request)
+
+	// then
+	assert.Equal(t, http.StatusOK, patched.Code)
+	assert.Equal(t, `"4"`, patched.Header().Get("ETag"))
+	assert.JSONEq(t, `{"id":"1","name":"Running Shirt","sku":"RS-1","description":"","price":0,"image":""}`, patched.Body.String())
+	mockProductService.AssertExpectations(t)
+}
+
+func TestGateway_Revisions(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(&DbProduct{ID: 1, Name: "Running Shirt", Revision: 7}, nil)
+	mockProductService.On("DeleteProductByID", mock.Anything, uint64(1), uint64(6)).Return(revisionConflict(1, 7, 6))
+	gateway := NewGateway(&Server{ProductService: mockProductService}, CORSConfig{})
+
+	// when
+	read := httptest.NewRecorder()
+	gateway.ServeHTTP(read, httptest.NewRequest(http.MethodGet, "/v1/products/1", nil))
+	stale := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodDelete, "/v1/products/1", nil)
+	request.Header.Set("If-Match", `"6"`)
+	gateway.ServeHTTP(stale, request)
+	unconditional := httptest.NewRecorder()
+	gateway.ServeHTTP(unconditional, httptest.NewRequest(http.MethodDelete, "/v1/products/1", nil))
+
+	// then
+	assert.Equal(t, http.StatusOK, read.Code)
+	assert.Equal(t, `"7"`, read.Header().Get("ETag"))
+	assert.Equal(t, "7", read.Header().Get("Grpc-Metadata-Revision"))
+	assert.Equal(t, http.StatusConflict, stale.Code)
+	assert.Equal(t, http.StatusBadRequest, unconditional.Code)
+	mockProductService.AssertExpectations(t)
+}
+
+func TestGateway_BatchGetProducts(t *testing.T) {
+	// given
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("GetProductsByIDs", mock.Anything, []uint64{1, 2}).
+		Return([]*DbProduct{{ID: 1, Name: "Running Shirt", Price: Money{Amount: 1999, Currency: "USD"}}}, []uint64{2}, nil)
+	gateway := NewGateway(&Server{ProductService: mockProductService}, CORSConfig{})
+
+	// when
+	rec := httptest.NewRecorder()
+	gateway.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/products/batch-get", strings.NewReader(`{"ids":["1","2"]}`)))
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"products":[{"id":"1","name":"Running Shirt","sku":"","description":"","price":19.99,"image":""}],"missing_ids":["2"]}`, rec.Body.String())
+	mockProductService.AssertExpectations(t)
+}
+
+func TestGateway_Availability(t *testing.T) {
+	// given
+	from := time.Date(2026, 11, 27, 8, 0, 0, 0, time.UTC)
+	mockProductService := new(ProductServiceMock)
+	mockProductService.On("SetProductAvailability", mock.Anything, uint64(1), uint64(5), &from, (*time.Time)(nil)).Return(nil)
+	mockProductService.On("GetProductByID", mock.Anything, uint64(1)).Return(&DbProduct{ID: 1, AvailableFrom: &from, Revision: 6}, nil)
+	gateway := NewGateway(&Server{ProductService: mockProductService, AdminToken: "secret"}, CORSConfig{})
+
+	// when
+	updated := httptest.NewRecorder()
+	update := httptest.NewRequest(http.MethodPut, "/v1/products/1/availability", strings.NewReader(`{"availableFrom":"2026-11-27T08:00:00Z"}`))
+	update.Header.Set("Authorization", "Bearer secret")
+	update.Header.Set("If-Match", `"5"`)
+	gateway.ServeHTTP(updated, update)
+	read := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/v1/products/1/availability", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	gateway.ServeHTTP(read, request)
+
+	// then
+	assert.Equal(t, http.StatusOK, updated.Code)
+	assert.Equal(t, http.StatusOK, read.Code)
+	assert.JSONEq(t, `{"product_id":"1","available_from":"2026-11-27T08:00:00Z","available_until":null,"revision":"6"}`, read.Body.String())
+	mockProductService.AssertExpectations(t)
+}
+
+func TestGateway_Synonyms(t *testing.T) {
+	// given
+	mockDictionaryService := new(DictionaryServiceMock)
+	mockDictionaryService.On("UpdateSynonymSet", mock.Anything, &DbSynonymSet{ID: 1, Type: SynonymEquivalent, Terms: []string{"tee", "t-shirt"}}).Return(nil)
+	mockDictionaryService.On("DeleteSynonymSetByID", mock.Anything, uint64(2)).Return(NotFoundError(ResourceSynonymSet, 2))
+	mockDictionaryService.On("GetStopWords", mock.Anything).Return([]string{"a", "the"}, nil)
+	gateway := NewGateway(&Server{DictionaryService: mockDictionaryService}, CORSConfig{})
+
+	// when
+	updated := httptest.NewRecorder()
+	gateway.ServeHTTP(updated, httptest.NewRequest(http.MethodPut, "/v1/synonyms/1", strings.NewReader(`{"type":"SYNONYM_TYPE_EQUIVALENT","terms":["tee","t-shirt"]}`)))
+	deleted := httptest.NewRecorder()
+	gateway.ServeHTTP(deleted, httptest.NewRequest(http.MethodDelete, "/v1/synonyms/2", nil))
+	stopWords := httptest.NewRecorder()
+	gateway.ServeHTTP(stopWords, httptest.NewRequest(http.MethodGet, "/v1/stop-words", nil))
+
+	// then
+	assert.Equal(t, http.StatusOK, updated.Code)
+	assert.Equal(t, http.StatusNotFound, deleted.Code)
+	assert.Equal(t, http.StatusOK, stopWords.Code)
+	assert.JSONEq(t, `{"words":["a","the"]}`, stopWords.Body.String())
+	mockDictionaryService.AssertExpectations(t)
+}
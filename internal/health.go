@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Pinger is satisfied by *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// HealthStatusSetter is satisfied by *health.Server.
+type HealthStatusSetter interface {
+	SetServingStatus(service string, servingStatus healthpb.HealthCheckResponse_ServingStatus)
+}
+
+// DbHealthChecker pings the database periodically and publishes the result
+// as the serving status of Services ("" is the overall server status).
+type DbHealthChecker struct {
+	DB       Pinger
+	Health   HealthStatusSetter
+	Services []string
+	Interval time.Duration
+	Timeout  time.Duration
+
+	last healthpb.HealthCheckResponse_ServingStatus
+}
+
+// Check pings the database once and updates the serving status.
+func (c *DbHealthChecker) Check(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+	if err := c.DB.PingContext(ctx); err != nil {
+		servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		if c.last != servingStatus {
+			log.Printf("Database ping failed, reporting %v. Error: %v", servingStatus, err)
+		}
+	} else if c.last != servingStatus && c.last != healthpb.HealthCheckResponse_UNKNOWN {
+		log.Printf("Database is reachable again, reporting %v.", servingStatus)
+	}
+	c.last = servingStatus
+
+	for _, service := range c.Services {
+		c.Health.SetServingStatus(service, servingStatus)
+	}
+	return servingStatus
+}
+
+// Run checks the database every Interval until ctx is done.
+func (c *DbHealthChecker) Run(ctx context.Context) {
+	interval := c.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c.Check(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Check(ctx)
+		}
+	}
+}
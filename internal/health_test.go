@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type pingerMock struct {
+	mock.Mock
+}
+
+func (p *pingerMock) PingContext(ctx context.Context) error {
+	return p.Called(ctx).Error(0)
+}
+
+func TestDbHealthChecker_Check(t *testing.T) {
+	// given
+	tests := []struct {
+		name       string
+		pingErr    error
+		wantStatus healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:       "Database is reachable",
+			wantStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:       "Database is down",
+			pingErr:    errors.New("connection refused"),
+			wantStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pinger := new(pingerMock)
+			pinger.On("PingContext", mock.Anything).Return(tt.pingErr)
+			healthServer := health.NewServer()
+			checker := &DbHealthChecker{DB: pinger, Health: healthServer, Services: []string{"", "product.ProductInfo"}}
+			//when
+			got := checker.Check(context.Background())
+			//then
+			assert.Equal(t, tt.wantStatus, got)
+			for _, service := range checker.Services {
+				res, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, res.Status)
+			}
+		})
+	}
+}
+
+func TestDbHealthChecker_Run(t *testing.T) {
+	// given
+	pinger := new(pingerMock)
+	pinger.On("PingContext", mock.Anything).Return(errors.New("connection refused")).Once()
+	pinger.On("PingContext", mock.Anything).Return(nil)
+	healthServer := health.NewServer()
+	checker := &DbHealthChecker{DB: pinger, Health: healthServer, Services: []string{"product.ProductInfo"}, Interval: time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	//when
+	go checker.Run(ctx)
+	//then
+	assert.Eventually(t, func() bool {
+		res, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "product.ProductInfo"})
+		return err == nil && res.Status == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultIdempotencyTTL is how long an idempotency key is remembered when
+// ProductService.IdempotencyTTL is not set.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// DefaultIdempotencyPurgeInterval is how often RunIdempotencyPurge forgets
+// expired keys when ProductService.IdempotencyPurgeInterval is not set.
+const DefaultIdempotencyPurgeInterval = time.Hour
+
+// MaxIdempotencyKeyLength is the longest idempotency key that can be stored.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey identifies a create request a client may retry.
+type IdempotencyKey struct {
+	Key string
+	// RequestHash tells apart different requests sent with the same key.
+	RequestHash string
+}
+
+// DbIdempotencyKey remembers the product created for an idempotency key
+// until ExpiresAt.
+type DbIdempotencyKey struct {
+	Key         string `gorm:"primaryKey;size:255"`
+	RequestHash string `gorm:"size:64;not null"`
+	ProductID   uint64
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"index"`
+}
+
+func (DbIdempotencyKey) TableName() string {
+	return "catalog_idempotency_keys"
+}
+
+func (p *ProductService) idempotencyTTL() time.Duration {
+	if p.IdempotencyTTL <= 0 {
+		return DefaultIdempotencyTTL
+	}
+	return p.IdempotencyTTL
+}
+
+// Create a new DbProduct unless key was used before. A replay of the same
+// request returns the ID of the product created the first time and reports
+// replayed, reusing the key for a different request is rejected.
+func (p *ProductService) CreateProductOnce(ctx context.Context, key IdempotencyKey, product *DbProduct) (uint64, bool, error) {
+	product.Status, product.Visibility = product.lifecycle()
+	product.Revision = 1
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	now := time.Now()
+	stored := DbIdempotencyKey{}
+	replayed := false
+	err := db.Transaction(func(tx DbWrapper) error {
+		err := tx.Scopes(forUpdate).Where(&DbIdempotencyKey{Key: key.Key}).First(&stored).Error
+		switch {
+		case err == nil && stored.ExpiresAt.After(now):
+			if stored.RequestHash != key.RequestHash {
+				return &Error{Kind: KindFailedPrecondition, Message: fmt.Sprintf("idempotency key %q was used for a different request", key.Key)}
+			}
+			replayed = true
+			return nil
+		case err == nil:
+			if err := tx.Delete(&stored).Error; err != nil {
+				return err
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+		if err := tx.Create(product).Error; err != nil {
+			return err
+		}
+		stored = DbIdempotencyKey{Key: key.Key, RequestHash: key.RequestHash, ProductID: product.ID, ExpiresAt: now.Add(p.idempotencyTTL())}
+		if err := tx.Create(&stored).Error; err != nil {
+			if isDuplicateOf(err, "PRIMARY") {
+				return ConflictError(ResourceIdempotencyKey, key.Key, "a request with the same idempotency key is in progress, retry it")
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return ErrorId, false, skuError(classifyDbError(ctx, fmt.Errorf("failed to create a product: %w", err), ResourceProduct, nil), product.Sku)
+	}
+	if replayed {
+		return stored.ProductID, true, nil
+	}
+	p.indexProduct(product)
+	return product.ID, false, nil
+}
+
+// PurgeIdempotencyKeys forgets the idempotency keys expired at now and
+// returns how many there were.
+func (p *ProductService) PurgeIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	result := db.Where("expires_at <= ?", now).Delete(&DbIdempotencyKey{})
+	if result.Error != nil {
+		return 0, classifyDbError(ctx, fmt.Errorf("failed to purge idempotency keys: %w", result.Error), ResourceIdempotencyKey, nil)
+	}
+	return result.RowsAffected, nil
+}
+
+// RunIdempotencyPurge purges expired idempotency keys every
+// IdempotencyPurgeInterval until ctx is done.
+func (p *ProductService) RunIdempotencyPurge(ctx context.Context) {
+	interval := p.IdempotencyPurgeInterval
+	if interval <= 0 {
+		interval = DefaultIdempotencyPurgeInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purged, err := p.PurgeIdempotencyKeys(ctx, now)
+			if err != nil {
+				// Expired keys are not used again, they are purged on the next tick.
+				log.Printf("Failed to purge idempotency keys. Error: %v", err)
+				continue
+			}
+			if purged > 0 {
+				log.Printf("Purged %v expired idempotency keys.", purged)
+			}
+		}
+	}
+}
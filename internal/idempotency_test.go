@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductService_CreateProductOnce(t *testing.T) {
+	key := IdempotencyKey{Key: "retry-1", RequestHash: "hash"}
+	selectKey := regexp.QuoteMeta("SELECT * FROM `catalog_idempotency_keys` WHERE `catalog_idempotency_keys`.`key` = ? ORDER BY `catalog_idempotency_keys`.`key` LIMIT ? FOR UPDATE")
+
+	t.Run("New key creates the product", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex(), IdempotencyTTL: time.Hour}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(selectKey).
+			WithArgs("retry-1", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"key"}))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_products`")).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_idempotency_keys` (`key`,`request_hash`,`product_id`,`created_at`,`expires_at`) VALUES (?,?,?,?,?)")).
+			WithArgs("retry-1", "hash", 7, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+		//when
+		id, replayed, err := ps.CreateProductOnce(context.Background(), key, &DbProduct{Name: "Desk Lamp", Sku: "DL-1"})
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), id)
+		assert.False(t, replayed)
+		assert.Equal(t, []uint64{7}, hitIDs(ps.Index.Search("lamp")))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Retry returns the original product", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(selectKey).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "request_hash", "product_id", "expires_at"}).
+				AddRow("retry-1", "hash", 7, time.Now().Add(time.Hour)))
+		sqlMock.ExpectCommit()
+		//when
+		id, replayed, err := ps.CreateProductOnce(context.Background(), key, &DbProduct{Name: "Desk Lamp", Sku: "DL-1"})
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), id)
+		assert.True(t, replayed)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Key reused for a different request", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(selectKey).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "request_hash", "product_id", "expires_at"}).
+				AddRow("retry-1", "other", 7, time.Now().Add(time.Hour)))
+		sqlMock.ExpectRollback()
+		//when
+		_, _, err := ps.CreateProductOnce(context.Background(), key, &DbProduct{Name: "Desk Lamp", Sku: "DL-1"})
+		//then
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, KindFailedPrecondition, e.Kind)
+		assert.Equal(t, `idempotency key "retry-1" was used for a different request`, e.PublicMessage())
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Expired key is used again", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(selectKey).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "request_hash", "product_id", "expires_at"}).
+				AddRow("retry-1", "other", 3, time.Now().Add(-time.Minute)))
+		sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_idempotency_keys` WHERE `catalog_idempotency_keys`.`key` = ?")).
+			WithArgs("retry-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_products`")).
+			WillReturnResult(sqlmock.NewResult(8, 1))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_idempotency_keys`")).
+			WithArgs("retry-1", "hash", 8, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+		//when
+		id, replayed, err := ps.CreateProductOnce(context.Background(), key, &DbProduct{Name: "Desk Lamp", Sku: "DL-1"})
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8), id)
+		assert.False(t, replayed)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Concurrent request with the same key", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(selectKey).
+			WillReturnRows(sqlmock.NewRows([]string{"key"}))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_products`")).
+			WillReturnResult(sqlmock.NewResult(9, 1))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_idempotency_keys`")).
+			WillReturnError(&mysql.MySQLError{Number: mysqlErrDuplicateEntry, Message: "Duplicate entry 'retry-1' for key 'catalog_idempotency_keys.PRIMARY'"})
+		sqlMock.ExpectRollback()
+		//when
+		_, _, err := ps.CreateProductOnce(context.Background(), key, &DbProduct{Name: "Desk Lamp", Sku: "DL-1"})
+		//then
+		assert.Equal(t, KindConflict, KindOf(err))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestProductService_PurgeIdempotencyKeys(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	ps := &ProductService{DB: GormWrapper{DB: db}}
+	now := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_idempotency_keys` WHERE expires_at <= ?")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	sqlMock.ExpectCommit()
+	//when
+	purged, err := ps.PurgeIdempotencyKeys(context.Background(), now)
+	//then
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestProductService_RunIdempotencyPurge(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	ps := &ProductService{DB: GormWrapper{DB: db}, IdempotencyPurgeInterval: time.Millisecond}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_idempotency_keys` WHERE expires_at <= ?")).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	sqlMock.ExpectCommit()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	//when
+	go ps.RunIdempotencyPurge(ctx)
+	//then
+	assert.Eventually(t, func() bool {
+		return sqlMock.ExpectationsWereMet() == nil
+	}, time.Second, time.Millisecond)
+}
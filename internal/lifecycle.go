@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProductStatus is the lifecycle stage of a product.
+type ProductStatus string
+
+const (
+	// ProductDraft is staged before launch and not shown to the public.
+	ProductDraft ProductStatus = "draft"
+	// ProductActive is live, where the public sees it depends on its visibility.
+	ProductActive ProductStatus = "active"
+	// ProductArchived is discontinued and no longer shown to the public.
+	ProductArchived ProductStatus = "archived"
+)
+
+// ProductVisibility tells where the public finds an active product.
+type ProductVisibility string
+
+const (
+	// VisibilityCatalog lists the product but leaves it out of search.
+	VisibilityCatalog ProductVisibility = "catalog"
+	// VisibilitySearch finds the product by search but does not list it.
+	VisibilitySearch ProductVisibility = "search"
+	VisibilityBoth   ProductVisibility = "both"
+	// VisibilityHidden keeps the product out of listings, search and lookups.
+	VisibilityHidden ProductVisibility = "hidden"
+)
+
+// statusTransitions are the statuses a product can move to. A launched
+// product does not go back to draft, archived products can be reactivated.
+var statusTransitions = map[ProductStatus][]ProductStatus{
+	ProductDraft:    {ProductActive, ProductArchived},
+	ProductActive:   {ProductArchived},
+	ProductArchived: {ProductActive},
+}
+
+// Visibilities listed in the catalog and found by search.
+var (
+	catalogVisibilities = []ProductVisibility{VisibilityCatalog, VisibilityBoth}
+	searchVisibilities  = []ProductVisibility{VisibilitySearch, VisibilityBoth}
+)
+
+func (s ProductStatus) valid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// canBecome reports whether the status may change to next, staying is allowed.
+func (s ProductStatus) canBecome(next ProductStatus) bool {
+	if s == next {
+		return true
+	}
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (v ProductVisibility) valid() bool {
+	switch v {
+	case VisibilityCatalog, VisibilitySearch, VisibilityBoth, VisibilityHidden:
+		return true
+	}
+	return false
+}
+
+// lifecycle returns the status and visibility of the product. Products
+// written before they had a lifecycle are active and visible everywhere.
+func (p *DbProduct) lifecycle() (ProductStatus, ProductVisibility) {
+	status, visibility := p.Status, p.Visibility
+	if status == "" {
+		status = ProductActive
+	}
+	if visibility == "" {
+		visibility = VisibilityBoth
+	}
+	return status, visibility
+}
+
+// Public reports whether the public can look the product up by id or SKU
+// at the given time.
+func (p *DbProduct) Public(at time.Time) bool {
+	status, visibility := p.lifecycle()
+	return status == ProductActive && visibility != VisibilityHidden && p.availableAt(at)
+}
+
+// InCatalog reports whether the product is listed to the public at the given time.
+func (p *DbProduct) InCatalog(at time.Time) bool {
+	status, visibility := p.lifecycle()
+	return status == ProductActive && (visibility == VisibilityCatalog || visibility == VisibilityBoth) && p.availableAt(at)
+}
+
+// InSearch reports whether the public finds the product by search at the given time.
+func (p *DbProduct) InSearch(at time.Time) bool {
+	status, visibility := p.lifecycle()
+	return status == ProductActive && (visibility == VisibilitySearch || visibility == VisibilityBoth) && p.availableAt(at)
+}
+
+// publicScope limits a query to active products with one of visibilities
+// that are available at the given time.
+func publicScope(visibilities []ProductVisibility, at time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ? AND visibility IN ?", ProductActive, visibilities).
+			Where("available_from IS NULL OR available_from <= ?", at).
+			Where("available_until IS NULL OR available_until > ?", at)
+	}
+}
+
+// Change the status and visibility of a DbProduct, empty values are kept.
+// Status changes must follow statusTransitions. The product must still have
+// revision.
+func (p *ProductService) SetProductLifecycle(ctx context.Context, id, revision uint64, status ProductStatus, visibility ProductVisibility) error {
+	var violations []FieldViolation
+	if status != "" && !status.valid() {
+		violations = append(violations, FieldViolation{Field: "status", Description: "must be draft, active or archived"})
+	}
+	if visibility != "" && !visibility.valid() {
+		violations = append(violations, FieldViolation{Field: "visibility", Description: "must be catalog, search, both or hidden"})
+	}
+	if len(violations) > 0 {
+		return InvalidArgumentError("invalid product lifecycle", violations...)
+	}
+
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	product := DbProduct{}
+	var wasPublic bool
+	err := db.Transaction(func(tx DbWrapper) error {
+		if err := tx.Scopes(forUpdate).First(&product, id).Error; err != nil {
+			return err
+		}
+		wasPublic = product.Public(time.Now())
+		current, _ := product.lifecycle()
+		if status == "" {
+			status = current
+		}
+		if visibility == "" {
+			_, visibility = product.lifecycle()
+		}
+		if !current.canBecome(status) {
+			return InvalidArgumentError("invalid status transition", FieldViolation{Field: "status", Description: fmt.Sprintf("cannot change from %s to %s", current, status)})
+		}
+		stored := product.Revision
+		product.Status, product.Visibility, product.UpdatedAt = status, visibility, time.Now()
+		product.Revision++
+		// Hooks are skipped as the SKU does not change.
+		updates := map[string]interface{}{"status": status, "visibility": visibility, "revision": gorm.Expr("revision + 1"), "updated_at": product.UpdatedAt}
+		result := tx.Scopes(withoutHooks).Model(&product).Where("revision = ?", revision).Updates(updates)
+		if result.Error == nil && result.RowsAffected == 0 {
+			return revisionConflict(id, stored, revision)
+		}
+		return result.Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to set the lifecycle of a product %d: %w", id, err), ResourceProduct, id)
+	}
+	p.indexProduct(&product)
+	p.publishChange(&product, wasPublic, product.UpdatedAt)
+	return nil
+}
@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductStatus_CanBecome(t *testing.T) {
+	tests := []struct {
+		from, to ProductStatus
+		want     bool
+	}{
+		{from: ProductDraft, to: ProductActive, want: true},
+		{from: ProductDraft, to: ProductArchived, want: true},
+		{from: ProductActive, to: ProductArchived, want: true},
+		{from: ProductArchived, to: ProductActive, want: true},
+		{from: ProductActive, to: ProductActive, want: true},
+		{from: ProductActive, to: ProductDraft},
+		{from: ProductArchived, to: ProductDraft},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.canBecome(tt.to))
+		})
+	}
+}
+
+func TestDbProduct_Visibility(t *testing.T) {
+	tests := []struct {
+		name                        string
+		product                     DbProduct
+		public, inCatalog, inSearch bool
+	}{
+		{name: "Stored before the lifecycle", product: DbProduct{}, public: true, inCatalog: true, inSearch: true},
+		{name: "Active in the catalog only", product: DbProduct{Status: ProductActive, Visibility: VisibilityCatalog}, public: true, inCatalog: true},
+		{name: "Active in search only", product: DbProduct{Status: ProductActive, Visibility: VisibilitySearch}, public: true, inSearch: true},
+		{name: "Active but hidden", product: DbProduct{Status: ProductActive, Visibility: VisibilityHidden}},
+		{name: "Draft", product: DbProduct{Status: ProductDraft, Visibility: VisibilityBoth}},
+		{name: "Archived", product: DbProduct{Status: ProductArchived, Visibility: VisibilityBoth}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			assert.Equal(t, tt.public, tt.product.Public(now))
+			assert.Equal(t, tt.inCatalog, tt.product.InCatalog(now))
+			assert.Equal(t, tt.inSearch, tt.product.InSearch(now))
+		})
+	}
+}
+
+func TestProductService_SetProductLifecycle(t *testing.T) {
+	t.Run("Draft is activated and indexed", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex()}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ? AND `catalog_products`.`deleted_at` IS NULL ORDER BY `catalog_products`.`id` LIMIT ? FOR UPDATE")).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status", "visibility"}).AddRow(1, "Running Shirt", "draft", "catalog"))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `revision`=revision + 1,`status`=?,`updated_at`=?,`visibility`=?")).
+			WithArgs(ProductActive, sqlmock.AnyArg(), VisibilityBoth, 1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+		//when
+		err := ps.SetProductLifecycle(context.Background(), 1, 1, ProductActive, VisibilityBoth)
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1}, hitIDs(ps.Index.SearchPublic("shirt")))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Empty values are kept", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status", "visibility"}).AddRow(1, "active", "search"))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `revision`=revision + 1,`status`=?,`updated_at`=?,`visibility`=?")).
+			WithArgs(ProductArchived, sqlmock.AnyArg(), VisibilitySearch, 1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+		//when
+		err := ps.SetProductLifecycle(context.Background(), 1, 1, ProductArchived, "")
+		//then
+		require.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Changed product is not written", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status", "visibility", "revision"}).AddRow(1, "active", "both", 5))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `revision`=revision + 1,`status`=?,`updated_at`=?,`visibility`=? WHERE revision = ?")).
+			WithArgs(ProductArchived, sqlmock.AnyArg(), VisibilityBoth, 4, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectRollback()
+		//when
+		err := ps.SetProductLifecycle(context.Background(), 1, 4, ProductArchived, "")
+		//then
+		assert.Equal(t, KindConflict, KindOf(err))
+		assert.EqualError(t, err, "failed to set the lifecycle of a product 1: product 1 was changed, its revision is 5 not 4")
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Active product cannot return to draft", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status", "visibility"}).AddRow(1, "active", "both"))
+		sqlMock.ExpectRollback()
+		//when
+		err := ps.SetProductLifecycle(context.Background(), 1, 1, ProductDraft, "")
+		//then
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, KindInvalidArgument, e.Kind)
+		assert.Equal(t, []FieldViolation{{Field: "status", Description: "cannot change from active to draft"}}, e.Violations)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown values are rejected without a query", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		//when
+		err := ps.SetProductLifecycle(context.Background(), 1, 1, "live", "everywhere")
+		//then
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Len(t, e.Violations, 2)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
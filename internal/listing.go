@@ -0,0 +1,295 @@
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SortField is a column products can be listed by.
+type SortField string
+
+const (
+	SortByID        SortField = "id"
+	SortByName      SortField = "name"
+	SortByPrice     SortField = "price"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// categorySubtreeCondition matches products assigned to a category or to any
+// of its descendants, found by the materialized path prefix of the category.
+const categorySubtreeCondition = "id IN (SELECT pc.product_id FROM catalog_product_categories pc " +
+	"JOIN catalog_categories c ON c.id = pc.category_id " +
+	"JOIN catalog_categories root ON c.path LIKE CONCAT(root.path, '%') " +
+	"WHERE root.id = ?)"
+
+// attributeValueCondition matches products having one of the values of an attribute.
+const attributeValueCondition = "id IN (SELECT v.product_id FROM catalog_product_attribute_values v " +
+	"JOIN catalog_attributes a ON a.id = v.attribute_id " +
+	"WHERE a.code = ? AND v.value IN ?)"
+
+// ListQuery describes a page of the product listing.
+type ListQuery struct {
+	// PageSize is capped at MaxPageSize, zero means DefaultPageSize.
+	PageSize int
+	// PageToken is the opaque NextPageToken of the previous page.
+	PageToken  string
+	SortBy     SortField
+	Descending bool
+
+	// MinPrice and MaxPrice filter on the base price and must share its currency.
+	MinPrice     *Money
+	MaxPrice     *Money
+	SkuPrefix    string
+	NameContains string
+	// CategoryID limits the listing to products assigned to the category or
+	// any of its descendants.
+	CategoryID uint64
+	// Attributes filters by attribute code: a product must have one of the
+	// listed canonical values for every code.
+	Attributes map[string][]string
+	// Public limits the listing to products listed in the public catalog.
+	Public bool
+}
+
+// ListPage is a single page of products. NextPageToken is empty on the last page.
+type ListPage struct {
+	Products      []*DbProduct
+	NextPageToken string
+}
+
+// listCursor is the decoded form of a page token: the sort key of the last
+// returned product plus a fingerprint of the query it belongs to.
+type listCursor struct {
+	Value       json.RawMessage `json:"v"`
+	ID          uint64          `json:"id"`
+	Fingerprint uint32          `json:"f"`
+}
+
+// column returns the database column behind the sort field.
+func (f SortField) column() string {
+	if f == SortByPrice {
+		return "price_amount"
+	}
+	return string(f)
+}
+
+// ParseOrderBy parses an "<field> [asc|desc]" ordering expression.
+func ParseOrderBy(orderBy string) (SortField, bool, error) {
+	parts := strings.Fields(strings.ToLower(orderBy))
+	if len(parts) == 0 {
+		return SortByID, false, nil
+	}
+	if len(parts) > 2 || (len(parts) == 2 && parts[1] != "asc" && parts[1] != "desc") {
+		return "", false, InvalidArgumentError("invalid order_by", FieldViolation{Field: "order_by", Description: "must be \"<field> [asc|desc]\""})
+	}
+	return SortField(parts[0]), len(parts) == 2 && parts[1] == "desc", nil
+}
+
+// normalize applies defaults and validates the query.
+func (q *ListQuery) normalize() error {
+	var violations []FieldViolation
+	switch {
+	case q.PageSize < 0:
+		violations = append(violations, FieldViolation{Field: "page_size", Description: "must not be negative"})
+	case q.PageSize == 0:
+		q.PageSize = DefaultPageSize
+	case q.PageSize > MaxPageSize:
+		q.PageSize = MaxPageSize
+	}
+	switch q.SortBy {
+	case "":
+		q.SortBy = SortByID
+	case SortByID, SortByName, SortByPrice, SortByCreatedAt, SortByUpdatedAt:
+	default:
+		violations = append(violations, FieldViolation{Field: "order_by", Description: fmt.Sprintf("unsupported sort field %q", q.SortBy)})
+	}
+	for field, price := range map[string]*Money{"min_price": q.MinPrice, "max_price": q.MaxPrice} {
+		if price != nil && !ValidCurrency(price.Currency) {
+			violations = append(violations, FieldViolation{Field: field, Description: "must have an ISO 4217 currency code"})
+		}
+	}
+	for _, code := range q.attributeCodes() {
+		if len(q.Attributes[code]) == 0 {
+			violations = append(violations, FieldViolation{Field: "attributes." + code, Description: "must have at least one value"})
+		}
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil {
+		switch {
+		case q.MinPrice.Currency != q.MaxPrice.Currency:
+			violations = append(violations, FieldViolation{Field: "max_price", Description: "must have the currency of min_price"})
+		case q.MinPrice.Amount > q.MaxPrice.Amount:
+			violations = append(violations, FieldViolation{Field: "max_price", Description: "must not be less than min_price"})
+		}
+	}
+	if len(violations) > 0 {
+		return InvalidArgumentError("invalid list query", violations...)
+	}
+	return nil
+}
+
+// fingerprint identifies the ordering and filters so a token cannot be
+// replayed against a different query.
+func (q *ListQuery) fingerprint() uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s|%t|%s|%s|%d", q.SortBy, q.Descending, q.SkuPrefix, q.NameContains, q.CategoryID)
+	if q.MinPrice != nil {
+		fmt.Fprintf(h, "|min:%v", *q.MinPrice)
+	}
+	if q.MaxPrice != nil {
+		fmt.Fprintf(h, "|max:%v", *q.MaxPrice)
+	}
+	for _, code := range q.attributeCodes() {
+		fmt.Fprintf(h, "|attr:%s=%q", code, q.Attributes[code])
+	}
+	if q.Public {
+		fmt.Fprint(h, "|public")
+	}
+	return h.Sum32()
+}
+
+func (q *ListQuery) decodeCursor() (*listCursor, error) {
+	if q.PageToken == "" {
+		return nil, nil
+	}
+	invalid := InvalidArgumentError("invalid page token", FieldViolation{Field: "page_token", Description: "is malformed or belongs to a different query"})
+	raw, err := base64.RawURLEncoding.DecodeString(q.PageToken)
+	if err != nil {
+		return nil, invalid
+	}
+	cursor := &listCursor{}
+	if err := json.Unmarshal(raw, cursor); err != nil || cursor.Fingerprint != q.fingerprint() {
+		return nil, invalid
+	}
+	return cursor, nil
+}
+
+func (q *ListQuery) encodeCursor(last *DbProduct) (string, error) {
+	var value interface{}
+	switch q.SortBy {
+	case SortByName:
+		value = last.Name
+	case SortByPrice:
+		value = last.Price.Amount
+	case SortByCreatedAt:
+		value = last.CreatedAt
+	case SortByUpdatedAt:
+		value = last.UpdatedAt
+	}
+	cursor := listCursor{ID: last.ID, Fingerprint: q.fingerprint()}
+	if value != nil {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		cursor.Value = encoded
+	}
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// cursorValue decodes the sort key stored in the cursor into the Go type of the column.
+func (q *ListQuery) cursorValue(cursor *listCursor) (interface{}, error) {
+	var err error
+	switch q.SortBy {
+	case SortByName:
+		var v string
+		err = json.Unmarshal(cursor.Value, &v)
+		return v, err
+	case SortByPrice:
+		var v int64
+		err = json.Unmarshal(cursor.Value, &v)
+		return v, err
+	case SortByCreatedAt, SortByUpdatedAt:
+		var v time.Time
+		err = json.Unmarshal(cursor.Value, &v)
+		return v, err
+	}
+	return nil, nil
+}
+
+// attributeCodes returns the codes of the attribute filters in a stable order.
+func (q *ListQuery) attributeCodes() []string {
+	codes := make([]string, 0, len(q.Attributes))
+	for code := range q.Attributes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// filterScope applies the filters of the query. The price filter and the
+// filter on skipAttribute are left out when computing facets for them.
+func (q *ListQuery) filterScope(skipPrice bool, skipAttribute string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if q.Public {
+			db = db.Scopes(publicScope(catalogVisibilities, time.Now()))
+		}
+		if q.MinPrice != nil && !skipPrice {
+			db = db.Where("price_currency = ? AND price_amount >= ?", q.MinPrice.Currency, q.MinPrice.Amount)
+		}
+		if q.MaxPrice != nil && !skipPrice {
+			db = db.Where("price_currency = ? AND price_amount <= ?", q.MaxPrice.Currency, q.MaxPrice.Amount)
+		}
+		if q.SkuPrefix != "" {
+			db = db.Where("sku LIKE ?", escapeLike(q.SkuPrefix)+"%")
+		}
+		if q.NameContains != "" {
+			db = db.Where("name LIKE ?", "%"+escapeLike(q.NameContains)+"%")
+		}
+		if q.CategoryID != 0 {
+			db = db.Where(categorySubtreeCondition, q.CategoryID)
+		}
+		for _, code := range q.attributeCodes() {
+			if code != skipAttribute {
+				db = db.Where(attributeValueCondition, code, q.Attributes[code])
+			}
+		}
+		return db
+	}
+}
+
+// scope applies filters, keyset condition, ordering and limit. One extra row
+// is requested to find out whether there is a next page.
+func (q *ListQuery) scope(cursor *listCursor, cursorValue interface{}) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Scopes(q.filterScope(false, ""))
+
+		op, dir := ">", "ASC"
+		if q.Descending {
+			op, dir = "<", "DESC"
+		}
+		if cursor != nil {
+			if q.SortBy == SortByID {
+				db = db.Where("id "+op+" ?", cursor.ID)
+			} else {
+				column := q.SortBy.column()
+				db = db.Where(column+" "+op+" ? OR ("+column+" = ? AND id "+op+" ?)", cursorValue, cursorValue, cursor.ID)
+			}
+		}
+		if q.SortBy != SortByID {
+			db = db.Order(q.SortBy.column() + " " + dir)
+		}
+		return db.Order("id " + dir).Limit(q.PageSize + 1)
+	}
+}
+
+// escapeLike escapes LIKE wildcards in user input.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
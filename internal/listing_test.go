@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newSqlMockDB returns a GORM connection backed by sqlmock for tests that
+// need real query building.
+func newSqlMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	return db, sqlMock
+}
+
+func TestProductService_ListProducts(t *testing.T) {
+	t.Run("First page with filters", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		minPrice := Money{Amount: 1000, Currency: "USD"}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE (price_currency = ? AND price_amount >= ?) AND sku LIKE ? AND name LIKE ? AND `catalog_products`.`deleted_at` IS NULL ORDER BY price_amount DESC,id DESC LIMIT ?")).
+			WithArgs("USD", 1000, `TS\_%`, `%shirt%`, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price_amount", "price_currency"}).
+				AddRow(3, "T-shirt XL", 3000, "USD").
+				AddRow(2, "T-shirt L", 2000, "USD").
+				AddRow(1, "T-shirt M", 1000, "USD"))
+		//when
+		page, err := ps.ListProducts(context.Background(), ListQuery{PageSize: 2, SortBy: SortByPrice, Descending: true, MinPrice: &minPrice, SkuPrefix: "TS_", NameContains: "shirt"})
+		//then
+		require.NoError(t, err)
+		assert.Len(t, page.Products, 2)
+		assert.NotEmpty(t, page.NextPageToken)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Category filter includes descendants", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE "+categorySubtreeCondition+" AND `catalog_products`.`deleted_at` IS NULL ORDER BY id ASC LIMIT ?")).
+			WithArgs(4, 51).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "T-shirt M"))
+		//when
+		page, err := ps.ListProducts(context.Background(), ListQuery{CategoryID: 4})
+		//then
+		require.NoError(t, err)
+		assert.Len(t, page.Products, 1)
+		assert.Empty(t, page.NextPageToken)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Public listing leaves out unlisted and unavailable products", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE (status = ? AND visibility IN (?,?)) AND (available_from IS NULL OR available_from <= ?) AND (available_until IS NULL OR available_until > ?) AND `catalog_products`.`deleted_at` IS NULL ORDER BY id ASC LIMIT ?")).
+			WithArgs(ProductActive, VisibilityCatalog, VisibilityBoth, sqlmock.AnyArg(), sqlmock.AnyArg(), 51).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "T-shirt M"))
+		//when
+		page, err := ps.ListProducts(context.Background(), ListQuery{Public: true})
+		//then
+		require.NoError(t, err)
+		assert.Len(t, page.Products, 1)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Next page continues after the last product", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		query := ListQuery{PageSize: 2, SortBy: SortByName}
+		require.NoError(t, query.normalize())
+		token, err := query.encodeCursor(&DbProduct{ID: 7, Name: "Mug"})
+		require.NoError(t, err)
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE (name > ? OR (name = ? AND id > ?)) AND `catalog_products`.`deleted_at` IS NULL ORDER BY name ASC,id ASC LIMIT ?")).
+			WithArgs("Mug", "Mug", 7, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(8, "Poster"))
+		//when
+		page, err := ps.ListProducts(context.Background(), ListQuery{PageSize: 2, SortBy: SortByName, PageToken: token})
+		//then
+		require.NoError(t, err)
+		assert.Len(t, page.Products, 1)
+		assert.Empty(t, page.NextPageToken)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Page size is capped", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`deleted_at` IS NULL ORDER BY id ASC LIMIT ?")).
+			WithArgs(501).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		//when
+		_, err := ps.ListProducts(context.Background(), ListQuery{PageSize: 10000})
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Token of a different query is rejected", func(t *testing.T) {
+		// given
+		query := ListQuery{SortBy: SortByName}
+		require.NoError(t, query.normalize())
+		token, err := query.encodeCursor(&DbProduct{ID: 7, Name: "Mug"})
+		require.NoError(t, err)
+		ps := &ProductService{DB: new(DbWrapperMock)}
+		//when
+		_, err = ps.ListProducts(context.Background(), ListQuery{SortBy: SortByPrice, PageToken: token})
+		//then
+		assert.Equal(t, KindInvalidArgument, KindOf(err))
+	})
+
+	t.Run("Unsupported sort field", func(t *testing.T) {
+		// given
+		ps := &ProductService{DB: new(DbWrapperMock)}
+		//when
+		_, err := ps.ListProducts(context.Background(), ListQuery{SortBy: "description"})
+		//then
+		assert.Equal(t, KindInvalidArgument, KindOf(err))
+	})
+}
+
+func TestParseOrderBy(t *testing.T) {
+	sortBy, desc, err := ParseOrderBy("price DESC")
+	assert.NoError(t, err)
+	assert.Equal(t, SortByPrice, sortBy)
+	assert.True(t, desc)
+
+	_, _, err = ParseOrderBy("price sideways")
+	assert.Equal(t, KindInvalidArgument, KindOf(err))
+}
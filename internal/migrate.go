@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// legacyPriceColumn is the float price column replaced by price_amount and price_currency.
+const legacyPriceColumn = "price"
+
+const (
+	// activeSkuColumn mirrors the SKU of a product that is not deleted and is
+	// NULL once it is, so its unique index lets a deleted product free its SKU.
+	activeSkuColumn = "sku_active"
+	activeSkuIndex  = "idx_product_active_sku"
+	// legacySkuIndex is the unique index on sku that also counted deleted products.
+	legacySkuIndex = "idx_catalog_products_sku"
+)
+
+const (
+	// maxSkuLength and maxNameLength are the sizes of the sku and name columns.
+	maxSkuLength  = 255
+	maxNameLength = 255
+	// maxReportedProducts caps how many offending products a failed check lists.
+	maxReportedProducts = 20
+)
+
+// catalogModels are the tables created and updated by Migrate.
+var catalogModels = []interface{}{
+	&DbProduct{}, &DbProductPrice{},
+	&DbProductOption{}, &DbVariant{},
+	&DbCategory{}, &DbProductCategory{},
+	&DbAttribute{}, &DbAttributeSet{}, &DbAttributeSetAttribute{}, &DbProductAttributeSet{}, &DbProductAttributeValue{},
+	&DbSynonymSet{}, &DbStopWord{},
+	&DbIdempotencyKey{},
+}
+
+// Migrate brings the catalog schema up to date. Float prices left by earlier
+// versions are converted to minor units of currency, the catalog currency they
+// were entered in, before the float column is dropped.
+func Migrate(db *gorm.DB, currency string) error {
+	if !ValidCurrency(currency) {
+		return fmt.Errorf("invalid catalog currency %q", currency)
+	}
+	if err := checkProductColumns(db); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(catalogModels...); err != nil {
+		return fmt.Errorf("failed to migrate tables: %w", err)
+	}
+	if err := uniqueActiveSkus(db); err != nil {
+		return err
+	}
+	return convertFloatPrices(db, currency)
+}
+
+// checkProductColumns refuses to migrate products that the sku and name
+// columns cannot hold: SKUs shared by products that are not deleted would
+// break the unique SKU index, and longer values would make AutoMigrate fail
+// half way or truncate them. The error lists what the operator has to fix.
+func checkProductColumns(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&DbProduct{}) {
+		return nil
+	}
+	var duplicates []struct {
+		Sku      string
+		Products int64
+	}
+	err := db.Model(&DbProduct{}).
+		Select("sku, COUNT(*) AS products").
+		Group("sku").
+		Having("COUNT(*) > 1").
+		Order("sku").
+		Limit(maxReportedProducts).
+		Scan(&duplicates).Error
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate skus: %w", err)
+	}
+	var tooLong []uint64
+	err = db.Model(&DbProduct{}).Unscoped().
+		Where("CHAR_LENGTH(sku) > ? OR CHAR_LENGTH(name) > ?", maxSkuLength, maxNameLength).
+		Order("id").
+		Limit(maxReportedProducts).
+		Pluck("id", &tooLong).Error
+	if err != nil {
+		return fmt.Errorf("failed to check for over-long skus and names: %w", err)
+	}
+
+	var problems []string
+	if len(duplicates) > 0 {
+		skus := make([]string, len(duplicates))
+		for i, d := range duplicates {
+			skus[i] = fmt.Sprintf("%q (%d products)", d.Sku, d.Products)
+		}
+		problems = append(problems, "skus used by more than one product: "+strings.Join(skus, ", "))
+	}
+	if len(tooLong) > 0 {
+		ids := make([]string, len(tooLong))
+		for i, id := range tooLong {
+			ids[i] = fmt.Sprint(id)
+		}
+		problems = append(problems, fmt.Sprintf("products with a sku longer than %d or a name longer than %d characters: %s",
+			maxSkuLength, maxNameLength, strings.Join(ids, ", ")))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("cannot migrate %s, %s; change or delete these products and restart",
+		DbProduct{}.TableName(), strings.Join(problems, "; "))
+}
+
+// uniqueActiveSkus replaces the unique index on sku with one on the generated
+// activeSkuColumn. The column is not part of DbProduct, so AutoMigrate leaves
+// its definition alone.
+func uniqueActiveSkus(db *gorm.DB) error {
+	table := clause.Table{Name: DbProduct{}.TableName()}
+	if !db.Migrator().HasColumn(&DbProduct{}, activeSkuColumn) {
+		err := db.Exec("ALTER TABLE ? ADD COLUMN ? VARCHAR(255) AS (IF(deleted_at IS NULL, sku, NULL)) STORED",
+			table, clause.Column{Name: activeSkuColumn}).Error
+		if err != nil {
+			return fmt.Errorf("failed to add the active sku column: %w", err)
+		}
+	}
+	if !db.Migrator().HasIndex(&DbProduct{}, activeSkuIndex) {
+		err := db.Exec("CREATE UNIQUE INDEX ? ON ?(?)",
+			clause.Column{Name: activeSkuIndex}, table, clause.Column{Name: activeSkuColumn}).Error
+		if err != nil {
+			return fmt.Errorf("failed to index the active sku column: %w", err)
+		}
+	}
+	if db.Migrator().HasIndex(&DbProduct{}, legacySkuIndex) {
+		if err := db.Migrator().DropIndex(&DbProduct{}, legacySkuIndex); err != nil {
+			return fmt.Errorf("failed to drop the unique sku index: %w", err)
+		}
+	}
+	return nil
+}
+
+// convertFloatPrices moves the legacy float prices into price_amount and
+// price_currency. It does nothing once the float column is gone.
+func convertFloatPrices(db *gorm.DB, currency string) error {
+	if !db.Migrator().HasColumn(&DbProduct{}, legacyPriceColumn) {
+		return nil
+	}
+	// The float is rounded in SQL: 19.99 stored as 19.9899997 becomes 1999.
+	scale := math.Pow10(CurrencyExponent(currency))
+	result := db.Model(&DbProduct{}).Unscoped().
+		Where("price_currency = ? OR price_currency IS NULL", "").
+		UpdateColumns(map[string]interface{}{
+			"price_amount":   gorm.Expr("ROUND(COALESCE(price, 0) * ?)", scale),
+			"price_currency": currency,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to convert float prices: %w", result.Error)
+	}
+	if err := db.Migrator().DropColumn(&DbProduct{}, legacyPriceColumn); err != nil {
+		return fmt.Errorf("failed to drop the float price column: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertFloatPrices(t *testing.T) {
+	t.Run("Float prices are rounded to minor units and the column dropped", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT DATABASE()")).
+			WillReturnRows(sqlmock.NewRows([]string{"DATABASE()"}).AddRow("catalog"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT SCHEMA_NAME from Information_schema.SCHEMATA")).
+			WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME"}).AddRow("catalog"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM INFORMATION_SCHEMA.columns")).
+			WithArgs("catalog", "catalog_products", "price").
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `price_amount`=ROUND(COALESCE(price, 0) * ?),`price_currency`=? WHERE price_currency = ? OR price_currency IS NULL")).
+			WithArgs(float64(1000), "KWD", "").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		sqlMock.ExpectCommit()
+		sqlMock.ExpectExec(regexp.QuoteMeta("ALTER TABLE `catalog_products` DROP COLUMN `price`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		//when
+		err := convertFloatPrices(db, "KWD")
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to do once the float column is gone", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT DATABASE()")).
+			WillReturnRows(sqlmock.NewRows([]string{"DATABASE()"}).AddRow("catalog"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT SCHEMA_NAME from Information_schema.SCHEMATA")).
+			WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME"}).AddRow("catalog"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM INFORMATION_SCHEMA.columns")).
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+		//when
+		err := convertFloatPrices(db, "USD")
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestCheckProductColumns(t *testing.T) {
+	expectTable := func(sqlMock sqlmock.Sqlmock, count int) {
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT DATABASE()")).
+			WillReturnRows(sqlmock.NewRows([]string{"DATABASE()"}).AddRow("catalog"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT SCHEMA_NAME from Information_schema.SCHEMATA")).
+			WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME"}).AddRow("catalog"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM information_schema.tables")).
+			WithArgs("catalog", "catalog_products", "BASE TABLE").
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(count))
+	}
+	const duplicates = "SELECT sku, COUNT(*) AS products FROM `catalog_products` WHERE `catalog_products`.`deleted_at` IS NULL GROUP BY `sku` HAVING COUNT(*) > 1 ORDER BY sku LIMIT ?"
+	const tooLong = "SELECT `id` FROM `catalog_products` WHERE CHAR_LENGTH(sku) > ? OR CHAR_LENGTH(name) > ? ORDER BY id LIMIT ?"
+
+	t.Run("Duplicate and over-long values are reported", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		expectTable(sqlMock, 1)
+		sqlMock.ExpectQuery(regexp.QuoteMeta(duplicates)).
+			WithArgs(20).
+			WillReturnRows(sqlmock.NewRows([]string{"sku", "products"}).AddRow("MG-1", 2).AddRow("", 3))
+		sqlMock.ExpectQuery(regexp.QuoteMeta(tooLong)).
+			WithArgs(255, 255, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(9))
+		//when
+		err := checkProductColumns(db)
+		//then
+		require.Error(t, err)
+		assert.Equal(t, `cannot migrate catalog_products, skus used by more than one product: "MG-1" (2 products), "" (3 products); `+
+			`products with a sku longer than 255 or a name longer than 255 characters: 4, 9; change or delete these products and restart`, err.Error())
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Clean products pass", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		expectTable(sqlMock, 1)
+		sqlMock.ExpectQuery(regexp.QuoteMeta(duplicates)).
+			WillReturnRows(sqlmock.NewRows([]string{"sku", "products"}))
+		sqlMock.ExpectQuery(regexp.QuoteMeta(tooLong)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		//when
+		err := checkProductColumns(db)
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to check before the first migration", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		expectTable(sqlMock, 0)
+		//when
+		err := checkProductColumns(db)
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestUniqueActiveSkus(t *testing.T) {
+	expectSchemaCount := func(sqlMock sqlmock.Sqlmock, query string, name string, count int) {
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT DATABASE()")).
+			WillReturnRows(sqlmock.NewRows([]string{"DATABASE()"}).AddRow("catalog"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT SCHEMA_NAME from Information_schema.SCHEMATA")).
+			WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME"}).AddRow("catalog"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs("catalog", "catalog_products", name).
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(count))
+	}
+	const columns = "SELECT count(*) FROM INFORMATION_SCHEMA.columns"
+	const indexes = "SELECT count(*) FROM information_schema.statistics"
+
+	t.Run("Unique sku index is replaced by one ignoring deleted products", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		expectSchemaCount(sqlMock, columns, "sku_active", 0)
+		sqlMock.ExpectExec(regexp.QuoteMeta("ALTER TABLE `catalog_products` ADD COLUMN `sku_active` VARCHAR(255) AS (IF(deleted_at IS NULL, sku, NULL)) STORED")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectSchemaCount(sqlMock, indexes, "idx_product_active_sku", 0)
+		sqlMock.ExpectExec(regexp.QuoteMeta("CREATE UNIQUE INDEX `idx_product_active_sku` ON `catalog_products`(`sku_active`)")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		expectSchemaCount(sqlMock, indexes, "idx_catalog_products_sku", 1)
+		sqlMock.ExpectExec(regexp.QuoteMeta("DROP INDEX `idx_catalog_products_sku` ON `catalog_products`")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		//when
+		err := uniqueActiveSkus(db)
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to do once migrated", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		expectSchemaCount(sqlMock, columns, "sku_active", 1)
+		expectSchemaCount(sqlMock, indexes, "idx_product_active_sku", 1)
+		expectSchemaCount(sqlMock, indexes, "idx_catalog_products_sku", 0)
+		//when
+		err := uniqueActiveSkus(db)
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrate_InvalidCurrency(t *testing.T) {
+	// given
+	db, _ := newSqlMockDB(t)
+	//when
+	err := Migrate(db, "dollars")
+	//then
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the catalog currency used when none is configured.
+const DefaultCurrency = "USD"
+
+// currencyExponents lists ISO 4217 currencies whose minor unit is not 1/100.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyExponent returns the number of decimal digits of the minor unit.
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// ValidCurrency reports whether currency looks like an ISO 4217 code.
+func ValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Money is an exact amount expressed in the minor unit of Currency, e.g.
+// Amount 1999 with Currency "USD" is 19.99 USD.
+type Money struct {
+	// Amount is indexed where Money is embedded, for sorting and filtering by price.
+	Amount   int64  `gorm:"index"`
+	Currency string `gorm:"size:3"`
+}
+
+// ParseMoney parses a decimal string such as "19.99". Digits beyond the minor
+// unit are rounded half away from zero.
+func ParseMoney(amount string, currency string) (Money, error) {
+	if !ValidCurrency(currency) {
+		return Money{}, fmt.Errorf("invalid currency %q", currency)
+	}
+	s := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || strings.ContainsAny(intPart+fracPart, "+-eE") {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	exp := CurrencyExponent(currency)
+	roundUp := false
+	if len(fracPart) > exp {
+		if fracPart[exp] < '0' || fracPart[exp] > '9' {
+			return Money{}, fmt.Errorf("invalid amount %q", amount)
+		}
+		roundUp = fracPart[exp] >= '5'
+		for _, r := range fracPart[exp:] {
+			if r < '0' || r > '9' {
+				return Money{}, fmt.Errorf("invalid amount %q", amount)
+			}
+		}
+		fracPart = fracPart[:exp]
+	}
+	fracPart += strings.Repeat("0", exp-len(fracPart))
+
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	var minor int64
+	if digits != "" {
+		var err error
+		minor, err = strconv.ParseInt(digits, 10, 64)
+		if err != nil {
+			return Money{}, fmt.Errorf("invalid amount %q: %w", amount, err)
+		}
+	}
+	if roundUp {
+		if minor == math.MaxInt64 {
+			return Money{}, fmt.Errorf("amount %q is out of range", amount)
+		}
+		minor++
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// MoneyFromFloat32 converts a legacy float price. The shortest decimal
+// representation of f is used, so float32(19.99) becomes exactly 19.99.
+func MoneyFromFloat32(f float32, currency string) (Money, error) {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return Money{}, fmt.Errorf("amount %v is not a finite number", f)
+	}
+	return ParseMoney(strconv.FormatFloat(float64(f), 'f', -1, 32), currency)
+}
+
+// Float32 returns the nearest float32, for APIs that still carry float prices.
+func (m Money) Float32() float32 {
+	f, _ := strconv.ParseFloat(m.Decimal(), 32)
+	return float32(f)
+}
+
+// Decimal formats the amount with the currency's number of decimals, e.g. "19.99".
+func (m Money) Decimal() string {
+	exp := CurrencyExponent(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absInt64(amount), 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func absInt64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
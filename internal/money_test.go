@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{name: "Cents", amount: "19.99", currency: "USD", want: Money{Amount: 1999, Currency: "USD"}},
+		{name: "Whole amount", amount: "20", currency: "EUR", want: Money{Amount: 2000, Currency: "EUR"}},
+		{name: "Missing decimals are padded", amount: "0.5", currency: "USD", want: Money{Amount: 50, Currency: "USD"}},
+		{name: "Half is rounded up", amount: "1.005", currency: "USD", want: Money{Amount: 101, Currency: "USD"}},
+		{name: "Below half is rounded down", amount: "1.0049", currency: "USD", want: Money{Amount: 100, Currency: "USD"}},
+		{name: "Negative is rounded away from zero", amount: "-1.005", currency: "USD", want: Money{Amount: -101, Currency: "USD"}},
+		{name: "Zero decimal currency", amount: "1500.5", currency: "JPY", want: Money{Amount: 1501, Currency: "JPY"}},
+		{name: "Three decimal currency", amount: "2.5", currency: "KWD", want: Money{Amount: 2500, Currency: "KWD"}},
+		{name: "Invalid currency", amount: "1", currency: "usd", wantErr: true},
+		{name: "Exponent notation", amount: "1e3", currency: "USD", wantErr: true},
+		{name: "Not a number", amount: "cheap", currency: "USD", wantErr: true},
+		{name: "Empty", amount: "", currency: "USD", wantErr: true},
+		{name: "Out of range", amount: "92233720368547758.08", currency: "USD", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			got, err := ParseMoney(tt.amount, tt.currency)
+			//then
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoneyFromFloat32(t *testing.T) {
+	t.Run("Float noise is not stored", func(t *testing.T) {
+		//when
+		got, err := MoneyFromFloat32(19.99, "USD")
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, Money{Amount: 1999, Currency: "USD"}, got)
+		assert.Equal(t, float32(19.99), got.Float32())
+	})
+
+	t.Run("Non-finite floats are rejected", func(t *testing.T) {
+		for _, f := range []float32{float32(math.NaN()), float32(math.Inf(1)), float32(math.Inf(-1))} {
+			_, err := MoneyFromFloat32(f, "USD")
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: Money{Amount: 1999, Currency: "USD"}, want: "19.99"},
+		{money: Money{Amount: 5, Currency: "USD"}, want: "0.05"},
+		{money: Money{Amount: -5, Currency: "EUR"}, want: "-0.05"},
+		{money: Money{Amount: 1500, Currency: "JPY"}, want: "1500"},
+		{money: Money{Amount: 2500, Currency: "KWD"}, want: "2.500"},
+		{money: Money{Amount: math.MinInt64, Currency: "USD"}, want: "-92233720368547758.08"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.money.Decimal())
+	}
+	assert.Equal(t, "19.99 USD", Money{Amount: 1999, Currency: "USD"}.String())
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProductField is a product field PatchProduct can change.
+type ProductField string
+
+const (
+	FieldName        ProductField = "name"
+	FieldSku         ProductField = "sku"
+	FieldDescription ProductField = "description"
+	FieldPrice       ProductField = "price"
+	FieldImage       ProductField = "image"
+)
+
+// productFieldColumns are the columns written for every patchable field.
+var productFieldColumns = map[ProductField][]string{
+	FieldName:        {"name"},
+	FieldSku:         {"sku"},
+	FieldDescription: {"description"},
+	FieldPrice:       {"price_amount", "price_currency"},
+	FieldImage:       {"image"},
+}
+
+func (f ProductField) valid() bool {
+	_, ok := productFieldColumns[f]
+	return ok
+}
+
+// applyPatch copies the fields of patch to the product.
+func (p *DbProduct) applyPatch(patch *DbProduct, fields []ProductField) {
+	for _, field := range fields {
+		switch field {
+		case FieldName:
+			p.Name = patch.Name
+		case FieldSku:
+			p.Sku = patch.Sku
+		case FieldDescription:
+			p.Description = patch.Description
+		case FieldPrice:
+			p.Price = patch.Price
+		case FieldImage:
+			p.Image = patch.Image
+		}
+	}
+}
+
+// Change the given fields of a DbProduct to those of patch, other columns
+// are kept. The product must still have patch.Revision. It returns the
+// product as stored.
+func (p *ProductService) PatchProduct(ctx context.Context, id uint64, patch *DbProduct, fields []ProductField) (*DbProduct, error) {
+	if len(fields) == 0 {
+		return nil, InvalidArgumentError("invalid product patch", FieldViolation{Field: "fields", Description: "must list at least one field"})
+	}
+	columns := []string{"updated_at", "revision"}
+	patchesSku := false
+	for _, field := range fields {
+		if !field.valid() {
+			return nil, InvalidArgumentError("invalid product patch", FieldViolation{Field: "fields", Description: fmt.Sprintf("unknown field %q", field)})
+		}
+		columns = append(columns, productFieldColumns[field]...)
+		patchesSku = patchesSku || field == FieldSku
+	}
+
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	product := DbProduct{}
+	err := db.Transaction(func(tx DbWrapper) error {
+		if err := tx.Scopes(forUpdate).First(&product, id).Error; err != nil {
+			return err
+		}
+		if product.Revision != patch.Revision {
+			return revisionConflict(id, product.Revision, patch.Revision)
+		}
+		product.applyPatch(patch, fields)
+		product.UpdatedAt = time.Now()
+		product.Revision++
+		write := func(db *gorm.DB) *gorm.DB {
+			if !patchesSku {
+				// Hooks only check the SKU.
+				db = withoutHooks(db)
+			}
+			return db.Model(&product).Select(columns)
+		}
+		return tx.Where("revision = ?", patch.Revision).Scopes(write).Updates(&product).Error
+	})
+	if err != nil {
+		return nil, skuError(classifyDbError(ctx, fmt.Errorf("failed to patch a product %d: %w", id, err), ResourceProduct, id), patch.Sku)
+	}
+	p.indexProduct(&product)
+	return &product, nil
+}
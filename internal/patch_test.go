@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductService_PatchProduct(t *testing.T) {
+	t.Run("Only the listed fields are written", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex()}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ? AND `catalog_products`.`deleted_at` IS NULL ORDER BY `catalog_products`.`id` LIMIT ? FOR UPDATE")).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "description", "price_amount", "price_currency", "revision"}).
+				AddRow(1, "Running Shirt", "RS-1", "Breathable mesh", 1999, "USD", 2))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `updated_at`=?,`price_amount`=?,`price_currency`=?,`revision`=? WHERE revision = ? AND `catalog_products`.`deleted_at` IS NULL AND `id` = ?")).
+			WithArgs(sqlmock.AnyArg(), 1499, "USD", 3, 2, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+		//when
+		product, err := ps.PatchProduct(context.Background(), 1, &DbProduct{Price: Money{Amount: 1499, Currency: "USD"}, Revision: 2}, []ProductField{FieldPrice})
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), product.Revision)
+		assert.Equal(t, "Breathable mesh", product.Description)
+		assert.Equal(t, Money{Amount: 1499, Currency: "USD"}, product.Price)
+		assert.Equal(t, []uint64{1}, hitIDs(ps.Index.Search("mesh")))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Stale revision is rejected", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "revision"}).AddRow(1, "Running Shirt", 5))
+		sqlMock.ExpectRollback()
+		//when
+		_, err := ps.PatchProduct(context.Background(), 1, &DbProduct{Name: "Shirt", Revision: 4}, []ProductField{FieldName})
+		//then
+		assert.Equal(t, KindConflict, KindOf(err))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing product", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		sqlMock.ExpectRollback()
+		//when
+		_, err := ps.PatchProduct(context.Background(), 7, &DbProduct{Name: "Shirt"}, []ProductField{FieldName})
+		//then
+		assert.Equal(t, KindNotFound, KindOf(err))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown field is rejected without a query", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}}
+		//when
+		_, err := ps.PatchProduct(context.Background(), 1, &DbProduct{}, []ProductField{"status"})
+		//then
+		assert.Equal(t, KindInvalidArgument, KindOf(err))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,115 @@
+package internal
+
+import "sync"
+
+// indexShard is one generation of an in-memory product index.
+type indexShard interface {
+	put(product *DbProduct)
+	remove(id uint64)
+	size() int
+}
+
+// liveIndex keeps an in-memory index in sync with product writes and
+// rebuilds it from the database while the current shard keeps serving.
+type liveIndex[S indexShard] struct {
+	mu       sync.RWMutex
+	shard    S
+	newShard func() S
+	// rebuild is the shard being loaded. Changes are applied to both shards
+	// meanwhile and touched keeps the rebuild from overwriting them with rows
+	// read earlier.
+	rebuild *shardRebuild[S]
+}
+
+type shardRebuild[S indexShard] struct {
+	index   *liveIndex[S]
+	next    S
+	touched map[uint64]bool
+}
+
+// productIndex is an index ProductService keeps in sync.
+type productIndex interface {
+	Put(product *DbProduct)
+	Remove(id uint64)
+	beginRebuild() (indexRebuild, error)
+}
+
+// indexRebuild loads a fresh shard with add and swaps it in on finish.
+type indexRebuild interface {
+	add(product *DbProduct)
+	// finish swaps the shard in unless err is set and returns its size.
+	finish(err error) int
+}
+
+func newLiveIndex[S indexShard](newShard func() S) liveIndex[S] {
+	return liveIndex[S]{shard: newShard(), newShard: newShard}
+}
+
+// Put adds or replaces a product.
+func (l *liveIndex[S]) Put(product *DbProduct) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.shard.put(product)
+	if l.rebuild != nil {
+		l.rebuild.next.put(product)
+		l.rebuild.touched[product.ID] = true
+	}
+}
+
+// Remove deletes a product, unknown ids are ignored.
+func (l *liveIndex[S]) Remove(id uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.shard.remove(id)
+	if l.rebuild != nil {
+		l.rebuild.next.remove(id)
+		l.rebuild.touched[id] = true
+	}
+}
+
+// Len returns the number of indexed products.
+func (l *liveIndex[S]) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.shard.size()
+}
+
+// Rebuild replaces the index with the products passed to add by load. The
+// current shard keeps serving until load returns successfully. Only one
+// rebuild can run at a time.
+func (l *liveIndex[S]) Rebuild(load func(add func(*DbProduct)) error) (int, error) {
+	rebuild, err := l.beginRebuild()
+	if err != nil {
+		return 0, err
+	}
+	err = load(rebuild.add)
+	return rebuild.finish(err), err
+}
+
+func (l *liveIndex[S]) beginRebuild() (indexRebuild, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rebuild != nil {
+		return nil, &Error{Kind: KindConflict, Message: "index rebuild already in progress"}
+	}
+	l.rebuild = &shardRebuild[S]{index: l, next: l.newShard(), touched: map[uint64]bool{}}
+	return l.rebuild, nil
+}
+
+func (r *shardRebuild[S]) add(product *DbProduct) {
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+	if !r.touched[product.ID] {
+		r.next.put(product)
+	}
+}
+
+func (r *shardRebuild[S]) finish(err error) int {
+	r.index.mu.Lock()
+	defer r.index.mu.Unlock()
+	if err == nil {
+		r.index.shard = r.next
+	}
+	r.index.rebuild = nil
+	return r.next.size()
+}
@@ -0,0 +1,540 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
+const (
+	DefaultSearchPageSize = 20
+	MaxSearchPageSize     = 100
+	// MaxSearchQueryLength caps the query text in runes.
+	MaxSearchQueryLength = 256
+
+	// maxPrefixExpansions limits the index terms a prefix expands to.
+	maxPrefixExpansions = 50
+	// minPrefixLength is the shortest query term matched as a prefix.
+	minPrefixLength = 2
+	// Terms of at least these lengths tolerate one and two typos.
+	minOneTypoLength  = 4
+	minTwoTyposLength = 8
+
+	// BM25 saturation and length normalization.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Relevance factors of the ways a query term can match an index term.
+const (
+	exactMatchFactor  = 1.0
+	prefixMatchFactor = 0.8
+	typoMatchFactor   = 0.5
+	// synonymMatchFactor ranks products matching a synonym below those
+	// matching the query itself.
+	synonymMatchFactor = 0.9
+)
+
+// searchField is a product field covered by the search index.
+type searchField int
+
+const (
+	searchFieldName searchField = iota
+	searchFieldSku
+	searchFieldDescription
+	searchFieldCount
+)
+
+// searchFieldWeights rank a match in the name above one in the SKU and
+// both above the description.
+var searchFieldWeights = [searchFieldCount]float64{3, 2, 1}
+
+// SearchQuery describes a page of search hits.
+type SearchQuery struct {
+	Text string
+	// PageSize is capped at MaxSearchPageSize, zero means DefaultSearchPageSize.
+	PageSize int
+	// PageToken is the opaque NextPageToken of the previous page.
+	PageToken string
+	// Public limits the hits to products the public finds by search.
+	Public bool
+}
+
+// SearchHit is a matching product with its relevance score.
+type SearchHit struct {
+	ProductID uint64
+	Score     float64
+}
+
+// SearchPage is a page of hits by descending relevance with the products
+// loaded. Products deleted since the hit was found are left out.
+type SearchPage struct {
+	Hits      []SearchHit
+	Products  []*DbProduct
+	TotalHits int
+	// NextPageToken is empty on the last page.
+	NextPageToken string
+}
+
+// searchCursor is the decoded form of a search page token.
+type searchCursor struct {
+	Offset      int    `json:"o"`
+	Fingerprint uint32 `json:"f"`
+}
+
+// SearchIndex is an in-memory inverted index over product names, SKUs and
+// descriptions. It is safe for concurrent use.
+type SearchIndex struct {
+	liveIndex[*searchShard]
+	dictionary atomic.Pointer[SearchDictionary]
+}
+
+// searchShard holds the postings of one generation of the index.
+type searchShard struct {
+	docs map[uint64]*searchDoc
+	// postings maps a term to the term frequency per field of every product.
+	postings map[string]map[uint64]*[searchFieldCount]int
+	// terms is the sorted vocabulary, used for prefix and typo matching.
+	terms        []string
+	fieldLengths [searchFieldCount]int
+}
+
+type searchDoc struct {
+	terms   []string
+	lengths [searchFieldCount]int
+	// lifecycle holds the fields deciding when the public finds the product
+	// by search, the availability window is checked at query time.
+	lifecycle DbProduct
+}
+
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{liveIndex: newLiveIndex(newSearchShard)}
+}
+
+func newSearchShard() *searchShard {
+	return &searchShard{docs: map[uint64]*searchDoc{}, postings: map[string]map[uint64]*[searchFieldCount]int{}}
+}
+
+// SetDictionary replaces the synonyms and stop words applied to queries.
+func (s *SearchIndex) SetDictionary(dictionary *SearchDictionary) {
+	s.dictionary.Store(dictionary)
+}
+
+// Search returns the hits of the query by descending relevance. Every query
+// word must match a term of the product, exactly after stemming, as a prefix
+// for the last word or with a typo for words the index does not know. A word
+// with synonyms may match any of them instead, stop words are ignored.
+func (s *SearchIndex) Search(text string) []SearchHit {
+	return s.search(text, false)
+}
+
+// SearchPublic is Search limited to products the public finds by search.
+func (s *SearchIndex) SearchPublic(text string) []SearchHit {
+	return s.search(text, true)
+}
+
+func (s *SearchIndex) search(text string, publicOnly bool) []SearchHit {
+	units := s.dictionary.Load().analyze(tokenize(text))
+	if len(units) == 0 {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	shard := s.shard
+
+	var scores map[uint64]float64
+	for i, unit := range units {
+		unitScores := shard.matchWords(unit.words, i == len(units)-1)
+		for _, alternative := range unit.alternatives {
+			for id, score := range shard.matchWords(alternative, false) {
+				unitScores[id] = math.Max(unitScores[id], synonymMatchFactor*score)
+			}
+		}
+		scores = intersectScores(scores, unitScores)
+	}
+
+	now := time.Now()
+	hits := make([]SearchHit, 0, len(scores))
+	for id, score := range scores {
+		if publicOnly && !shard.docs[id].lifecycle.InSearch(now) {
+			continue
+		}
+		hits = append(hits, SearchHit{ProductID: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ProductID < hits[j].ProductID
+	})
+	return hits
+}
+
+// matchWords scores the products matching every word. The last word is also
+// matched as a prefix when prefixLast is set.
+func (sh *searchShard) matchWords(words []string, prefixLast bool) map[uint64]float64 {
+	var scores map[uint64]float64
+	for i, word := range words {
+		wordScores := map[uint64]float64{}
+		for term, factor := range sh.expand(word, prefixLast && i == len(words)-1) {
+			for id, score := range sh.scoreTerm(term) {
+				// a product matching several expansions counts the best one
+				wordScores[id] = math.Max(wordScores[id], factor*score)
+			}
+		}
+		scores = intersectScores(scores, wordScores)
+	}
+	return scores
+}
+
+// intersectScores adds next to the products of scores having a score in
+// both. A nil scores starts the intersection with next.
+func intersectScores(scores, next map[uint64]float64) map[uint64]float64 {
+	if scores == nil {
+		return next
+	}
+	for id := range scores {
+		if score, ok := next[id]; ok {
+			scores[id] += score
+		} else {
+			delete(scores, id)
+		}
+	}
+	return scores
+}
+
+func (sh *searchShard) put(product *DbProduct) {
+	sh.remove(product.ID)
+	doc := &searchDoc{lifecycle: DbProduct{
+		Status:         product.Status,
+		Visibility:     product.Visibility,
+		AvailableFrom:  product.AvailableFrom,
+		AvailableUntil: product.AvailableUntil,
+	}}
+	frequencies := map[string]*[searchFieldCount]int{}
+	for field, terms := range productTerms(product) {
+		doc.lengths[field] = len(terms)
+		sh.fieldLengths[field] += len(terms)
+		for _, term := range terms {
+			if frequencies[term] == nil {
+				frequencies[term] = &[searchFieldCount]int{}
+				doc.terms = append(doc.terms, term)
+			}
+			frequencies[term][field]++
+		}
+	}
+	for term, frequency := range frequencies {
+		if sh.postings[term] == nil {
+			sh.postings[term] = map[uint64]*[searchFieldCount]int{}
+			sh.addTerm(term)
+		}
+		sh.postings[term][product.ID] = frequency
+	}
+	sh.docs[product.ID] = doc
+}
+
+func (sh *searchShard) size() int {
+	return len(sh.docs)
+}
+
+func (sh *searchShard) remove(id uint64) {
+	doc, ok := sh.docs[id]
+	if !ok {
+		return
+	}
+	for field, length := range doc.lengths {
+		sh.fieldLengths[field] -= length
+	}
+	for _, term := range doc.terms {
+		delete(sh.postings[term], id)
+		if len(sh.postings[term]) == 0 {
+			delete(sh.postings, term)
+			sh.removeTerm(term)
+		}
+	}
+	delete(sh.docs, id)
+}
+
+func (sh *searchShard) addTerm(term string) {
+	i := sort.SearchStrings(sh.terms, term)
+	sh.terms = append(sh.terms, "")
+	copy(sh.terms[i+1:], sh.terms[i:])
+	sh.terms[i] = term
+}
+
+func (sh *searchShard) removeTerm(term string) {
+	i := sort.SearchStrings(sh.terms, term)
+	if i < len(sh.terms) && sh.terms[i] == term {
+		sh.terms = append(sh.terms[:i], sh.terms[i+1:]...)
+	}
+}
+
+// expand returns the index terms a query token matches with their factors.
+// Typos are only tolerated when the token has no exact match.
+func (sh *searchShard) expand(token string, last bool) map[string]float64 {
+	expansions := map[string]float64{}
+	for _, term := range []string{token, stem(token)} {
+		if _, ok := sh.postings[term]; ok {
+			expansions[term] = exactMatchFactor
+		}
+	}
+	if last && len([]rune(token)) >= minPrefixLength {
+		i := sort.SearchStrings(sh.terms, token)
+		for n := 0; i < len(sh.terms) && n < maxPrefixExpansions && strings.HasPrefix(sh.terms[i], token); i, n = i+1, n+1 {
+			if _, ok := expansions[sh.terms[i]]; !ok {
+				expansions[sh.terms[i]] = prefixMatchFactor
+			}
+		}
+	}
+	if len(expansions) > 0 {
+		return expansions
+	}
+	maxTypos := 0
+	switch length := len([]rune(token)); {
+	case length >= minTwoTyposLength:
+		maxTypos = 2
+	case length >= minOneTypoLength:
+		maxTypos = 1
+	default:
+		return expansions
+	}
+	raw, stemmed := []rune(token), []rune(stem(token))
+	for _, term := range sh.terms {
+		candidate := []rune(term)
+		if typos := min(editDistance(raw, candidate, maxTypos), editDistance(stemmed, candidate, maxTypos)); typos <= maxTypos {
+			expansions[term] = typoMatchFactor / float64(typos)
+		}
+	}
+	return expansions
+}
+
+// scoreTerm computes the BM25F score of the term for every product having it.
+func (sh *searchShard) scoreTerm(term string) map[uint64]float64 {
+	postings := sh.postings[term]
+	n := float64(len(sh.docs))
+	idf := math.Log(1 + (n-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+	scores := make(map[uint64]float64, len(postings))
+	for id, frequency := range postings {
+		doc := sh.docs[id]
+		weighted := 0.0
+		for field, tf := range frequency {
+			if tf == 0 {
+				continue
+			}
+			avg := float64(sh.fieldLengths[field]) / n
+			norm := 1 - bm25B + bm25B*float64(doc.lengths[field])/avg
+			weighted += searchFieldWeights[field] * float64(tf) / norm
+		}
+		scores[id] = idf * weighted * (bm25K1 + 1) / (weighted + bm25K1)
+	}
+	return scores
+}
+
+// productTerms returns the index terms of every searchable field. Names and
+// descriptions are stemmed, SKUs are split into their parts so both "ts" and
+// "001" find "TS-001".
+func productTerms(product *DbProduct) [searchFieldCount][]string {
+	var terms [searchFieldCount][]string
+	for _, token := range tokenize(product.Name) {
+		terms[searchFieldName] = append(terms[searchFieldName], stem(token))
+	}
+	terms[searchFieldSku] = tokenize(product.Sku)
+	for _, token := range tokenize(product.Description) {
+		terms[searchFieldDescription] = append(terms[searchFieldDescription], stem(token))
+	}
+	return terms
+}
+
+// tokenize splits text into lower case runs of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// editDistance returns the optimal string alignment distance of a and b,
+// counting an adjacent transposition as one edit. It returns max+1 as soon
+// as the distance is known to exceed max.
+func editDistance(a, b []rune, max int) int {
+	if d := len(a) - len(b); d > max || -d > max {
+		return max + 1
+	}
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// normalize applies defaults and validates the query.
+func (q *SearchQuery) normalize() error {
+	var violations []FieldViolation
+	switch {
+	case q.PageSize < 0:
+		violations = append(violations, FieldViolation{Field: "page_size", Description: "must not be negative"})
+	case q.PageSize == 0:
+		q.PageSize = DefaultSearchPageSize
+	case q.PageSize > MaxSearchPageSize:
+		q.PageSize = MaxSearchPageSize
+	}
+	if len([]rune(q.Text)) > MaxSearchQueryLength {
+		violations = append(violations, FieldViolation{Field: "query", Description: "must not be longer than 256 characters"})
+	}
+	if len(violations) > 0 {
+		return InvalidArgumentError("invalid search query", violations...)
+	}
+	return nil
+}
+
+// fingerprint identifies the query text so a token cannot be replayed
+// against a different search.
+func (q *SearchQuery) fingerprint() uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(strings.Join(tokenize(q.Text), " ")))
+	if q.Public {
+		h.Write([]byte("|public"))
+	}
+	return h.Sum32()
+}
+
+// offset decodes the page token into the number of hits to skip.
+func (q *SearchQuery) offset() (int, error) {
+	if q.PageToken == "" {
+		return 0, nil
+	}
+	invalid := InvalidArgumentError("invalid page token", FieldViolation{Field: "page_token", Description: "is malformed or belongs to a different query"})
+	raw, err := base64.RawURLEncoding.DecodeString(q.PageToken)
+	if err != nil {
+		return 0, invalid
+	}
+	cursor := searchCursor{}
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Offset < 0 || cursor.Fingerprint != q.fingerprint() {
+		return 0, invalid
+	}
+	return cursor.Offset, nil
+}
+
+func (q *SearchQuery) encodeCursor(offset int) (string, error) {
+	raw, err := json.Marshal(searchCursor{Offset: offset, Fingerprint: q.fingerprint()})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// Search DbProducts by relevance in the search index and load the page of
+// hits from the database.
+func (p *ProductService) SearchProducts(ctx context.Context, query SearchQuery) (*SearchPage, error) {
+	if p.Index == nil {
+		return nil, &Error{Kind: KindUnavailable, Message: "product search is not enabled"}
+	}
+	if err := query.normalize(); err != nil {
+		return nil, err
+	}
+	offset, err := query.offset()
+	if err != nil {
+		return nil, err
+	}
+	search := p.Index.Search
+	if query.Public {
+		search = p.Index.SearchPublic
+	}
+	hits := search(query.Text)
+	page := &SearchPage{TotalHits: len(hits)}
+	if offset >= len(hits) {
+		return page, nil
+	}
+	end := min(offset+query.PageSize, len(hits))
+	if end < len(hits) {
+		if page.NextPageToken, err = query.encodeCursor(end); err != nil {
+			return nil, fmt.Errorf("failed to encode page token: %w", err)
+		}
+	}
+
+	ids := make([]uint64, 0, end-offset)
+	for _, hit := range hits[offset:end] {
+		ids = append(ids, hit.ProductID)
+	}
+	db, ctx, cancel := p.db(ctx)
+	defer cancel()
+	var products []*DbProduct
+	if err := db.Find(&products, ids).Error; err != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to load search hits: %w", err), ResourceProduct, nil)
+	}
+	byID := make(map[uint64]*DbProduct, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+	for _, hit := range hits[offset:end] {
+		if product, ok := byID[hit.ProductID]; ok {
+			page.Hits = append(page.Hits, hit)
+			page.Products = append(page.Products, product)
+		}
+	}
+	return page, nil
+}
+
+// Rebuild the search and suggestion indexes from all DbProducts in a single
+// pass and return how many were indexed. The previous indexes keep serving
+// meanwhile.
+func (p *ProductService) RebuildSearchIndex(ctx context.Context) (int, error) {
+	if p.Index == nil {
+		return 0, &Error{Kind: KindUnavailable, Message: "product search is not enabled"}
+	}
+	var rebuilds []indexRebuild
+	finish := func(err error) {
+		for _, rebuild := range rebuilds {
+			rebuild.finish(err)
+		}
+	}
+	for _, index := range p.indexes() {
+		rebuild, err := index.beginRebuild()
+		if err != nil {
+			finish(err)
+			return 0, err
+		}
+		rebuilds = append(rebuilds, rebuild)
+	}
+	indexed := 0
+	err := p.ExportProducts(ctx, 0, MaxExportBatchSize, func(product *DbProduct) error {
+		for _, rebuild := range rebuilds {
+			rebuild.add(product)
+		}
+		indexed++
+		return nil
+	})
+	finish(err)
+	if err != nil {
+		return 0, err
+	}
+	return indexed, nil
+}
@@ -0,0 +1,227 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSearchIndex() *SearchIndex {
+	index := NewSearchIndex()
+	index.Put(&DbProduct{ID: 1, Name: "Running Shirt", Sku: "TS-001", Description: "Breathable shirt for runners"})
+	index.Put(&DbProduct{ID: 2, Name: "Cotton Hoodie", Sku: "HD-002", Description: "Warm hoodie, pairs with a running shirt"})
+	index.Put(&DbProduct{ID: 3, Name: "Coffee Mug", Sku: "MG-003", Description: "Ceramic mug"})
+	return index
+}
+
+func hitIDs(hits []SearchHit) []uint64 {
+	ids := make([]uint64, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ProductID
+	}
+	return ids
+}
+
+func TestSearchIndex_Search(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected []uint64
+	}{
+		{name: "Stemmed terms, name match ranks first", query: "running shirts", expected: []uint64{1, 2}},
+		{name: "Every term must match", query: "running mug", expected: []uint64{}},
+		{name: "Last term matches as a prefix", query: "coff", expected: []uint64{3}},
+		{name: "SKU parts", query: "ts 001", expected: []uint64{1}},
+		{name: "One typo", query: "hoodei", expected: []uint64{2}},
+		{name: "Two typos in a long term", query: "breatheble", expected: []uint64{1}},
+		{name: "Too many typos", query: "mgu", expected: []uint64{}},
+		{name: "Empty query", query: " - ", expected: []uint64{}},
+	}
+	index := newTestSearchIndex()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			//when
+			hits := index.Search(tc.query)
+			//then
+			assert.Equal(t, tc.expected, hitIDs(hits))
+		})
+	}
+}
+
+func TestSearchIndex_SearchPublic(t *testing.T) {
+	// given
+	index := NewSearchIndex()
+	index.Put(&DbProduct{ID: 1, Name: "Running Shirt", Status: ProductActive, Visibility: VisibilitySearch})
+	index.Put(&DbProduct{ID: 2, Name: "Trail Shirt", Status: ProductDraft, Visibility: VisibilityBoth})
+	index.Put(&DbProduct{ID: 3, Name: "Shirt Hanger", Status: ProductActive, Visibility: VisibilityCatalog})
+	ended := time.Now().Add(-time.Hour)
+	index.Put(&DbProduct{ID: 4, Name: "Summer Shirt", Status: ProductActive, Visibility: VisibilityBoth, AvailableUntil: &ended})
+	//when
+	public := index.SearchPublic("shirt")
+	all := index.Search("shirt")
+	//then
+	assert.Equal(t, []uint64{1}, hitIDs(public))
+	assert.Len(t, all, 4)
+}
+
+func TestSearchIndex_PutAndRemove(t *testing.T) {
+	// given
+	index := newTestSearchIndex()
+	//when
+	index.Put(&DbProduct{ID: 3, Name: "Travel Mug", Sku: "MG-003"})
+	index.Remove(2)
+	//then
+	assert.Empty(t, index.Search("coffee"))
+	assert.Equal(t, []uint64{3}, hitIDs(index.Search("travel")))
+	assert.Empty(t, index.Search("hoodie"))
+	assert.Equal(t, 2, index.Len())
+}
+
+func TestSearchIndex_Rebuild(t *testing.T) {
+	t.Run("Changes during the rebuild win over loaded rows", func(t *testing.T) {
+		// given
+		index := newTestSearchIndex()
+		//when
+		indexed, err := index.Rebuild(func(add func(*DbProduct)) error {
+			index.Put(&DbProduct{ID: 4, Name: "Water Bottle"})
+			index.Remove(3)
+			add(&DbProduct{ID: 1, Name: "Running Shirt"})
+			add(&DbProduct{ID: 3, Name: "Coffee Mug"})
+			return nil
+		})
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 2, indexed)
+		assert.Equal(t, []uint64{4}, hitIDs(index.Search("bottle")))
+		assert.Empty(t, index.Search("mug"))
+		assert.Empty(t, index.Search("hoodie"))
+	})
+
+	t.Run("Failed rebuild keeps the index", func(t *testing.T) {
+		// given
+		index := newTestSearchIndex()
+		//when
+		_, err := index.Rebuild(func(add func(*DbProduct)) error {
+			add(&DbProduct{ID: 9, Name: "Poster"})
+			return errors.New("connection lost")
+		})
+		//then
+		assert.Error(t, err)
+		assert.Equal(t, 3, index.Len())
+	})
+}
+
+func TestProductService_KeepsSearchIndexInSync(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	product := &DbProduct{ID: 5, Name: "Desk Lamp"}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_products`")).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET `deleted_at`=?")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+	ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex()}
+
+	//when
+	_, err := ps.CreateProduct(context.Background(), product)
+	require.NoError(t, err)
+	created := hitIDs(ps.Index.Search("lamp"))
+	require.NoError(t, ps.UpdateProduct(context.Background(), &DbProduct{ID: 5, Name: "Floor Lamp", Revision: 1}))
+	updated := hitIDs(ps.Index.Search("floor"))
+	require.NoError(t, ps.DeleteProductByID(context.Background(), 5, 2))
+
+	//then
+	assert.Equal(t, []uint64{5}, created)
+	assert.Equal(t, []uint64{5}, updated)
+	assert.Empty(t, ps.Index.Search("lamp"))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestProductService_RebuildSearchIndex(t *testing.T) {
+	// given
+	db, sqlMock := newSqlMockDB(t)
+	ps := &ProductService{DB: GormWrapper{DB: db}, Index: newTestSearchIndex(), Suggestions: NewSuggestIndex()}
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE id > ? AND `catalog_products`.`deleted_at` IS NULL ORDER BY `catalog_products`.`id` LIMIT ?")).
+		WithArgs(0, MaxExportBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku"}).AddRow(7, "Desk Lamp", "LP-007"))
+	//when
+	indexed, err := ps.RebuildSearchIndex(context.Background())
+	//then
+	require.NoError(t, err)
+	assert.Equal(t, 1, indexed)
+	assert.Equal(t, []uint64{7}, hitIDs(ps.Index.Search("lamp")))
+	assert.Empty(t, ps.Index.Search("shirt"))
+	assert.Equal(t, []Suggestion{{Text: "LP-007", Kind: SuggestionSku, ProductID: 7}}, ps.Suggestions.Suggest("lp", 10))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance([]rune("mug"), []rune("mug"), 2))
+	assert.Equal(t, 1, editDistance([]rune("hoodei"), []rune("hoodie"), 2))
+	assert.Equal(t, 2, editDistance([]rune("shrit"), []rune("shirts"), 2))
+	assert.Equal(t, 2, editDistance([]rune("cotton"), []rune("cat"), 1))
+}
+
+func TestProductService_SearchProducts(t *testing.T) {
+	t.Run("Pages through hits by relevance", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: newTestSearchIndex()}
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ? AND `catalog_products`.`deleted_at` IS NULL")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Running Shirt"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ? AND `catalog_products`.`deleted_at` IS NULL")).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "Cotton Hoodie"))
+		//when
+		first, err := ps.SearchProducts(context.Background(), SearchQuery{Text: "running shirt", PageSize: 1})
+		require.NoError(t, err)
+		second, err := ps.SearchProducts(context.Background(), SearchQuery{Text: "Running, shirt!", PageSize: 1, PageToken: first.NextPageToken})
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, 2, first.TotalHits)
+		assert.Equal(t, []uint64{1}, hitIDs(first.Hits))
+		assert.Equal(t, []uint64{2}, hitIDs(second.Hits))
+		assert.Equal(t, "Cotton Hoodie", second.Products[0].Name)
+		assert.Empty(t, second.NextPageToken)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Token of a different query is rejected", func(t *testing.T) {
+		// given
+		ps := &ProductService{DB: new(DbWrapperMock), Index: newTestSearchIndex()}
+		query := SearchQuery{Text: "shirt"}
+		token, err := query.encodeCursor(1)
+		require.NoError(t, err)
+		//when
+		_, err = ps.SearchProducts(context.Background(), SearchQuery{Text: "mug", PageToken: token})
+		//then
+		assert.Equal(t, KindInvalidArgument, KindOf(err))
+	})
+
+	t.Run("Search disabled", func(t *testing.T) {
+		// given
+		ps := &ProductService{DB: new(DbWrapperMock)}
+		//when
+		_, err := ps.SearchProducts(context.Background(), SearchQuery{Text: "mug"})
+		//then
+		assert.Equal(t, KindUnavailable, KindOf(err))
+	})
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var kindCodes = map[ErrorKind]codes.Code{
+	KindInternal:           codes.Internal,
+	KindNotFound:           codes.NotFound,
+	KindAlreadyExists:      codes.AlreadyExists,
+	KindInvalidArgument:    codes.InvalidArgument,
+	KindConflict:           codes.Aborted,
+	KindUnavailable:        codes.Unavailable,
+	KindFailedPrecondition: codes.FailedPrecondition,
+	KindUnauthenticated:    codes.Unauthenticated,
+}
+
+// toStatus converts an error returned by the service layer into a gRPC status
+// error carrying errdetails. Errors that already are gRPC statuses are passed
+// through unchanged.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if isContextError(err) {
+		if errors.Is(err, context.Canceled) {
+			return status.Error(codes.Canceled, "request canceled")
+		}
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		e = &Error{Kind: KindInternal, Err: err}
+	}
+
+	st := status.New(kindCodes[e.Kind], e.PublicMessage())
+	var details []protoadapt.MessageV1
+	if len(e.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range e.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if e.Resource != "" && e.Kind != KindInternal {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: e.Resource,
+			ResourceName: e.ID,
+			Description:  e.Kind.String(),
+		})
+	}
+	if e.RetryDelay > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryDelay)})
+	}
+	if len(details) == 0 {
+		return st.Err()
+	}
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		log.Printf("Failed to attach error details: %v", detailsErr)
+		return st.Err()
+	}
+	return withDetails.Err()
+}
@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	testCases := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"shirts":         "shirt",
+		"running":        "run",
+		"hopping":        "hop",
+		"filing":         "file",
+		"agreed":         "agre",
+		"happy":          "happi",
+		"relational":     "relat",
+		"generalization": "gener",
+		"hopefulness":    "hope",
+		"adjustment":     "adjust",
+		"controll":       "control",
+		"tv":             "tv",
+		"café":           "café",
+		"4k":             "4k",
+	}
+	for word, expected := range testCases {
+		t.Run(word, func(t *testing.T) {
+			assert.Equal(t, expected, stem(word))
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultSuggestLimit = 10
+	MaxSuggestLimit     = 20
+	// MaxSuggestPrefixLength caps the prefix in runes.
+	MaxSuggestPrefixLength = 128
+)
+
+// SuggestionKind tells which product field a suggestion completes.
+type SuggestionKind string
+
+const (
+	SuggestionName SuggestionKind = "name"
+	SuggestionSku  SuggestionKind = "sku"
+)
+
+// Suggestion is a completion of the typed prefix.
+type Suggestion struct {
+	Text      string
+	Kind      SuggestionKind
+	ProductID uint64
+}
+
+// SuggestIndex completes prefixes of product names and SKUs from in-memory
+// tries. Names are also completed from the start of every word, so "shi"
+// suggests "Running Shirt". Recently updated products rank first. Only
+// products the public finds by search are suggested. It is safe for
+// concurrent use.
+type SuggestIndex struct {
+	liveIndex[*suggestShard]
+}
+
+type suggestEntry struct {
+	Suggestion
+	// rank orders entries, higher first.
+	rank int64
+}
+
+// trieNode keeps the best entries of its subtree so a lookup costs the
+// length of the prefix only.
+type trieNode struct {
+	children map[byte]*trieNode
+	// entries are the entries whose key ends at this node.
+	entries []*suggestEntry
+	top     []*suggestEntry
+}
+
+type suggestShard struct {
+	names *trieNode
+	skus  *trieNode
+	// keys remembers where the entries of a product are stored for removal.
+	keys map[uint64][]suggestKey
+}
+
+type suggestKey struct {
+	root  *trieNode
+	key   string
+	entry *suggestEntry
+}
+
+func NewSuggestIndex() *SuggestIndex {
+	return &SuggestIndex{newLiveIndex(newSuggestShard)}
+}
+
+func newSuggestShard() *suggestShard {
+	return &suggestShard{names: &trieNode{}, skus: &trieNode{}, keys: map[uint64][]suggestKey{}}
+}
+
+// Suggest returns up to limit completions of prefix, best first. Equal
+// texts of several products are suggested once.
+func (s *SuggestIndex) Suggest(prefix string, limit int) []Suggestion {
+	nameKey := strings.Join(tokenize(prefix), " ")
+	skuKey := strings.ToLower(strings.TrimSpace(prefix))
+	s.mu.RLock()
+	var candidates []*suggestEntry
+	if nameKey != "" {
+		candidates = append(candidates, s.shard.names.lookup(nameKey)...)
+	}
+	if skuKey != "" {
+		candidates = append(candidates, s.shard.skus.lookup(skuKey)...)
+	}
+	s.mu.RUnlock()
+
+	sortEntries(candidates)
+	suggestions := make([]Suggestion, 0, min(limit, len(candidates)))
+	seen := map[Suggestion]bool{}
+	for _, entry := range candidates {
+		key := Suggestion{Text: entry.Text, Kind: entry.Kind}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		suggestions = append(suggestions, entry.Suggestion)
+		if len(suggestions) == limit {
+			break
+		}
+	}
+	return suggestions
+}
+
+// put indexes the product if the public finds it by search, suggestions
+// complete what is typed into a storefront search box. Products crossing
+// their availability window are put again by ProductService.RunScheduler.
+func (sh *suggestShard) put(product *DbProduct) {
+	sh.remove(product.ID)
+	if !product.InSearch(time.Now()) {
+		return
+	}
+	rank := product.UpdatedAt.UnixNano()
+	var keys []suggestKey
+	if tokens := tokenize(product.Name); len(tokens) > 0 {
+		entry := &suggestEntry{Suggestion: Suggestion{Text: product.Name, Kind: SuggestionName, ProductID: product.ID}, rank: rank}
+		for i := range tokens {
+			keys = append(keys, suggestKey{root: sh.names, key: strings.Join(tokens[i:], " "), entry: entry})
+		}
+	}
+	if sku := strings.ToLower(strings.TrimSpace(product.Sku)); sku != "" {
+		entry := &suggestEntry{Suggestion: Suggestion{Text: product.Sku, Kind: SuggestionSku, ProductID: product.ID}, rank: rank}
+		keys = append(keys, suggestKey{root: sh.skus, key: sku, entry: entry})
+	}
+	for _, key := range keys {
+		key.root.insert(key.key, key.entry)
+	}
+	if len(keys) > 0 {
+		sh.keys[product.ID] = keys
+	}
+}
+
+func (sh *suggestShard) remove(id uint64) {
+	for _, key := range sh.keys[id] {
+		key.root.delete(key.key, key.entry)
+	}
+	delete(sh.keys, id)
+}
+
+func (sh *suggestShard) size() int {
+	return len(sh.keys)
+}
+
+func (n *trieNode) lookup(prefix string) []*suggestEntry {
+	for i := 0; i < len(prefix) && n != nil; i++ {
+		n = n.children[prefix[i]]
+	}
+	if n == nil {
+		return nil
+	}
+	return n.top
+}
+
+func (n *trieNode) insert(key string, entry *suggestEntry) {
+	path := []*trieNode{n}
+	for i := 0; i < len(key); i++ {
+		if n.children == nil {
+			n.children = map[byte]*trieNode{}
+		}
+		child := n.children[key[i]]
+		if child == nil {
+			child = &trieNode{}
+			n.children[key[i]] = child
+		}
+		n = child
+		path = append(path, n)
+	}
+	n.entries = append(n.entries, entry)
+	updateTops(path)
+}
+
+func (n *trieNode) delete(key string, entry *suggestEntry) {
+	path := []*trieNode{n}
+	for i := 0; i < len(key); i++ {
+		if n = n.children[key[i]]; n == nil {
+			return
+		}
+		path = append(path, n)
+	}
+	for i, e := range n.entries {
+		if e == entry {
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+			break
+		}
+	}
+	// prune nodes left without entries below them
+	for i := len(path) - 1; i > 0; i-- {
+		if len(path[i].entries) > 0 || len(path[i].children) > 0 {
+			break
+		}
+		delete(path[i-1].children, key[i-1])
+	}
+	updateTops(path)
+}
+
+// updateTops recomputes the best entries along path from the leaf up. The
+// best entries of a subtree are among those of its node and the best of its
+// children.
+func updateTops(path []*trieNode) {
+	for i := len(path) - 1; i >= 0; i-- {
+		n := path[i]
+		candidates := append([]*suggestEntry(nil), n.entries...)
+		for _, child := range n.children {
+			candidates = append(candidates, child.top...)
+		}
+		sortEntries(candidates)
+		top := make([]*suggestEntry, 0, min(len(candidates), MaxSuggestLimit))
+		for j, entry := range candidates {
+			// an entry reachable by several keys appears more than once
+			if j > 0 && entry == candidates[j-1] {
+				continue
+			}
+			if len(top) == MaxSuggestLimit {
+				break
+			}
+			top = append(top, entry)
+		}
+		n.top = top
+	}
+}
+
+// sortEntries orders entries by rank, then text and product so equal
+// entries end up adjacent.
+func sortEntries(entries []*suggestEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch {
+		case a.rank != b.rank:
+			return a.rank > b.rank
+		case a.Text != b.Text:
+			return a.Text < b.Text
+		case a.Kind != b.Kind:
+			return a.Kind < b.Kind
+		}
+		return a.ProductID < b.ProductID
+	})
+}
+
+// Suggest product names and SKUs completing prefix from the suggestion index.
+func (p *ProductService) SuggestProducts(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	if p.Suggestions == nil {
+		return nil, &Error{Kind: KindUnavailable, Message: "product suggestions are not enabled"}
+	}
+	var violations []FieldViolation
+	switch {
+	case limit < 0:
+		violations = append(violations, FieldViolation{Field: "limit", Description: "must not be negative"})
+	case limit == 0:
+		limit = DefaultSuggestLimit
+	case limit > MaxSuggestLimit:
+		limit = MaxSuggestLimit
+	}
+	if len([]rune(prefix)) > MaxSuggestPrefixLength {
+		violations = append(violations, FieldViolation{Field: "prefix", Description: fmt.Sprintf("must not be longer than %d characters", MaxSuggestPrefixLength)})
+	}
+	if len(violations) > 0 {
+		return nil, InvalidArgumentError("invalid suggest query", violations...)
+	}
+	return p.Suggestions.Suggest(prefix, limit), nil
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestSuggestIndex() *SuggestIndex {
+	updated := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	index := NewSuggestIndex()
+	index.Put(&DbProduct{ID: 1, Name: "Running Shirt", Sku: "TS-001", Model: gorm.Model{UpdatedAt: updated}})
+	index.Put(&DbProduct{ID: 2, Name: "Running Shoes", Sku: "SH-002", Model: gorm.Model{UpdatedAt: updated.Add(time.Hour)}})
+	index.Put(&DbProduct{ID: 3, Name: "Shirt Hanger", Sku: "HG-003", Model: gorm.Model{UpdatedAt: updated.Add(-time.Hour)}})
+	return index
+}
+
+func suggestionTexts(suggestions []Suggestion) []string {
+	texts := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		texts[i] = suggestion.Text
+	}
+	return texts
+}
+
+func TestSuggestIndex_Suggest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefix   string
+		limit    int
+		expected []string
+	}{
+		{name: "Recently updated first", prefix: "run", limit: 10, expected: []string{"Running Shoes", "Running Shirt"}},
+		{name: "Start of any word", prefix: "shi", limit: 10, expected: []string{"Running Shirt", "Shirt Hanger"}},
+		{name: "Names and SKUs", prefix: "sh", limit: 10, expected: []string{"Running Shoes", "SH-002", "Running Shirt", "Shirt Hanger"}},
+		{name: "Multiple words", prefix: "Running  SH", limit: 10, expected: []string{"Running Shoes", "Running Shirt"}},
+		{name: "SKU with separators", prefix: "ts-0", limit: 10, expected: []string{"TS-001"}},
+		{name: "Limit", prefix: "r", limit: 1, expected: []string{"Running Shoes"}},
+		{name: "No match", prefix: "mug", limit: 10, expected: []string{}},
+		{name: "Empty prefix", prefix: " ", limit: 10, expected: []string{}},
+	}
+	index := newTestSuggestIndex()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			//when
+			suggestions := index.Suggest(tc.prefix, tc.limit)
+			//then
+			assert.Equal(t, tc.expected, suggestionTexts(suggestions))
+		})
+	}
+}
+
+func TestSuggestIndex_PutAndRemove(t *testing.T) {
+	// given
+	index := newTestSuggestIndex()
+	//when
+	index.Put(&DbProduct{ID: 1, Name: "Trail Shirt", Sku: "TS-001", Model: gorm.Model{UpdatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}})
+	index.Remove(2)
+	//then
+	assert.Equal(t, []string{"Trail Shirt", "Shirt Hanger"}, suggestionTexts(index.Suggest("shirt", 10)))
+	assert.Empty(t, index.Suggest("run", 10))
+	assert.Equal(t, []Suggestion{{Text: "TS-001", Kind: SuggestionSku, ProductID: 1}}, index.Suggest("ts", 10))
+	assert.Equal(t, 2, index.Len())
+}
+
+func TestSuggestIndex_OnlyPublicProducts(t *testing.T) {
+	// given
+	index := newTestSuggestIndex()
+	//when
+	index.Put(&DbProduct{ID: 1, Name: "Running Shirt", Sku: "TS-001", Status: ProductArchived, Visibility: VisibilityBoth})
+	index.Put(&DbProduct{ID: 4, Name: "Shirt Box", Status: ProductActive, Visibility: VisibilityCatalog})
+	//then
+	assert.NotContains(t, suggestionTexts(index.Suggest("shirt", 10)), "Running Shirt")
+	assert.NotContains(t, suggestionTexts(index.Suggest("shirt", 10)), "Shirt Box")
+	assert.Empty(t, index.Suggest("ts", 10))
+}
+
+func TestSuggestIndex_KeepsBestOfLargeSubtrees(t *testing.T) {
+	// given
+	index := NewSuggestIndex()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 3*MaxSuggestLimit; i++ {
+		index.Put(&DbProduct{ID: uint64(i), Name: fmt.Sprintf("Poster %02d", i), Model: gorm.Model{UpdatedAt: base.Add(time.Duration(i) * time.Minute)}})
+	}
+	//when
+	index.Remove(3 * MaxSuggestLimit)
+	suggestions := index.Suggest("poster", 2)
+	//then
+	assert.Equal(t, []string{"Poster 59", "Poster 58"}, suggestionTexts(suggestions))
+	assert.Len(t, index.Suggest("p", MaxSuggestLimit), MaxSuggestLimit)
+}
+
+func TestProductService_SuggestProducts(t *testing.T) {
+	t.Run("Default and capped limit", func(t *testing.T) {
+		// given
+		ps := &ProductService{DB: new(DbWrapperMock), Suggestions: newTestSuggestIndex()}
+		//when
+		suggestions, err := ps.SuggestProducts(context.Background(), "running", 0)
+		require.NoError(t, err)
+		capped, err := ps.SuggestProducts(context.Background(), "s", 1000)
+		//then
+		require.NoError(t, err)
+		assert.Len(t, suggestions, 2)
+		assert.Len(t, capped, 4)
+	})
+
+	t.Run("Negative limit", func(t *testing.T) {
+		// given
+		ps := &ProductService{DB: new(DbWrapperMock), Suggestions: newTestSuggestIndex()}
+		//when
+		_, err := ps.SuggestProducts(context.Background(), "running", -1)
+		//then
+		assert.Equal(t, KindInvalidArgument, KindOf(err))
+	})
+
+	t.Run("Suggestions disabled", func(t *testing.T) {
+		// given
+		ps := &ProductService{DB: new(DbWrapperMock)}
+		//when
+		_, err := ps.SuggestProducts(context.Background(), "running", 5)
+		//then
+		assert.Equal(t, KindUnavailable, KindOf(err))
+	})
+}
@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// Tx is a DbWrapper bound to a database transaction, or to a savepoint when
+// it was begun within another transaction. Every Tx must end with either
+// Commit or Rollback.
+type Tx interface {
+	DbWrapper
+	// Commit makes the changes permanent. Committing a savepoint keeps its
+	// changes in the enclosing transaction.
+	Commit() error
+	// Rollback discards the changes, of a savepoint only back to where it
+	// was set.
+	Rollback() error
+}
+
+// savepoints names the savepoints of nested transactions.
+var savepoints atomic.Uint64
+
+// Begin starts a transaction, within a transaction it sets a savepoint.
+func (g GormWrapper) Begin(opts ...*sql.TxOptions) (Tx, error) {
+	if committer, ok := g.Statement.ConnPool.(gorm.TxCommitter); ok && committer != nil {
+		name := fmt.Sprintf("sp%d", savepoints.Add(1))
+		if err := g.DB.SavePoint(name).Error; err != nil {
+			return nil, err
+		}
+		return &gormTx{GormWrapper: g, savepoint: name}, nil
+	}
+	tx := g.DB.Begin(opts...)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return &gormTx{GormWrapper: GormWrapper{DB: tx}}, nil
+}
+
+// gormTx adapts a GORM transaction to Tx.
+type gormTx struct {
+	GormWrapper
+	// savepoint is set for nested transactions.
+	savepoint string
+}
+
+func (t *gormTx) Commit() error {
+	if t.savepoint != "" {
+		// Released together with the enclosing transaction.
+		return nil
+	}
+	return t.DB.Commit().Error
+}
+
+func (t *gormTx) Rollback() error {
+	if t.savepoint != "" {
+		return t.DB.RollbackTo(t.savepoint).Error
+	}
+	return t.DB.Rollback().Error
+}
+
+// InTransaction runs fn as one unit of work: every call of the
+// ProductServiceInterface passed to fn takes part in a single transaction
+// committed when fn returns nil and rolled back otherwise. Calling
+// InTransaction on that ProductServiceInterface sets a savepoint. Index
+// updates and change events wait for the commit of the outermost
+// transaction and are dropped on rollback.
+func (p *ProductService) InTransaction(ctx context.Context, fn func(tx ProductServiceInterface) error) error {
+	tx, err := p.DB.WithContext(ctx).Begin()
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to begin a transaction: %w", err), ResourceProduct, nil)
+	}
+	unit := *p
+	unit.DB = tx
+	unit.pending = new([]func())
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		// Also reached when fn panics, the panic goes on.
+		if err := tx.Rollback(); err != nil {
+			log.Printf("Failed to roll back a transaction. Error: %v", err)
+		}
+	}()
+	if err := fn(&unit); err != nil {
+		return err
+	}
+	err = tx.Commit()
+	committed = true
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to commit a transaction: %w", err), ResourceProduct, nil)
+	}
+	for _, effect := range *unit.pending {
+		p.afterCommit(effect)
+	}
+	return nil
+}
+
+// afterCommit runs effect once the changes it reflects are committed: right
+// away outside of InTransaction, with the commit of the outermost
+// transaction within.
+func (p *ProductService) afterCommit(effect func()) {
+	if p.pending != nil {
+		*p.pending = append(*p.pending, effect)
+		return
+	}
+	effect()
+}
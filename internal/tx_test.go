@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductService_InTransaction(t *testing.T) {
+	expectUpdate := func(sqlMock sqlmock.Sqlmock) {
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_variants`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE `catalog_products` SET")).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	t.Run("Changes are committed together", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex()}
+		sqlMock.ExpectBegin()
+		expectUpdate(sqlMock)
+		expectUpdate(sqlMock)
+		sqlMock.ExpectCommit()
+		//when
+		err := ps.InTransaction(context.Background(), func(tx ProductServiceInterface) error {
+			if err := tx.UpdateProduct(context.Background(), &DbProduct{ID: 1, Name: "Desk Lamp", Revision: 1}); err != nil {
+				return err
+			}
+			assert.Empty(t, ps.Index.Search("lamp"))
+			return tx.UpdateProduct(context.Background(), &DbProduct{ID: 2, Name: "Floor Lamp", Revision: 1})
+		})
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1, 2}, hitIDs(ps.Index.Search("lamp")))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Error rolls everything back", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex()}
+		failure := errors.New("prices rejected")
+		sqlMock.ExpectBegin()
+		expectUpdate(sqlMock)
+		sqlMock.ExpectRollback()
+		//when
+		err := ps.InTransaction(context.Background(), func(tx ProductServiceInterface) error {
+			if err := tx.UpdateProduct(context.Background(), &DbProduct{ID: 1, Name: "Desk Lamp", Revision: 1}); err != nil {
+				return err
+			}
+			return failure
+		})
+		//then
+		assert.Equal(t, failure, err)
+		assert.Empty(t, ps.Index.Search("lamp"))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Nested unit of work rolls back to its savepoint", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		ps := &ProductService{DB: GormWrapper{DB: db}, Index: NewSearchIndex()}
+		sqlMock.ExpectBegin()
+		expectUpdate(sqlMock)
+		sqlMock.ExpectExec(`SAVEPOINT sp\d+`).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectUpdate(sqlMock)
+		sqlMock.ExpectExec(`ROLLBACK TO SAVEPOINT sp\d+`).WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectCommit()
+		//when
+		var nested error
+		err := ps.InTransaction(context.Background(), func(tx ProductServiceInterface) error {
+			if err := tx.UpdateProduct(context.Background(), &DbProduct{ID: 1, Name: "Desk Lamp", Revision: 1}); err != nil {
+				return err
+			}
+			nested = tx.InTransaction(context.Background(), func(tx ProductServiceInterface) error {
+				if err := tx.UpdateProduct(context.Background(), &DbProduct{ID: 2, Name: "Floor Lamp", Revision: 1}); err != nil {
+					return err
+				}
+				return ConflictError(ResourceProduct, 2, "stale")
+			})
+			return nil
+		})
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, KindConflict, KindOf(nested))
+		assert.Equal(t, []uint64{1}, hitIDs(ps.Index.Search("lamp")))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Mocked transaction", func(t *testing.T) {
+		// given
+		tx := new(TxMock)
+		tx.On("Commit").Return(nil)
+		dbWrapper := new(DbWrapperMock)
+		dbWrapper.On("Begin", mock.Anything).Return(tx, nil)
+		ps := &ProductService{DB: dbWrapper}
+		//when
+		err := ps.InTransaction(context.Background(), func(tx ProductServiceInterface) error { return nil })
+		//then
+		assert.NoError(t, err)
+		dbWrapper.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("Database down", func(t *testing.T) {
+		// given
+		dbWrapper := new(DbWrapperMock)
+		dbWrapper.On("Begin", mock.Anything).Return(nil, driver.ErrBadConn)
+		ps := &ProductService{DB: dbWrapper}
+		//when
+		err := ps.InTransaction(context.Background(), func(tx ProductServiceInterface) error {
+			t.Fatal("unit of work run without a transaction")
+			return nil
+		})
+		//then
+		assert.Equal(t, KindUnavailable, KindOf(err))
+	})
+}
@@ -0,0 +1,383 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationRules holds the deployment specific limits applied to products
+// before they are written to the database.
+type ValidationRules struct {
+	MaxNameLength        int
+	MaxSkuLength         int
+	MaxDescriptionLength int
+	MaxImageURLLength    int
+	// ImageSchemes lists the URL schemes accepted for product images.
+	ImageSchemes []string
+}
+
+func DefaultValidationRules() ValidationRules {
+	return ValidationRules{
+		MaxNameLength:        255,
+		MaxSkuLength:         64,
+		MaxDescriptionLength: 65535,
+		MaxImageURLLength:    2048,
+		ImageSchemes:         []string{"http", "https"},
+	}
+}
+
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+var (
+	slugPattern          = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	attributeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// Limits matching the size of the option name, category slug, attribute
+// code, synonym input and stop word columns.
+const (
+	maxOptionNameLength    = 64
+	maxSlugLength          = 255
+	maxAttributeCodeLength = 64
+	maxSynonymTermLength   = 255
+	maxStopWordLength      = 64
+)
+
+// Limits keeping synonym expansion and stop word lists manageable.
+const (
+	maxSynonymTerms = 32
+	maxStopWords    = 1000
+)
+
+// ProductValidator checks products against ValidationRules. It is shared by
+// every write path (single and bulk) so the rules are enforced consistently.
+type ProductValidator struct {
+	Rules ValidationRules
+}
+
+func NewProductValidator(rules ValidationRules) *ProductValidator {
+	return &ProductValidator{Rules: rules}
+}
+
+// Validate returns an InvalidArgument *Error listing every violation of the
+// product, or nil when the product is valid.
+func (v *ProductValidator) Validate(product *DbProduct) error {
+	violations := v.Violations(product)
+	if len(violations) == 0 {
+		return nil
+	}
+	return InvalidArgumentError("invalid product", violations...)
+}
+
+// Violations collects all rule violations of the product.
+func (v *ProductValidator) Violations(product *DbProduct) []FieldViolation {
+	var violations []FieldViolation
+	add := func(field, format string, args ...interface{}) {
+		violations = append(violations, FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(product.Name) == "" {
+		add("name", "must not be empty")
+	} else if n := utf8.RuneCountInString(product.Name); n > v.Rules.MaxNameLength {
+		add("name", "must be at most %d characters, got %d", v.Rules.MaxNameLength, n)
+	}
+
+	if reason := v.checkSku(product.Sku); reason != "" {
+		add("sku", reason)
+	}
+
+	if n := utf8.RuneCountInString(product.Description); n > v.Rules.MaxDescriptionLength {
+		add("description", "must be at most %d characters, got %d", v.Rules.MaxDescriptionLength, n)
+	}
+
+	violations = append(violations, priceViolations("price", product.Price)...)
+	for i, price := range product.Prices {
+		violations = append(violations, priceViolations(fmt.Sprintf("prices[%d]", i), price.Money())...)
+	}
+
+	if product.Image != "" {
+		if reason := v.checkImageURL(product.Image); reason != "" {
+			add("image", reason)
+		}
+	}
+
+	return violations
+}
+
+// PriceViolations checks a full set of product prices: every currency may
+// appear only once.
+func (v *ProductValidator) PriceViolations(prices []Money) []FieldViolation {
+	var violations []FieldViolation
+	seen := make(map[string]bool, len(prices))
+	for i, price := range prices {
+		field := fmt.Sprintf("prices[%d]", i)
+		violations = append(violations, priceViolations(field, price)...)
+		if seen[price.Currency] {
+			violations = append(violations, FieldViolation{Field: field + ".currency", Description: fmt.Sprintf("duplicate currency %q", price.Currency)})
+		}
+		seen[price.Currency] = true
+	}
+	return violations
+}
+
+// VariantViolations checks the fields of a variant itself. Its options are
+// checked against the product when the variant is written.
+func (v *ProductValidator) VariantViolations(variant *DbVariant) []FieldViolation {
+	var violations []FieldViolation
+	if reason := v.checkSku(variant.Sku); reason != "" {
+		violations = append(violations, FieldViolation{Field: "sku", Description: reason})
+	}
+	if variant.HasPrice() {
+		violations = append(violations, priceViolations("price", variant.Price)...)
+	}
+	if variant.Image != "" {
+		if reason := v.checkImageURL(variant.Image); reason != "" {
+			violations = append(violations, FieldViolation{Field: "image", Description: reason})
+		}
+	}
+	return violations
+}
+
+// OptionViolations checks the option axes of a product: names and values
+// must be non-empty and unique.
+func (v *ProductValidator) OptionViolations(options []DbProductOption) []FieldViolation {
+	var violations []FieldViolation
+	add := func(field, format string, args ...interface{}) {
+		violations = append(violations, FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+	names := make(map[string]bool, len(options))
+	for i, option := range options {
+		field := fmt.Sprintf("options[%d]", i)
+		switch {
+		case strings.TrimSpace(option.Name) == "":
+			add(field+".name", "must not be empty")
+		case utf8.RuneCountInString(option.Name) > maxOptionNameLength:
+			add(field+".name", "must be at most %d characters", maxOptionNameLength)
+		case names[option.Name]:
+			add(field+".name", "duplicate option %q", option.Name)
+		}
+		names[option.Name] = true
+		if len(option.Values) == 0 {
+			add(field+".values", "must not be empty")
+		}
+		values := make(map[string]bool, len(option.Values))
+		for j, value := range option.Values {
+			if strings.TrimSpace(value) == "" {
+				add(fmt.Sprintf("%s.values[%d]", field, j), "must not be empty")
+			} else if values[value] {
+				add(fmt.Sprintf("%s.values[%d]", field, j), "duplicate value %q", value)
+			}
+			values[value] = true
+		}
+	}
+	return violations
+}
+
+// CategoryViolations checks the name and slug of a category.
+func (v *ProductValidator) CategoryViolations(category *DbCategory) []FieldViolation {
+	var violations []FieldViolation
+	if strings.TrimSpace(category.Name) == "" {
+		violations = append(violations, FieldViolation{Field: "name", Description: "must not be empty"})
+	} else if n := utf8.RuneCountInString(category.Name); n > v.Rules.MaxNameLength {
+		violations = append(violations, FieldViolation{Field: "name", Description: fmt.Sprintf("must be at most %d characters, got %d", v.Rules.MaxNameLength, n)})
+	}
+	switch {
+	case category.Slug == "":
+		violations = append(violations, FieldViolation{Field: "slug", Description: "must not be empty"})
+	case len(category.Slug) > maxSlugLength:
+		violations = append(violations, FieldViolation{Field: "slug", Description: fmt.Sprintf("must be at most %d characters", maxSlugLength)})
+	case !slugPattern.MatchString(category.Slug):
+		violations = append(violations, FieldViolation{Field: "slug", Description: "may only contain lowercase letters and digits separated by single '-'"})
+	}
+	return violations
+}
+
+// AttributeViolations checks an attribute definition: its code, type, enum
+// options and the rules that apply to its type.
+func (v *ProductValidator) AttributeViolations(attribute *DbAttribute) []FieldViolation {
+	var violations []FieldViolation
+	add := func(field, format string, args ...interface{}) {
+		violations = append(violations, FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case attribute.Code == "":
+		add("code", "must not be empty")
+	case len(attribute.Code) > maxAttributeCodeLength:
+		add("code", "must be at most %d characters", maxAttributeCodeLength)
+	case !attributeCodePattern.MatchString(attribute.Code):
+		add("code", "may only contain lowercase letters, digits and '_' and must start with a letter")
+	}
+	if strings.TrimSpace(attribute.Name) == "" {
+		add("name", "must not be empty")
+	} else if n := utf8.RuneCountInString(attribute.Name); n > v.Rules.MaxNameLength {
+		add("name", "must be at most %d characters, got %d", v.Rules.MaxNameLength, n)
+	}
+
+	isEnum := attribute.Type == AttributeEnum || attribute.Type == AttributeMultiEnum
+	isNumber := attribute.Type == AttributeInt || attribute.Type == AttributeDecimal
+	switch attribute.Type {
+	case AttributeString, AttributeInt, AttributeDecimal, AttributeBool, AttributeEnum, AttributeMultiEnum:
+	default:
+		add("type", "must be one of string, int, decimal, bool, enum, multi_enum")
+	}
+	if isEnum && len(attribute.Options) == 0 {
+		add("options", "must not be empty for %s attributes", attribute.Type)
+	}
+	if !isEnum && len(attribute.Options) > 0 {
+		add("options", "are only allowed for enum attributes")
+	}
+	seen := make(map[string]bool, len(attribute.Options))
+	for i, option := range attribute.Options {
+		field := fmt.Sprintf("options[%d]", i)
+		switch {
+		case strings.TrimSpace(option) == "":
+			add(field, "must not be empty")
+		case utf8.RuneCountInString(option) > maxAttributeValueLength:
+			add(field, "must be at most %d characters", maxAttributeValueLength)
+		case seen[option]:
+			add(field, "duplicate option %q", option)
+		}
+		seen[option] = true
+	}
+
+	rules := attribute.Rules
+	if rules.MaxLength != 0 || rules.Pattern != "" {
+		if attribute.Type != AttributeString {
+			add("rules", "max_length and pattern are only allowed for string attributes")
+		} else if rules.MaxLength < 0 || rules.MaxLength > maxAttributeValueLength {
+			add("rules.max_length", "must be between 0 and %d", maxAttributeValueLength)
+		}
+		if _, err := regexp.Compile(rules.Pattern); err != nil {
+			add("rules.pattern", "must be a valid regular expression")
+		}
+	}
+	if rules.Min != "" || rules.Max != "" {
+		if !isNumber {
+			add("rules", "min and max are only allowed for int and decimal attributes")
+		}
+		min, _, minOk := parseDecimal(rules.Min)
+		max, _, maxOk := parseDecimal(rules.Max)
+		if rules.Min != "" && !minOk {
+			add("rules.min", "must be a decimal number")
+		}
+		if rules.Max != "" && !maxOk {
+			add("rules.max", "must be a decimal number")
+		}
+		if minOk && maxOk && min.Cmp(max) > 0 {
+			add("rules.max", "must not be less than min")
+		}
+	}
+	return violations
+}
+
+// AttributeSetViolations checks the name of an attribute set.
+func (v *ProductValidator) AttributeSetViolations(set *DbAttributeSet) []FieldViolation {
+	if strings.TrimSpace(set.Name) == "" {
+		return []FieldViolation{{Field: "name", Description: "must not be empty"}}
+	}
+	if n := utf8.RuneCountInString(set.Name); n > v.Rules.MaxNameLength {
+		return []FieldViolation{{Field: "name", Description: fmt.Sprintf("must be at most %d characters, got %d", v.Rules.MaxNameLength, n)}}
+	}
+	return nil
+}
+
+// SynonymSetViolations checks the type and terms of a synonym set. Terms must
+// have words to match and at most maxSynonymWords of them.
+func (v *ProductValidator) SynonymSetViolations(set *DbSynonymSet) []FieldViolation {
+	var violations []FieldViolation
+	minTerms := 1
+	switch set.Type {
+	case SynonymEquivalent:
+		minTerms = 2
+		if set.Input != "" {
+			violations = append(violations, FieldViolation{Field: "input", Description: "must be empty for equivalent synonyms"})
+		}
+	case SynonymOneWay:
+		if description := checkSynonymTerm(set.Input); description != "" {
+			violations = append(violations, FieldViolation{Field: "input", Description: description})
+		}
+	default:
+		violations = append(violations, FieldViolation{Field: "type", Description: "must be equivalent or one_way"})
+	}
+	if len(set.Terms) < minTerms || len(set.Terms) > maxSynonymTerms {
+		violations = append(violations, FieldViolation{Field: "terms", Description: fmt.Sprintf("must have %d to %d terms", minTerms, maxSynonymTerms)})
+	}
+	for i, term := range set.Terms {
+		if description := checkSynonymTerm(term); description != "" {
+			violations = append(violations, FieldViolation{Field: fmt.Sprintf("terms[%d]", i), Description: description})
+		}
+	}
+	return violations
+}
+
+// StopWordViolations checks that every stop word is a single word.
+func (v *ProductValidator) StopWordViolations(words []string) []FieldViolation {
+	if len(words) > maxStopWords {
+		return []FieldViolation{{Field: "words", Description: fmt.Sprintf("must have at most %d words", maxStopWords)}}
+	}
+	var violations []FieldViolation
+	for i, word := range words {
+		switch tokens := tokenize(word); {
+		case len(tokens) != 1 || tokens[0] != strings.ToLower(strings.TrimSpace(word)):
+			violations = append(violations, FieldViolation{Field: fmt.Sprintf("words[%d]", i), Description: "must be a single word of letters and digits"})
+		case utf8.RuneCountInString(tokens[0]) > maxStopWordLength:
+			violations = append(violations, FieldViolation{Field: fmt.Sprintf("words[%d]", i), Description: fmt.Sprintf("must be at most %d characters", maxStopWordLength)})
+		}
+	}
+	return violations
+}
+
+func checkSynonymTerm(term string) string {
+	switch words := tokenize(term); {
+	case len(words) == 0:
+		return "must have a word"
+	case len(words) > maxSynonymWords:
+		return fmt.Sprintf("must have at most %d words", maxSynonymWords)
+	case utf8.RuneCountInString(term) > maxSynonymTermLength:
+		return fmt.Sprintf("must be at most %d characters", maxSynonymTermLength)
+	}
+	return ""
+}
+
+func (v *ProductValidator) checkSku(sku string) string {
+	switch {
+	case sku == "":
+		return "must not be empty"
+	case utf8.RuneCountInString(sku) > v.Rules.MaxSkuLength:
+		return fmt.Sprintf("must be at most %d characters", v.Rules.MaxSkuLength)
+	case !skuPattern.MatchString(sku):
+		return "may only contain letters, digits, '.', '_' and '-' and must start with a letter or digit"
+	}
+	return ""
+}
+
+func priceViolations(field string, price Money) []FieldViolation {
+	var violations []FieldViolation
+	if !ValidCurrency(price.Currency) {
+		violations = append(violations, FieldViolation{Field: field + ".currency", Description: "must be an ISO 4217 currency code"})
+	}
+	if price.Amount < 0 {
+		violations = append(violations, FieldViolation{Field: field, Description: "must not be negative"})
+	}
+	return violations
+}
+
+func (v *ProductValidator) checkImageURL(image string) string {
+	if len(image) > v.Rules.MaxImageURLLength {
+		return fmt.Sprintf("must be at most %d characters", v.Rules.MaxImageURLLength)
+	}
+	u, err := url.Parse(image)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return "must be an absolute URL"
+	}
+	for _, scheme := range v.Rules.ImageSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("scheme must be one of %s", strings.Join(v.Rules.ImageSchemes, ", "))
+}
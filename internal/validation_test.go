@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductValidator_Validate(t *testing.T) {
+	// given
+	valid := func() *DbProduct {
+		return &DbProduct{
+			Name:        "Test Product",
+			Sku:         "test-sku_1.0",
+			Description: "Test Description",
+			Price:       Money{Amount: 1000, Currency: "USD"},
+			Image:       "https://cdn.example.com/test.jpg",
+		}
+	}
+	tests := []struct {
+		name       string
+		rules      ValidationRules
+		modify     func(p *DbProduct)
+		wantFields []string
+	}{
+		{
+			name:   "Valid product",
+			rules:  DefaultValidationRules(),
+			modify: func(p *DbProduct) {},
+		},
+		{
+			name:   "Product without an image",
+			rules:  DefaultValidationRules(),
+			modify: func(p *DbProduct) { p.Image = "" },
+		},
+		{
+			name:  "All violations are collected",
+			rules: DefaultValidationRules(),
+			modify: func(p *DbProduct) {
+				p.Name = "  "
+				p.Sku = ""
+				p.Price.Amount = -1
+				p.Image = "not a url"
+			},
+			wantFields: []string{"name", "sku", "price", "image"},
+		},
+		{
+			name:       "SKU with forbidden characters",
+			rules:      DefaultValidationRules(),
+			modify:     func(p *DbProduct) { p.Sku = "test sku/1" },
+			wantFields: []string{"sku"},
+		},
+		{
+			name:       "Price without a currency",
+			rules:      DefaultValidationRules(),
+			modify:     func(p *DbProduct) { p.Price.Currency = "" },
+			wantFields: []string{"price.currency"},
+		},
+		{
+			name:  "Additional prices are checked",
+			rules: DefaultValidationRules(),
+			modify: func(p *DbProduct) {
+				p.Prices = []DbProductPrice{{Currency: "EUR", Amount: 900}, {Currency: "eur", Amount: -1}}
+			},
+			wantFields: []string{"prices[1].currency", "prices[1]"},
+		},
+		{
+			name:       "Image with a forbidden scheme",
+			rules:      DefaultValidationRules(),
+			modify:     func(p *DbProduct) { p.Image = "ftp://cdn.example.com/test.jpg" },
+			wantFields: []string{"image"},
+		},
+		{
+			name: "Deployment specific description limit",
+			rules: func() ValidationRules {
+				rules := DefaultValidationRules()
+				rules.MaxDescriptionLength = 10
+				return rules
+			}(),
+			modify:     func(p *DbProduct) { p.Description = strings.Repeat("ы", 11) },
+			wantFields: []string{"description"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := valid()
+			tt.modify(product)
+			//when
+			err := NewProductValidator(tt.rules).Validate(product)
+			//then
+			if len(tt.wantFields) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, KindInvalidArgument, KindOf(err))
+			var fields []string
+			for _, v := range err.(*Error).Violations {
+				fields = append(fields, v.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestProductValidator_OptionViolations(t *testing.T) {
+	// given
+	options := []DbProductOption{
+		{Name: "size", Values: []string{"S", "M", "S"}},
+		{Name: " ", Values: []string{"red"}},
+		{Name: "size", Values: nil},
+		{Name: "color", Values: []string{""}},
+	}
+	//when
+	violations := NewProductValidator(DefaultValidationRules()).OptionViolations(options)
+	//then
+	var fields []string
+	for _, v := range violations {
+		fields = append(fields, v.Field)
+	}
+	assert.Equal(t, []string{"options[0].values[2]", "options[1].name", "options[2].name", "options[2].values", "options[3].values[0]"}, fields)
+}
+
+func TestProductValidator_CategoryViolations(t *testing.T) {
+	validator := NewProductValidator(DefaultValidationRules())
+	assert.Empty(t, validator.CategoryViolations(&DbCategory{Name: "T-Shirts", Slug: "t-shirts-2024"}))
+	for _, slug := range []string{"", "T-Shirts", "t--shirts", "-shirts", "t_shirts", strings.Repeat("a", 256)} {
+		violations := validator.CategoryViolations(&DbCategory{Name: "T-Shirts", Slug: slug})
+		assert.Equal(t, []FieldViolation{{Field: "slug", Description: violations[0].Description}}, violations, slug)
+	}
+}
+
+func TestProductValidator_AttributeViolations(t *testing.T) {
+	tests := []struct {
+		name       string
+		attribute  DbAttribute
+		wantFields []string
+	}{
+		{name: "Valid enum", attribute: DbAttribute{Code: "color", Name: "Color", Type: AttributeEnum, Options: []string{"red", "blue"}}},
+		{name: "Valid bounded int", attribute: DbAttribute{Code: "wattage", Name: "Wattage", Type: AttributeInt, Rules: AttributeRules{Min: "1", Max: "3000"}}},
+		{name: "Malformed code and unknown type", attribute: DbAttribute{Code: "Color", Name: "Color", Type: "colour"}, wantFields: []string{"code", "type"}},
+		{name: "Enum without options", attribute: DbAttribute{Code: "color", Name: "Color", Type: AttributeMultiEnum}, wantFields: []string{"options"}},
+		{name: "Options of a string", attribute: DbAttribute{Code: "material", Name: "Material", Type: AttributeString, Options: []string{"cotton", "cotton"}}, wantFields: []string{"options", "options[1]"}},
+		{name: "Invalid pattern", attribute: DbAttribute{Code: "isbn", Name: "ISBN", Type: AttributeString, Rules: AttributeRules{Pattern: "("}}, wantFields: []string{"rules.pattern"}},
+		{name: "Bounds of a bool", attribute: DbAttribute{Code: "dimmable", Name: "Dimmable", Type: AttributeBool, Rules: AttributeRules{Min: "1"}}, wantFields: []string{"rules"}},
+		{name: "Min above max", attribute: DbAttribute{Code: "weight", Name: "Weight", Type: AttributeDecimal, Rules: AttributeRules{Min: "2.5", Max: "1"}}, wantFields: []string{"rules.max"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			violations := NewProductValidator(DefaultValidationRules()).AttributeViolations(&tt.attribute)
+			//then
+			var fields []string
+			for _, v := range violations {
+				fields = append(fields, v.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestProductValidator_SynonymSetViolations(t *testing.T) {
+	tests := []struct {
+		name       string
+		set        DbSynonymSet
+		wantFields []string
+	}{
+		{name: "Valid equivalent", set: DbSynonymSet{Type: SynonymEquivalent, Terms: []string{"tee", "t-shirt"}}},
+		{name: "Valid one-way", set: DbSynonymSet{Type: SynonymOneWay, Input: "hoodie", Terms: []string{"sweatshirt"}}},
+		{name: "Unknown type", set: DbSynonymSet{Type: "both", Terms: []string{"tee", "t-shirt"}}, wantFields: []string{"type"}},
+		{name: "Equivalent with an input and one term", set: DbSynonymSet{Type: SynonymEquivalent, Input: "tee", Terms: []string{"t-shirt"}}, wantFields: []string{"input", "terms"}},
+		{name: "One-way without input", set: DbSynonymSet{Type: SynonymOneWay, Terms: []string{"sweatshirt"}}, wantFields: []string{"input"}},
+		{name: "Terms without words or with too many", set: DbSynonymSet{Type: SynonymEquivalent, Terms: []string{"--", "short sleeve cotton shirt"}}, wantFields: []string{"terms[0]", "terms[1]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			violations := NewProductValidator(DefaultValidationRules()).SynonymSetViolations(&tt.set)
+			//then
+			var fields []string
+			for _, v := range violations {
+				fields = append(fields, v.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestProductValidator_StopWordViolations(t *testing.T) {
+	validator := NewProductValidator(DefaultValidationRules())
+	assert.Empty(t, validator.StopWordViolations([]string{"the", " A "}))
+	violations := validator.StopWordViolations([]string{"the", "", "of the", strings.Repeat("a", 65)})
+	assert.Equal(t, []string{"words[1]", "words[2]", "words[3]"}, []string{violations[0].Field, violations[1].Field, violations[2].Field})
+	assert.Len(t, validator.StopWordViolations(make([]string, 1001)), 1)
+}
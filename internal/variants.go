@@ -0,0 +1,318 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DbProductOption is an option axis of a parent product, e.g. "size" with the
+// values S, M and L. Every variant of the product picks one value per axis.
+type DbProductOption struct {
+	ID        uint64
+	ProductID uint64   `gorm:"uniqueIndex:idx_product_option"`
+	Name      string   `gorm:"size:64;uniqueIndex:idx_product_option"`
+	Position  int      `gorm:"not null;default:0"`
+	Values    []string `gorm:"serializer:json"`
+}
+
+func (DbProductOption) TableName() string {
+	return "catalog_product_options"
+}
+
+// VariantOptions maps option names of the parent product to the chosen values.
+type VariantOptions map[string]string
+
+// key is a fixed length digest of the options; encoding/json sorts map keys,
+// so equal options always give the same key.
+func (o VariantOptions) key() string {
+	if o == nil {
+		o = VariantOptions{}
+	}
+	encoded, _ := json.Marshal(o)
+	sum := sha1.Sum(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// DbVariant is a sellable variation of a product with its own SKU. Variants
+// are deleted for good so their SKUs and option combinations can be reused.
+type DbVariant struct {
+	ID        uint64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ProductID uint64 `gorm:"uniqueIndex:idx_variant_options"`
+	Sku       string `gorm:"size:255;uniqueIndex"`
+	// Price overrides the base price of the product; unset when Currency is empty.
+	Price   Money `gorm:"embedded;embeddedPrefix:price_"`
+	Image   string
+	Options VariantOptions `gorm:"serializer:json"`
+	// OptionKey enforces a single variant per combination of options.
+	OptionKey string `gorm:"size:40;uniqueIndex:idx_variant_options"`
+}
+
+func (DbVariant) TableName() string {
+	return "catalog_variants"
+}
+
+// HasPrice reports whether the variant overrides the product price.
+func (v *DbVariant) HasPrice() bool {
+	return v.Price.Currency != ""
+}
+
+// BeforeSave keeps SKUs unique across products and variants.
+func (v *DbVariant) BeforeSave(tx *gorm.DB) error {
+	v.OptionKey = v.Options.key()
+	return checkSkuUnused(tx, &DbProduct{}, v.Sku)
+}
+
+// checkSkuUnused fails with gorm.ErrDuplicatedKey when the table of model
+// already uses sku. Soft deleted products free their SKU as the unique index
+// does. The locking read also locks the gap of a missing SKU, so a concurrent
+// insert of the same SKU into the other table waits or deadlocks instead of
+// slipping through.
+func checkSkuUnused(tx *gorm.DB, model interface{}, sku string) error {
+	var count int64
+	err := tx.Session(&gorm.Session{NewDB: true}).Model(model).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("sku = ?", sku).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("sku %q is already used: %w", sku, gorm.ErrDuplicatedKey)
+	}
+	return nil
+}
+
+// ProductVariants is a product together with its option axes and variants.
+type ProductVariants struct {
+	Product  *DbProduct
+	Options  []DbProductOption
+	Variants []*DbVariant
+}
+
+type VariantServiceInterface interface {
+	SetProductOptions(ctx context.Context, productID uint64, options []DbProductOption) error
+	CreateVariant(ctx context.Context, variant *DbVariant) (uint64, error)
+	GetVariantByID(ctx context.Context, id uint64) (*DbVariant, error)
+	UpdateVariant(ctx context.Context, variant *DbVariant) error
+	DeleteVariantByID(ctx context.Context, id uint64) error
+	GetProductVariants(ctx context.Context, productID uint64) (*ProductVariants, error)
+}
+
+type VariantService struct {
+	DB DbWrapper
+	// QueryTimeout bounds every single query, zero disables the limit.
+	QueryTimeout time.Duration
+}
+
+// Replace the option axes of a product. Existing variants must still match
+// the new axes, so axes can only be added or values removed once the
+// variants using them are gone.
+func (v *VariantService) SetProductOptions(ctx context.Context, productID uint64, options []DbProductOption) error {
+	db, ctx, cancel := bindDB(ctx, v.DB, v.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		if err := tx.First(&DbProduct{}, productID).Error; err != nil {
+			return err
+		}
+		var variants []*DbVariant
+		if err := tx.Where("product_id = ?", productID).Order("id").Find(&variants).Error; err != nil {
+			return err
+		}
+		var violations []FieldViolation
+		for _, variant := range variants {
+			for _, violation := range optionViolations(options, variant.Options) {
+				violation.Description = fmt.Sprintf("variant %s: %s", variant.Sku, violation.Description)
+				violations = append(violations, violation)
+			}
+		}
+		if len(violations) > 0 {
+			return InvalidArgumentError("options do not match the existing variants", violations...)
+		}
+		if err := tx.Where("product_id = ?", productID).Delete(&DbProductOption{}).Error; err != nil {
+			return err
+		}
+		if len(options) == 0 {
+			return nil
+		}
+		rows := make([]DbProductOption, len(options))
+		for i, option := range options {
+			rows[i] = DbProductOption{ProductID: productID, Name: option.Name, Position: i, Values: option.Values}
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to set options of a product %d: %w", productID, err), ResourceProduct, productID)
+	}
+	return nil
+}
+
+// Create a new DbVariant after checking it against the options of its product
+func (v *VariantService) CreateVariant(ctx context.Context, variant *DbVariant) (uint64, error) {
+	db, ctx, cancel := bindDB(ctx, v.DB, v.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		if err := checkVariant(tx, variant); err != nil {
+			return err
+		}
+		return tx.Create(variant).Error
+	})
+	if err != nil {
+		return ErrorId, variantError(classifyDbError(ctx, fmt.Errorf("failed to create a variant: %w", err), ResourceVariant, nil), variant)
+	}
+	return variant.ID, nil
+}
+
+// Read a DbVariant by ID
+func (v *VariantService) GetVariantByID(ctx context.Context, id uint64) (*DbVariant, error) {
+	db, ctx, cancel := bindDB(ctx, v.DB, v.QueryTimeout)
+	defer cancel()
+	variant := DbVariant{}
+	result := db.First(&variant, id)
+	if result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get a variant %d: %w", id, result.Error), ResourceVariant, id)
+	}
+	return &variant, nil
+}
+
+// Update a DbVariant. A variant cannot be moved to another product.
+func (v *VariantService) UpdateVariant(ctx context.Context, variant *DbVariant) error {
+	db, ctx, cancel := bindDB(ctx, v.DB, v.QueryTimeout)
+	defer cancel()
+	err := db.Transaction(func(tx DbWrapper) error {
+		existing := DbVariant{}
+		if err := tx.First(&existing, variant.ID).Error; err != nil {
+			return err
+		}
+		variant.ProductID = existing.ProductID
+		variant.CreatedAt = existing.CreatedAt
+		if err := checkVariant(tx, variant); err != nil {
+			return err
+		}
+		return tx.Save(variant).Error
+	})
+	if err != nil {
+		return variantError(classifyDbError(ctx, fmt.Errorf("failed to update a variant %d: %w", variant.ID, err), ResourceVariant, variant.ID), variant)
+	}
+	return nil
+}
+
+// Delete a DbVariant by ID
+func (v *VariantService) DeleteVariantByID(ctx context.Context, id uint64) error {
+	db, ctx, cancel := bindDB(ctx, v.DB, v.QueryTimeout)
+	defer cancel()
+	result := db.Delete(&DbVariant{}, id)
+	if result.Error != nil {
+		return classifyDbError(ctx, fmt.Errorf("failed to delete a variant %d: %w", id, result.Error), ResourceVariant, id)
+	}
+	if result.RowsAffected == 0 {
+		return NotFoundError(ResourceVariant, id)
+	}
+	return nil
+}
+
+// Read a product with its options and variants
+func (v *VariantService) GetProductVariants(ctx context.Context, productID uint64) (*ProductVariants, error) {
+	db, ctx, cancel := bindDB(ctx, v.DB, v.QueryTimeout)
+	defer cancel()
+	out := &ProductVariants{Product: &DbProduct{}}
+	if result := db.First(out.Product, productID); result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get a product %d: %w", productID, result.Error), ResourceProduct, productID)
+	}
+	if result := db.Where("product_id = ?", productID).Order("position").Find(&out.Options); result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get options of a product %d: %w", productID, result.Error), ResourceProduct, productID)
+	}
+	if result := db.Where("product_id = ?", productID).Order("id").Find(&out.Variants); result.Error != nil {
+		return nil, classifyDbError(ctx, fmt.Errorf("failed to get variants of a product %d: %w", productID, result.Error), ResourceProduct, productID)
+	}
+	return out, nil
+}
+
+// checkVariant validates the variant against its product: the product must
+// exist, the options must match its axes and a price override must be in the
+// product currency.
+func checkVariant(tx DbWrapper, variant *DbVariant) error {
+	product := DbProduct{}
+	if err := tx.First(&product, variant.ProductID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFoundError(ResourceProduct, variant.ProductID)
+	} else if err != nil {
+		return err
+	}
+	var options []DbProductOption
+	if err := tx.Where("product_id = ?", variant.ProductID).Order("position").Find(&options).Error; err != nil {
+		return err
+	}
+	violations := optionViolations(options, variant.Options)
+	if variant.HasPrice() && variant.Price.Currency != product.Price.Currency {
+		violations = append(violations, FieldViolation{Field: "price.currency", Description: fmt.Sprintf("must be the product currency %s", product.Price.Currency)})
+	}
+	if len(violations) > 0 {
+		return InvalidArgumentError("invalid variant", violations...)
+	}
+	return nil
+}
+
+// optionViolations checks that values has exactly one allowed value for
+// every option axis.
+func optionViolations(options []DbProductOption, values VariantOptions) []FieldViolation {
+	var violations []FieldViolation
+	known := make(map[string]bool, len(options))
+	for _, option := range options {
+		known[option.Name] = true
+		value, ok := values[option.Name]
+		if !ok {
+			violations = append(violations, FieldViolation{Field: "options." + option.Name, Description: "is required"})
+			continue
+		}
+		if !containsString(option.Values, value) {
+			violations = append(violations, FieldViolation{Field: "options." + option.Name, Description: fmt.Sprintf("%q is not one of %v", value, option.Values)})
+		}
+	}
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		violations = append(violations, FieldViolation{Field: "options." + name, Description: "is not an option of the product"})
+	}
+	return violations
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// variantError explains which unique constraint a duplicate variant hit.
+func variantError(err error, variant *DbVariant) error {
+	var e *Error
+	if !errors.As(err, &e) || e.Kind != KindAlreadyExists {
+		return err
+	}
+	dup := *e
+	if isDuplicateOf(e, "idx_variant_options") {
+		dup.Resource, dup.ID = ResourceProduct, fmt.Sprint(variant.ProductID)
+		dup.Message = "a variant with these options already exists"
+		return &dup
+	}
+	dup.ID = variant.Sku
+	dup.Message = fmt.Sprintf("sku %q is already used", variant.Sku)
+	return &dup
+}
@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariantService_CreateVariant(t *testing.T) {
+	expectProduct := func(sqlMock sqlmock.Sqlmock) {
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products` WHERE `catalog_products`.`id` = ?")).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "price_amount", "price_currency"}).AddRow(1, 1999, "USD"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_product_options` WHERE product_id = ? ORDER BY position")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "name", "position", "values"}).
+				AddRow(1, 1, "size", 0, `["S","M","L"]`).
+				AddRow(2, 1, "color", 1, `["red","blue"]`))
+	}
+
+	t.Run("Variant matching the options is created", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		vs := &VariantService{DB: GormWrapper{DB: db}}
+		expectProduct(sqlMock)
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_products` WHERE sku = ? AND `catalog_products`.`deleted_at` IS NULL FOR UPDATE")).
+			WithArgs("tee-m-red").
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_variants`")).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		sqlMock.ExpectCommit()
+		variant := &DbVariant{ProductID: 1, Sku: "tee-m-red", Price: Money{Amount: 2199, Currency: "USD"}, Options: VariantOptions{"size": "M", "color": "red"}}
+		//when
+		id, err := vs.CreateVariant(context.Background(), variant)
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), id)
+		assert.Equal(t, VariantOptions{"color": "red", "size": "M"}.key(), variant.OptionKey)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Variant with a product SKU is rejected", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		vs := &VariantService{DB: GormWrapper{DB: db}}
+		expectProduct(sqlMock)
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_products` WHERE sku = ? AND `catalog_products`.`deleted_at` IS NULL FOR UPDATE")).
+			WithArgs("tee").
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+		sqlMock.ExpectRollback()
+		//when
+		_, err := vs.CreateVariant(context.Background(), &DbVariant{ProductID: 1, Sku: "tee", Options: VariantOptions{"size": "M", "color": "red"}})
+		//then
+		assert.Equal(t, KindAlreadyExists, KindOf(err))
+		assert.Contains(t, err.(*Error).PublicMessage(), `sku "tee" is already used`)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicate option combination is reported on the product", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		vs := &VariantService{DB: GormWrapper{DB: db}}
+		expectProduct(sqlMock)
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_variants`")).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1-abc' for key 'catalog_variants.idx_variant_options'"})
+		sqlMock.ExpectRollback()
+		//when
+		_, err := vs.CreateVariant(context.Background(), &DbVariant{ProductID: 1, Sku: "tee-m-red-2", Options: VariantOptions{"size": "M", "color": "red"}})
+		//then
+		require.Equal(t, KindAlreadyExists, KindOf(err))
+		assert.Equal(t, ResourceProduct, err.(*Error).Resource)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Options and price currency are checked against the product", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		vs := &VariantService{DB: GormWrapper{DB: db}}
+		expectProduct(sqlMock)
+		sqlMock.ExpectRollback()
+		//when
+		_, err := vs.CreateVariant(context.Background(), &DbVariant{ProductID: 1, Sku: "tee-xl", Price: Money{Amount: 1, Currency: "EUR"}, Options: VariantOptions{"size": "XL", "fit": "slim"}})
+		//then
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, KindInvalidArgument, e.Kind)
+		var fields []string
+		for _, violation := range e.Violations {
+			fields = append(fields, violation.Field)
+		}
+		assert.Equal(t, []string{"options.size", "options.color", "options.fit", "price.currency"}, fields)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestVariantService_SetProductOptions(t *testing.T) {
+	t.Run("Options not matching existing variants are rejected", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		vs := &VariantService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_variants` WHERE product_id = ? ORDER BY id")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "sku", "options"}).AddRow(1, 1, "tee-l", `{"size":"L"}`))
+		sqlMock.ExpectRollback()
+		//when
+		err := vs.SetProductOptions(context.Background(), 1, []DbProductOption{{Name: "size", Values: []string{"S", "M"}}})
+		//then
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		assert.Equal(t, KindInvalidArgument, e.Kind)
+		assert.Equal(t, []FieldViolation{{Field: "options.size", Description: `variant tee-l: "L" is not one of [S M]`}}, e.Violations)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Options are replaced in order", func(t *testing.T) {
+		// given
+		db, sqlMock := newSqlMockDB(t)
+		vs := &VariantService{DB: GormWrapper{DB: db}}
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_products`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `catalog_variants`")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `catalog_product_options` WHERE product_id = ?")).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `catalog_product_options` (`product_id`,`name`,`position`,`values`) VALUES (?,?,?,?),(?,?,?,?)")).
+			WithArgs(1, "size", 0, `["S","M"]`, 1, "color", 1, `["red"]`).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		sqlMock.ExpectCommit()
+		//when
+		err := vs.SetProductOptions(context.Background(), 1, []DbProductOption{{Name: "size", Values: []string{"S", "M"}}, {Name: "color", Values: []string{"red"}}})
+		//then
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestVariantOptions_key(t *testing.T) {
+	assert.Equal(t, VariantOptions{"size": "M", "color": "red"}.key(), VariantOptions{"color": "red", "size": "M"}.key())
+	assert.NotEqual(t, VariantOptions{"size": "M"}.key(), VariantOptions{"size": "L"}.key())
+	assert.Equal(t, VariantOptions(nil).key(), VariantOptions{}.key())
+	assert.Len(t, VariantOptions{"size": "M"}.key(), 40)
+}
@@ -1,12 +1,17 @@
 package main
 
 import (
+	cpb "catalog/gen/go/catalog"
 	"catalog/internal"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
-	"strconv"
+	"os/signal"
+	"syscall"
 	"time"
 
 	pb "github.com/akolpakov-somehash/headless-ecom-protos/gen/go/catalog"
@@ -16,6 +21,9 @@ import (
 	"gorm.io/gorm"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func loadEnv() error {
@@ -55,21 +63,176 @@ func connectDB() (*gorm.DB, error) {
 	return db, nil
 }
 
-func startServer(db *gorm.DB, port int) error {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+// startServer serves gRPC and the HTTP gateway until ctx is canceled and then
+// shuts both down gracefully.
+func startServer(ctx context.Context, db *gorm.DB, cfg *config) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
 	if err != nil {
 		return fmt.Errorf("failed to listen: %v", err)
 	}
 	s := grpc.NewServer()
-	productService := &internal.ProductService{DB: db}
-	pb.RegisterProductInfoServer(s, &internal.Server{ProductService: productService})
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	productService := &internal.ProductService{
+		DB:           internal.GormWrapper{DB: db},
+		QueryTimeout: cfg.QueryTimeout,
+		Index:        internal.NewSearchIndex(),
+		Suggestions:  internal.NewSuggestIndex(),
+		// Changes are served to downstream caches by WatchProductChanges.
+		Changes:                  internal.NewChangeFeed(),
+		ScheduleInterval:         cfg.AvailabilityCheck,
+		IdempotencyTTL:           cfg.IdempotencyTTL,
+		IdempotencyPurgeInterval: cfg.IdempotencyPurge,
+	}
+	// The indexes live in memory, fill them in the background so startup is
+	// not delayed by large catalogs.
+	go func() {
+		indexed, err := productService.RebuildSearchIndex(ctx)
+		if err != nil {
+			log.Printf("failed to build the search indexes: %v", err)
+			return
+		}
+		log.Printf("search indexes built with %d products", indexed)
+	}()
+	go productService.RunScheduler(ctx)
+	go productService.RunIdempotencyPurge(ctx)
+	variantService := &internal.VariantService{
+		DB:           internal.GormWrapper{DB: db},
+		QueryTimeout: cfg.QueryTimeout,
+	}
+	categoryService := &internal.CategoryService{
+		DB:           internal.GormWrapper{DB: db},
+		QueryTimeout: cfg.QueryTimeout,
+	}
+	attributeService := &internal.AttributeService{
+		DB:           internal.GormWrapper{DB: db},
+		QueryTimeout: cfg.QueryTimeout,
+	}
+	// Synonym and stop word edits take effect without a restart, Run also
+	// loads the initial dictionary.
+	dictionaryService := &internal.DictionaryService{
+		DB:              internal.GormWrapper{DB: db},
+		QueryTimeout:    cfg.QueryTimeout,
+		Index:           productService.Index,
+		RefreshInterval: cfg.SearchDictionaryRefresh,
+	}
+	go dictionaryService.Run(ctx)
+	server := &internal.Server{
+		ProductService:    productService,
+		VariantService:    variantService,
+		CategoryService:   categoryService,
+		AttributeService:  attributeService,
+		DictionaryService: dictionaryService,
+		Changes:           productService.Changes,
+		Validator:         internal.NewProductValidator(cfg.ValidationRules),
+		Currency:          cfg.Currency,
+		AdminToken:        cfg.AdminToken,
+	}
+	pb.RegisterProductInfoServer(s, server)
+	cpb.RegisterCatalogServer(s, server)
+	if cfg.Reflection {
+		reflection.Register(s)
+	}
+
+	var httpServer *http.Server
+	var httpLis net.Listener
+	if cfg.HTTPPort > 0 {
+		httpLis, err = net.Listen("tcp", fmt.Sprintf(":%d", cfg.HTTPPort))
+		if err != nil {
+			return fmt.Errorf("failed to listen: %v", err)
+		}
+		httpServer = &http.Server{
+			Handler:           internal.NewGateway(server, cfg.CORS),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to obtain database pool: %v", err)
+	}
+	healthChecker := &internal.DbHealthChecker{
+		DB:       sqlDB,
+		Health:   healthServer,
+		Services: []string{"", pb.ProductInfo_ServiceDesc.ServiceName, cpb.Catalog_ServiceDesc.ServiceName},
+		Interval: cfg.HealthCheckInterval,
+	}
+	go healthChecker.Run(ctx)
+
+	serveErr := make(chan error, 2)
+	go func() {
+		serveErr <- s.Serve(lis)
+	}()
 	log.Printf("server listening at %v", lis.Addr())
-	return s.Serve(lis)
+	if httpServer != nil {
+		go func() {
+			if err := httpServer.Serve(httpLis); !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- err
+			}
+		}()
+		log.Printf("http gateway listening at %v", httpLis.Addr())
+	}
+
+	select {
+	case err = <-serveErr:
+		log.Printf("server stopped unexpectedly: %v", err)
+	case <-ctx.Done():
+	}
+	shutdown(s, httpServer, healthServer, cfg)
+	return err
 }
 
-const (
-	defaultPort = 50051
-)
+// shutdown reports NOT_SERVING so load balancers stop routing new calls,
+// waits for the drain period and then stops the servers, cutting in-flight
+// calls once the shutdown timeout is exceeded.
+func shutdown(s *grpc.Server, httpServer *http.Server, healthServer *health.Server, cfg *config) {
+	log.Printf("shutting down, draining for %v", cfg.DrainPeriod)
+	healthServer.Shutdown()
+	time.Sleep(cfg.DrainPeriod)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// httpStopped is closed once the gateway has finished its requests or
+	// ctx ran out, so the database is not closed under them.
+	httpStopped := make(chan struct{})
+	if httpServer != nil {
+		go func() {
+			defer close(httpStopped)
+			if err := httpServer.Shutdown(ctx); err != nil {
+				log.Printf("http gateway shutdown: %v", err)
+				httpServer.Close()
+			}
+		}()
+	} else {
+		close(httpStopped)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		log.Printf("server stopped")
+	case <-ctx.Done():
+		log.Printf("graceful stop timed out after %v, closing remaining connections", cfg.ShutdownTimeout)
+		s.Stop()
+	}
+	<-httpStopped
+}
+
+func closeDB(db *gorm.DB) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Printf("failed to obtain database pool: %v", err)
+		return
+	}
+	if err := sqlDB.Close(); err != nil {
+		log.Printf("failed to close database pool: %v", err)
+	}
+}
 
 func main() {
 	err := loadEnv()
@@ -77,25 +240,29 @@ func main() {
 		log.Fatalf("failed to load environment variables: %v", err)
 	}
 
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
 	db, err := connectDB()
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 
-	err = db.AutoMigrate(&internal.DbProduct{})
+	err = internal.Migrate(db, cfg.Currency)
 	if err != nil {
 		log.Fatalf("failed to migrate database: %v", err)
 	}
 
-	port := defaultPort
-	if p, ok := os.LookupEnv("PORT"); ok {
-		port, err = strconv.Atoi(p)
-		if err != nil {
-			log.Fatalf("invalid port number: %v", err)
-		}
-	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop() // a second signal terminates immediately
+	}()
 
-	err = startServer(db, port)
+	err = startServer(ctx, db, cfg)
+	closeDB(db)
 	if err != nil {
 		log.Fatalf("failed to start server: %v", err)
 	}